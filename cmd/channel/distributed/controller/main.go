@@ -73,6 +73,12 @@ func main() {
 	// Create A ResetOffset ControllerConstructor Factory With Custom Subscription Ref Mapping
 	resetOffsetControllerConstructor := resetoffset.NewControllerFactory(subscriptionRefMapperFactory, connectionPool)
 
+	// Create A ConsumerGroupSnapshot ControllerConstructor Factory With The Same Subscription Ref Mapping
+	snapshotControllerConstructor := resetoffset.NewSnapshotControllerFactory(subscriptionRefMapperFactory)
+
+	// Create A ConsumerGroupPause ControllerConstructor Factory Sharing The ConnectionPool With The ResetOffset Controller
+	pauseControllerConstructor := resetoffset.NewPauseControllerFactory(subscriptionRefMapperFactory, connectionPool)
+
 	// Create The SharedMain Instance With The Various Controllers
-	sharedmain.MainWithContext(ctx, constants.ControllerComponentName, kafkachannel.NewController, resetOffsetControllerConstructor)
+	sharedmain.MainWithContext(ctx, constants.ControllerComponentName, kafkachannel.NewController, resetOffsetControllerConstructor, snapshotControllerConstructor, pauseControllerConstructor)
 }
@@ -47,6 +47,7 @@ import (
 	dispatcherhealth "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/health"
 	kafkaclientset "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	"knative.dev/eventing-kafka/pkg/client/informers/externalversions"
+	"knative.dev/eventing-kafka/pkg/common/client"
 	commonconstants "knative.dev/eventing-kafka/pkg/common/constants"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	"knative.dev/eventing-kafka/pkg/common/kafka/sarama"
@@ -103,6 +104,17 @@ func main() {
 		logger.Fatal("Failed To Verify Configuration Settings", zap.Error(err))
 	}
 
+	// Override The ConfigMap Isolation Level With The KafkaChannel One, If Specified
+	if environment.IsolationLevel != "" {
+		ekConfig.Sarama.Config, err = client.NewConfigBuilder().
+			WithExisting(ekConfig.Sarama.Config).
+			WithIsolationLevel(environment.IsolationLevel).
+			Build(ctx)
+		if err != nil {
+			logger.Fatal("Failed To Apply The KafkaChannel Isolation Level", zap.Error(err))
+		}
+	}
+
 	// Enable Sarama Logging If Specified In ConfigMap
 	sarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
 
@@ -152,6 +164,7 @@ func main() {
 		StatsReporter:   statsReporter,
 		MetricsRegistry: ekConfig.Sarama.Config.MetricRegistry,
 		SaramaConfig:    ekConfig.Sarama.Config,
+		KubeClient:      k8sClient,
 	}
 	dispatcher, managerEvents := dispatch.NewDispatcher(dispatcherConfig, controlProtocolServer, func(ref types.NamespacedName) {})
 
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+var pauseCondSet = apis.NewLivingConditionSet(
+	ConsumerGroupPauseConditionRefMapped,
+	ConsumerGroupPauseConditionConsumerGroupsSynchronized)
+
+var pauseCondSetLock = sync.RWMutex{}
+
+const (
+	// ConsumerGroupPauseConditionReady has status True when all sub-conditions below have been set to True.
+	ConsumerGroupPauseConditionReady = apis.ConditionReady
+
+	// ConsumerGroupPauseConditionRefMapped has status True when the ConsumerGroupPause.Spec.Ref
+	// has been successfully mapped to the corresponding Kafka Topic name(s) and ConsumerGroup ID(s).
+	ConsumerGroupPauseConditionRefMapped apis.ConditionType = "RefMapped"
+
+	// ConsumerGroupPauseConditionConsumerGroupsSynchronized has status True when every mapped
+	// ConsumerGroup has been stopped or started in accordance with the current pause windows.
+	ConsumerGroupPauseConditionConsumerGroupsSynchronized apis.ConditionType = "ConsumerGroupsSynchronized"
+)
+
+// RegisterAlternateConsumerGroupPauseConditionSet register a different apis.ConditionSet.
+func RegisterAlternateConsumerGroupPauseConditionSet(conditionSet apis.ConditionSet) {
+	pauseCondSetLock.Lock()
+	defer pauseCondSetLock.Unlock()
+	pauseCondSet = conditionSet
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*ConsumerGroupPause) GetConditionSet() apis.ConditionSet {
+	pauseCondSetLock.RLock()
+	defer pauseCondSetLock.RUnlock()
+	return pauseCondSet
+}
+
+// GetConditionSet retrieves the condition set for this resource.
+func (*ConsumerGroupPauseStatus) GetConditionSet() apis.ConditionSet {
+	pauseCondSetLock.RLock()
+	defer pauseCondSetLock.RUnlock()
+	return pauseCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (cgps *ConsumerGroupPauseStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return cgps.GetConditionSet().Manage(cgps).GetCondition(t)
+}
+
+// IsReady returns true if the ConsumerGroups are synchronized with the current pause windows.
+func (cgps *ConsumerGroupPauseStatus) IsReady() bool {
+	return cgps.GetConditionSet().Manage(cgps).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (cgps *ConsumerGroupPauseStatus) InitializeConditions() {
+	cgps.GetConditionSet().Manage(cgps).InitializeConditions()
+}
+
+// MarkRefMappedFailed sets the RefMapped condition to False with the specified reason and message.
+func (cgps *ConsumerGroupPauseStatus) MarkRefMappedFailed(reason, messageFormat string, messageA ...interface{}) {
+	cgps.GetConditionSet().Manage(cgps).MarkFalse(ConsumerGroupPauseConditionRefMapped, reason, messageFormat, messageA...)
+}
+
+// MarkRefMappedTrue sets the RefMapped condition to True.
+func (cgps *ConsumerGroupPauseStatus) MarkRefMappedTrue() {
+	cgps.GetConditionSet().Manage(cgps).MarkTrue(ConsumerGroupPauseConditionRefMapped)
+}
+
+// MarkConsumerGroupsSynchronizedFailed sets the ConsumerGroupsSynchronized condition to False with the specified reason and message.
+func (cgps *ConsumerGroupPauseStatus) MarkConsumerGroupsSynchronizedFailed(reason, messageFormat string, messageA ...interface{}) {
+	cgps.GetConditionSet().Manage(cgps).MarkFalse(ConsumerGroupPauseConditionConsumerGroupsSynchronized, reason, messageFormat, messageA...)
+}
+
+// MarkConsumerGroupsSynchronizedTrue sets the ConsumerGroupsSynchronized condition to True.
+func (cgps *ConsumerGroupPauseStatus) MarkConsumerGroupsSynchronizedTrue() {
+	cgps.GetConditionSet().Manage(cgps).MarkTrue(ConsumerGroupPauseConditionConsumerGroupsSynchronized)
+}
+
+// IsPaused returns true if the ConsumerGroups are currently paused.
+func (cgps *ConsumerGroupPauseStatus) IsPaused() bool {
+	return cgps.Paused
+}
+
+// SetPaused sets whether the ConsumerGroups are currently paused.
+func (cgps *ConsumerGroupPauseStatus) SetPaused(paused bool) {
+	cgps.Paused = paused
+}
+
+// GetWindowEnd returns the time at which the currently active pause window(s) will end.
+func (cgps *ConsumerGroupPauseStatus) GetWindowEnd() *metav1.Time {
+	return cgps.WindowEnd
+}
+
+// SetWindowEnd sets the time at which the currently active pause window(s) will end.
+func (cgps *ConsumerGroupPauseStatus) SetWindowEnd(windowEnd *metav1.Time) {
+	cgps.WindowEnd = windowEnd
+}
+
+// GetNextWindowStart returns the time at which the next pause window will begin.
+func (cgps *ConsumerGroupPauseStatus) GetNextWindowStart() *metav1.Time {
+	return cgps.NextWindowStart
+}
+
+// SetNextWindowStart sets the time at which the next pause window will begin.
+func (cgps *ConsumerGroupPauseStatus) SetNextWindowStart(nextWindowStart *metav1.Time) {
+	cgps.NextWindowStart = nextWindowStart
+}
+
+// GetGroups returns the PausedGroups managed by the ConsumerGroupPause.
+func (cgps *ConsumerGroupPauseStatus) GetGroups() []PausedGroup {
+	return cgps.Groups
+}
+
+// SetGroups sets the PausedGroups managed by the ConsumerGroupPause.
+func (cgps *ConsumerGroupPauseStatus) SetGroups(pausedGroups []PausedGroup) {
+	cgps.Groups = pausedGroups
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestConsumerGroupPause_GetConditionSet(t *testing.T) {
+	pause := &ConsumerGroupPause{}
+	if got, want := pause.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestConsumerGroupPauseStatus_InitializeConditions(t *testing.T) {
+	status := &ConsumerGroupPauseStatus{}
+	status.InitializeConditions()
+	wantStatus := &ConsumerGroupPauseStatus{
+		Status: duckv1.Status{
+			Conditions: []apis.Condition{
+				{Type: ConsumerGroupPauseConditionConsumerGroupsSynchronized, Status: corev1.ConditionUnknown},
+				{Type: ConsumerGroupPauseConditionReady, Status: corev1.ConditionUnknown},
+				{Type: ConsumerGroupPauseConditionRefMapped, Status: corev1.ConditionUnknown},
+			},
+		},
+	}
+	ignoreAllButTypeAndStatus := cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime", "Message", "Reason", "Severity")
+	if diff := cmp.Diff(wantStatus, status, ignoreAllButTypeAndStatus); diff != "" {
+		t.Errorf("unexpected conditions (-want, +got) = %v", diff)
+	}
+}
+
+func TestConsumerGroupPauseStatus_IsReady(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		markRefMapped    bool
+		markSynchronized bool
+		markFailed       bool
+		wantReady        bool
+	}{
+		{name: "initialized"},
+		{name: "ref mapped", markRefMapped: true},
+		{name: "ref mapped and synchronized", markRefMapped: true, markSynchronized: true, wantReady: true},
+		{name: "ref mapped and synchronization failed", markRefMapped: true, markFailed: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &ConsumerGroupPauseStatus{}
+			status.InitializeConditions()
+			if test.markRefMapped {
+				status.MarkRefMappedTrue()
+			}
+			if test.markSynchronized {
+				status.MarkConsumerGroupsSynchronizedTrue()
+			}
+			if test.markFailed {
+				status.MarkConsumerGroupsSynchronizedFailed("TestReason", "test message")
+				assert.Equal(t, corev1.ConditionFalse, status.GetCondition(ConsumerGroupPauseConditionConsumerGroupsSynchronized).Status)
+			}
+			assert.Equal(t, test.wantReady, status.IsReady())
+		})
+	}
+}
+
+func TestConsumerGroupPauseStatus_MarkRefMappedFailed(t *testing.T) {
+	status := &ConsumerGroupPauseStatus{}
+	status.InitializeConditions()
+	status.MarkRefMappedFailed("TestReason", "test message %d", 1)
+	condition := status.GetCondition(ConsumerGroupPauseConditionRefMapped)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "TestReason", condition.Reason)
+	assert.Equal(t, "test message 1", condition.Message)
+}
+
+func TestConsumerGroupPauseStatus_Paused(t *testing.T) {
+	pause := ConsumerGroupPause{}
+	assert.False(t, pause.Status.IsPaused())
+	pause.Status.SetPaused(true)
+	assert.True(t, pause.Status.IsPaused())
+}
+
+func TestConsumerGroupPauseStatus_WindowTimes(t *testing.T) {
+	windowEnd := metav1.Now()
+	nextWindowStart := metav1.Now()
+	pause := ConsumerGroupPause{}
+	assert.Nil(t, pause.Status.GetWindowEnd())
+	assert.Nil(t, pause.Status.GetNextWindowStart())
+	pause.Status.SetWindowEnd(&windowEnd)
+	pause.Status.SetNextWindowStart(&nextWindowStart)
+	assert.Equal(t, &windowEnd, pause.Status.GetWindowEnd())
+	assert.Equal(t, &nextWindowStart, pause.Status.GetNextWindowStart())
+}
+
+func TestConsumerGroupPauseStatus_Groups(t *testing.T) {
+	groups := []PausedGroup{
+		{Topic: "test-topic-name", Group: "test-group-id-1"},
+		{Topic: "test-topic-name", Group: "test-group-id-2"},
+	}
+	pause := ConsumerGroupPause{}
+	assert.Nil(t, pause.Status.GetGroups())
+	pause.Status.SetGroups(groups)
+	assert.Equal(t, groups, pause.Status.GetGroups())
+}
+
+func TestRegisterAlternateConsumerGroupPauseConditionSet(t *testing.T) {
+	originalConditionSet := pauseCondSet
+	defer RegisterAlternateConsumerGroupPauseConditionSet(originalConditionSet)
+	conditionSet := apis.NewLivingConditionSet(apis.ConditionReady, "test")
+	RegisterAlternateConsumerGroupPauseConditionSet(conditionSet)
+	pause := ConsumerGroupPause{}
+	assert.Equal(t, conditionSet, pause.GetConditionSet())
+	assert.Equal(t, conditionSet, pause.Status.GetConditionSet())
+}
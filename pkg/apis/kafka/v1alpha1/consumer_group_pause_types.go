@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsumerGroupPause is a resource representing a recurring schedule of time windows during
+// which the Kafka ConsumerGroup(s) related to a specific Kafka resource (Subscription,
+// KafkaChannel, etc.) are to be stopped.  The ConsumerGroups are re-started when the
+// window ends, or when the ConsumerGroupPause is deleted.
+type ConsumerGroupPause struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the ConsumerGroupPause.
+	Spec ConsumerGroupPauseSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the ConsumerGroupPause.
+	// This data may be out of date.
+	// +optional
+	Status ConsumerGroupPauseStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that this resource can be validated and defaulted.
+	_ apis.Validatable = (*ConsumerGroupPause)(nil)
+	_ apis.Defaultable = (*ConsumerGroupPause)(nil)
+
+	_ runtime.Object = (*ConsumerGroupPause)(nil)
+
+	// Check that we can create OwnerReferences to an this resource.
+	_ kmeta.OwnerRefable = (*ConsumerGroupPause)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*ConsumerGroupPause)(nil)
+)
+
+// ConsumerGroupPauseSpec defines the specification for a ConsumerGroupPause.
+type ConsumerGroupPauseSpec struct {
+
+	// Ref is a KReference specifying the Knative resource, related to one or more Kafka
+	// ConsumerGroups, which are to be paused.  The supported values are the same as those
+	// of ResetOffsetSpec.Ref for the Controller in question.
+	Ref duckv1.KReference `json:"ref"`
+
+	// Windows is an array of PauseWindow structs defining when the ConsumerGroups are to be
+	// paused.  The ConsumerGroups are paused while any of the windows is active.
+	Windows []PauseWindow `json:"windows"`
+}
+
+// PauseWindow defines a single recurring pause window.
+type PauseWindow struct {
+
+	// Schedule is a standard (five field) cron expression, such as "0 2 * * *", defining the
+	// start of each occurrence of the window.  Descriptors such as "@daily" are supported,
+	// as is a "CRON_TZ=<zone>" prefix.  The schedule is evaluated in UTC by default.
+	Schedule string `json:"schedule"`
+
+	// Duration is a Go duration string, such as "30m" or "1h30m", defining the length of
+	// each occurrence of the window.
+	Duration string `json:"duration"`
+}
+
+// ParseSchedule parses the PauseWindow's Schedule into a cron.Schedule.
+func (pw *PauseWindow) ParseSchedule() (cron.Schedule, error) {
+	return cron.ParseStandard(pw.Schedule)
+}
+
+// ParseDuration parses the PauseWindow's Duration, which must be positive.
+func (pw *PauseWindow) ParseDuration() (time.Duration, error) {
+	duration, err := time.ParseDuration(pw.Duration)
+	if err != nil {
+		return 0, err
+	} else if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", pw.Duration)
+	}
+	return duration, nil
+}
+
+// ConsumerGroupPauseStatus represents the current state of a ConsumerGroupPause.
+type ConsumerGroupPauseStatus struct {
+
+	// Paused is true while a pause window is active and the ConsumerGroups have been stopped.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// WindowEnd is the time at which the currently active pause window(s) will end.
+	// +optional
+	WindowEnd *metav1.Time `json:"windowEnd,omitempty"`
+
+	// NextWindowStart is the time at which the next pause window will begin.
+	// +optional
+	NextWindowStart *metav1.Time `json:"nextWindowStart,omitempty"`
+
+	// Groups is an array of PausedGroup structs which represent the Kafka ConsumerGroups
+	// associated with the ConsumerGroupPauseSpec.Ref
+	// +optional
+	Groups []PausedGroup `json:"groups,omitempty"`
+
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	// * Annotations - optional status information to be conveyed to users.
+	duckv1.Status `json:",inline"`
+}
+
+// PausedGroup identifies a single Kafka ConsumerGroup / Topic managed by a ConsumerGroupPause.
+type PausedGroup struct {
+
+	// Topic is a string representing the Kafka Topic name of the ConsumerGroup.
+	Topic string `json:"topic"`
+
+	// Group is a string representing the Kafka ConsumerGroup ID.
+	Group string `json:"group"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsumerGroupPauseList is a collection of ConsumerGroupPauses.
+type ConsumerGroupPauseList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroupPause `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for ConsumerGroupPause
+func (cgp *ConsumerGroupPause) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ConsumerGroupPause")
+}
+
+// GetStatus retrieves the duck status for this resource. Implements the KRShaped interface.
+func (cgp *ConsumerGroupPause) GetStatus() *duckv1.Status {
+	return &cgp.Status.Status
+}
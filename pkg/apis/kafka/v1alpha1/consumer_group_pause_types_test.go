@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestConsumerGroupPause_GetGroupVersionKind(t *testing.T) {
+	pause := ConsumerGroupPause{}
+	gvk := pause.GetGroupVersionKind()
+	if gvk.Kind != "ConsumerGroupPause" {
+		t.Errorf("Should be 'ConsumerGroupPause'.")
+	}
+}
+
+func TestConsumerGroupPause_GetStatus(t *testing.T) {
+	status := &duckv1.Status{}
+	pause := ConsumerGroupPause{
+		Status: ConsumerGroupPauseStatus{
+			Status: *status,
+		},
+	}
+	if !cmp.Equal(pause.GetStatus(), status) {
+		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", pause.GetStatus(), status)
+	}
+}
+
+func TestPauseWindow_ParseSchedule(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		schedule string
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{name: "standard", schedule: "30 2 * * *", wantNext: time.Date(2021, 5, 5, 2, 30, 0, 0, time.UTC)},
+		{name: "descriptor", schedule: "@hourly", wantNext: time.Date(2021, 5, 4, 6, 0, 0, 0, time.UTC)},
+		{name: "timezone", schedule: "CRON_TZ=America/New_York 0 0 * * *", wantNext: time.Date(2021, 5, 5, 4, 0, 0, 0, time.UTC)},
+		{name: "seconds field", schedule: "0 30 2 * * *", wantErr: true},
+		{name: "invalid", schedule: "foo", wantErr: true},
+	}
+
+	now := time.Date(2021, 5, 4, 5, 4, 1, 0, time.UTC)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pauseWindow := &PauseWindow{Schedule: test.schedule}
+			schedule, err := pauseWindow.ParseSchedule()
+			if test.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.True(t, test.wantNext.Equal(schedule.Next(now)), "next=%v", schedule.Next(now))
+			}
+		})
+	}
+}
+
+func TestPauseWindow_ParseDuration(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		duration string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "valid", duration: "1h30m", want: 90 * time.Minute},
+		{name: "zero", duration: "0s", wantErr: true},
+		{name: "negative", duration: "-5m", wantErr: true},
+		{name: "invalid", duration: "foo", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pauseWindow := &PauseWindow{Duration: test.duration}
+			duration, err := pauseWindow.ParseDuration()
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.want, duration)
+		})
+	}
+}
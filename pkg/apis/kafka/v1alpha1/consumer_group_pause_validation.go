@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
+)
+
+// Validate verifies the ConsumerGroupPause and returns errors for any invalid fields.
+func (cgp *ConsumerGroupPause) Validate(ctx context.Context) *apis.FieldError {
+	errs := cgp.Spec.Validate(ctx).ViaField("spec")
+
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*ConsumerGroupPause)
+		errs = errs.Also(cgp.CheckImmutableFields(ctx, original))
+	}
+
+	return errs
+}
+
+// Validate verifies the ConsumerGroupPauseSpec and returns errors for an invalid fields.
+func (cgps *ConsumerGroupPauseSpec) Validate(ctx context.Context) *apis.FieldError {
+
+	// Validate The Ref KReference Basics (Kafka Topic relation which is expected to be done in Controllers!)
+	errs := cgps.Ref.Validate(ctx)
+
+	// Validate The Pause Windows
+	if len(cgps.Windows) == 0 {
+		errs = errs.Also(apis.ErrMissingField("windows"))
+	}
+	for index := range cgps.Windows {
+		errs = errs.Also(cgps.Windows[index].Validate(ctx).ViaFieldIndex("windows", index))
+	}
+
+	return errs
+}
+
+// Validate verifies the PauseWindow and returns errors for an invalid fields.
+func (pw *PauseWindow) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if pw.Schedule == "" {
+		errs = errs.Also(apis.ErrMissingField("schedule"))
+	} else if _, err := pw.ParseSchedule(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(pw.Schedule, "schedule"))
+	}
+	if pw.Duration == "" {
+		errs = errs.Also(apis.ErrMissingField("duration"))
+	} else if _, err := pw.ParseDuration(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(pw.Duration, "duration"))
+	}
+	return errs
+}
+
+// CheckImmutableFields verifies the Ref has not been changed from the original.  The Windows
+// may be changed at any time, but changing the Ref would strand any currently paused ConsumerGroups.
+func (cgp *ConsumerGroupPause) CheckImmutableFields(_ context.Context, original *ConsumerGroupPause) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	if diff, err := kmp.ShortDiff(original.Spec.Ref, cgp.Spec.Ref); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff ConsumerGroupPause",
+			Paths:   []string{"spec.ref"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec.ref"},
+			Details: diff,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestConsumerGroupPause_Validate(t *testing.T) {
+
+	reference := duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName}
+
+	tests := []struct {
+		name  string
+		pause *ConsumerGroupPause
+		want  *apis.FieldError
+	}{
+		{
+			name: "valid",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{
+					Ref:     reference,
+					Windows: []PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}, {Schedule: "@weekly", Duration: "30m"}},
+				},
+			},
+		},
+		{
+			name: "invalid ref nil",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{Windows: []PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}}},
+			},
+			want: apis.ErrMissingField("spec.apiVersion", "spec.kind", "spec.name"),
+		},
+		{
+			name: "invalid windows missing",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{Ref: reference},
+			},
+			want: apis.ErrMissingField("spec.windows"),
+		},
+		{
+			name: "invalid window fields missing",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{Ref: reference, Windows: []PauseWindow{{}}},
+			},
+			want: apis.ErrMissingField("spec.windows[0].schedule", "spec.windows[0].duration"),
+		},
+		{
+			name: "invalid window schedule",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{
+					Ref:     reference,
+					Windows: []PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}, {Schedule: "foo", Duration: "1h"}},
+				},
+			},
+			want: apis.ErrInvalidValue("foo", "spec.windows[1].schedule"),
+		},
+		{
+			name: "invalid window duration",
+			pause: &ConsumerGroupPause{
+				Spec: ConsumerGroupPauseSpec{
+					Ref:     reference,
+					Windows: []PauseWindow{{Schedule: "0 2 * * *", Duration: "-1h"}},
+				},
+			},
+			want: apis.ErrInvalidValue("-1h", "spec.windows[0].duration"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.pause.Validate(context.Background())
+			if test.want == nil {
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Errorf("validate (-want, +got) = %v", diff)
+				}
+			} else if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestConsumerGroupPauseImmutability(t *testing.T) {
+	original := &ConsumerGroupPause{
+		Spec: ConsumerGroupPauseSpec{
+			Ref:     duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+			Windows: []PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}},
+		},
+	}
+
+	// Changing The Windows Is Permitted
+	updated := original.DeepCopy()
+	updated.Spec.Windows[0].Duration = "2h"
+	ctx := apis.WithinUpdate(context.Background(), original)
+	if got := updated.Validate(ctx); got != nil {
+		t.Errorf("expected nil for updated windows, got %v", got)
+	}
+
+	// Changing The Ref Is Not
+	updated = original.DeepCopy()
+	updated.Spec.Ref.Name = "FOO"
+	want := &apis.FieldError{
+		Message: "Immutable fields changed (-old +new)",
+		Paths:   []string{"spec.ref"},
+		Details: fmt.Sprintf("{v1.KReference}.Name:\n\t-: \"%s\"\n\t+: \"%s\"\n", refName, "FOO"),
+	}
+	got := updated.Validate(ctx)
+	if diff := cmp.Diff(want.Error(), got.Error()); diff != "" {
+		t.Errorf("validate (-want, +got) = %v", diff)
+	}
+	if got := original.CheckImmutableFields(context.Background(), nil); got != nil {
+		t.Errorf("expected nil for nil original, got %v", got)
+	}
+}
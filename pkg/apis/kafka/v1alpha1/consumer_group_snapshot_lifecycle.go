@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+var snapshotCondSet = apis.NewBatchConditionSet(
+	ConsumerGroupSnapshotConditionRefMapped,
+	ConsumerGroupSnapshotConditionOffsetsCaptured)
+
+var snapshotCondSetLock = sync.RWMutex{}
+
+const (
+	// ConsumerGroupSnapshotConditionSucceeded has status True when all sub-conditions below have been set to True.
+	ConsumerGroupSnapshotConditionSucceeded = apis.ConditionSucceeded
+
+	// ConsumerGroupSnapshotConditionRefMapped has status True when the ConsumerGroupSnapshot.Spec.Ref
+	// has been successfully mapped to the corresponding Kafka Topic name(s) and ConsumerGroup ID(s).
+	ConsumerGroupSnapshotConditionRefMapped apis.ConditionType = "RefMapped"
+
+	// ConsumerGroupSnapshotConditionOffsetsCaptured has status True when the committed offsets
+	// of each Partition of every mapped ConsumerGroup have been captured in the Status.
+	ConsumerGroupSnapshotConditionOffsetsCaptured apis.ConditionType = "OffsetsCaptured"
+)
+
+// RegisterAlternateConsumerGroupSnapshotConditionSet register a different apis.ConditionSet.
+func RegisterAlternateConsumerGroupSnapshotConditionSet(conditionSet apis.ConditionSet) {
+	snapshotCondSetLock.Lock()
+	defer snapshotCondSetLock.Unlock()
+	snapshotCondSet = conditionSet
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*ConsumerGroupSnapshot) GetConditionSet() apis.ConditionSet {
+	snapshotCondSetLock.RLock()
+	defer snapshotCondSetLock.RUnlock()
+	return snapshotCondSet
+}
+
+// GetConditionSet retrieves the condition set for this resource.
+func (*ConsumerGroupSnapshotStatus) GetConditionSet() apis.ConditionSet {
+	snapshotCondSetLock.RLock()
+	defer snapshotCondSetLock.RUnlock()
+	return snapshotCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (cgss *ConsumerGroupSnapshotStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return cgss.GetConditionSet().Manage(cgss).GetCondition(t)
+}
+
+// IsSucceeded returns true if the ConsumerGroupSnapshot has captured all offsets successfully.
+func (cgss *ConsumerGroupSnapshotStatus) IsSucceeded() bool {
+	return cgss.GetConditionSet().Manage(cgss).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (cgss *ConsumerGroupSnapshotStatus) InitializeConditions() {
+	cgss.GetConditionSet().Manage(cgss).InitializeConditions()
+}
+
+// MarkRefMappedFailed sets the RefMapped condition to False with the specified reason and message.
+func (cgss *ConsumerGroupSnapshotStatus) MarkRefMappedFailed(reason, messageFormat string, messageA ...interface{}) {
+	cgss.GetConditionSet().Manage(cgss).MarkFalse(ConsumerGroupSnapshotConditionRefMapped, reason, messageFormat, messageA...)
+}
+
+// MarkRefMappedTrue sets the RefMapped condition to True.
+func (cgss *ConsumerGroupSnapshotStatus) MarkRefMappedTrue() {
+	cgss.GetConditionSet().Manage(cgss).MarkTrue(ConsumerGroupSnapshotConditionRefMapped)
+}
+
+// MarkOffsetsCapturedFailed sets the OffsetsCaptured condition to False with the specified reason and message.
+func (cgss *ConsumerGroupSnapshotStatus) MarkOffsetsCapturedFailed(reason, messageFormat string, messageA ...interface{}) {
+	cgss.GetConditionSet().Manage(cgss).MarkFalse(ConsumerGroupSnapshotConditionOffsetsCaptured, reason, messageFormat, messageA...)
+}
+
+// MarkOffsetsCapturedTrue sets the OffsetsCaptured condition to True.
+func (cgss *ConsumerGroupSnapshotStatus) MarkOffsetsCapturedTrue() {
+	cgss.GetConditionSet().Manage(cgss).MarkTrue(ConsumerGroupSnapshotConditionOffsetsCaptured)
+}
+
+// GetSnapshotTime returns the time at which the offsets were captured.
+func (cgss *ConsumerGroupSnapshotStatus) GetSnapshotTime() *metav1.Time {
+	return cgss.SnapshotTime
+}
+
+// SetSnapshotTime sets the time at which the offsets were captured.
+func (cgss *ConsumerGroupSnapshotStatus) SetSnapshotTime(snapshotTime *metav1.Time) {
+	cgss.SnapshotTime = snapshotTime
+}
+
+// GetGroups returns the captured ConsumerGroupOffsets.
+func (cgss *ConsumerGroupSnapshotStatus) GetGroups() []ConsumerGroupOffsets {
+	return cgss.Groups
+}
+
+// SetGroups sets the captured ConsumerGroupOffsets.
+func (cgss *ConsumerGroupSnapshotStatus) SetGroups(consumerGroupOffsets []ConsumerGroupOffsets) {
+	cgss.Groups = consumerGroupOffsets
+}
+
+// GetGroupOffsets returns the captured ConsumerGroupOffsets for the specified Topic / ConsumerGroup, or nil.
+func (cgss *ConsumerGroupSnapshotStatus) GetGroupOffsets(topic string, group string) *ConsumerGroupOffsets {
+	for index := range cgss.Groups {
+		if cgss.Groups[index].Topic == topic && cgss.Groups[index].Group == group {
+			return &cgss.Groups[index]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestConsumerGroupSnapshot_GetConditionSet(t *testing.T) {
+	snapshot := &ConsumerGroupSnapshot{}
+	if got, want := snapshot.GetConditionSet().GetTopLevelConditionType(), apis.ConditionSucceeded; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestConsumerGroupSnapshotStatus_InitializeConditions(t *testing.T) {
+	status := &ConsumerGroupSnapshotStatus{}
+	status.InitializeConditions()
+	wantStatus := &ConsumerGroupSnapshotStatus{
+		Status: duckv1.Status{
+			Conditions: []apis.Condition{
+				{Type: ConsumerGroupSnapshotConditionOffsetsCaptured, Status: corev1.ConditionUnknown},
+				{Type: ConsumerGroupSnapshotConditionRefMapped, Status: corev1.ConditionUnknown},
+				{Type: ConsumerGroupSnapshotConditionSucceeded, Status: corev1.ConditionUnknown},
+			},
+		},
+	}
+	ignoreAllButTypeAndStatus := cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime", "Message", "Reason", "Severity")
+	if diff := cmp.Diff(wantStatus, status, ignoreAllButTypeAndStatus); diff != "" {
+		t.Errorf("unexpected conditions (-want, +got) = %v", diff)
+	}
+}
+
+func TestConsumerGroupSnapshotStatus_IsSucceeded(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		markRefMapped bool
+		markCaptured  bool
+		markFailed    bool
+		wantSucceeded bool
+	}{
+		{name: "initialized"},
+		{name: "ref mapped", markRefMapped: true},
+		{name: "ref mapped and offsets captured", markRefMapped: true, markCaptured: true, wantSucceeded: true},
+		{name: "ref mapped and capture failed", markRefMapped: true, markFailed: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &ConsumerGroupSnapshotStatus{}
+			status.InitializeConditions()
+			if test.markRefMapped {
+				status.MarkRefMappedTrue()
+			}
+			if test.markCaptured {
+				status.MarkOffsetsCapturedTrue()
+			}
+			if test.markFailed {
+				status.MarkOffsetsCapturedFailed("TestReason", "test message")
+				assert.Equal(t, corev1.ConditionFalse, status.GetCondition(ConsumerGroupSnapshotConditionOffsetsCaptured).Status)
+			}
+			assert.Equal(t, test.wantSucceeded, status.IsSucceeded())
+		})
+	}
+}
+
+func TestConsumerGroupSnapshotStatus_MarkRefMappedFailed(t *testing.T) {
+	status := &ConsumerGroupSnapshotStatus{}
+	status.InitializeConditions()
+	status.MarkRefMappedFailed("TestReason", "test message %d", 1)
+	condition := status.GetCondition(ConsumerGroupSnapshotConditionRefMapped)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "TestReason", condition.Reason)
+	assert.Equal(t, "test message 1", condition.Message)
+}
+
+func TestConsumerGroupSnapshotStatus_SnapshotTime(t *testing.T) {
+	snapshotTime := metav1.Now()
+	snapshot := ConsumerGroupSnapshot{}
+	assert.Nil(t, snapshot.Status.GetSnapshotTime())
+	snapshot.Status.SetSnapshotTime(&snapshotTime)
+	assert.Equal(t, &snapshotTime, snapshot.Status.GetSnapshotTime())
+}
+
+func TestConsumerGroupSnapshotStatus_Groups(t *testing.T) {
+	groups := []ConsumerGroupOffsets{
+		{Topic: "test-topic-name", Group: "test-group-id-1", Partitions: []PartitionOffset{{Partition: 0, Offset: 1}}},
+		{Topic: "test-topic-name", Group: "test-group-id-2", Partitions: []PartitionOffset{{Partition: 0, Offset: 2}}},
+	}
+	snapshot := ConsumerGroupSnapshot{}
+	assert.Nil(t, snapshot.Status.GetGroups())
+	assert.Nil(t, snapshot.Status.GetGroupOffsets("test-topic-name", "test-group-id-1"))
+	snapshot.Status.SetGroups(groups)
+	assert.Equal(t, groups, snapshot.Status.GetGroups())
+	assert.Equal(t, &snapshot.Status.Groups[1], snapshot.Status.GetGroupOffsets("test-topic-name", "test-group-id-2"))
+	assert.Nil(t, snapshot.Status.GetGroupOffsets("other-topic-name", "test-group-id-2"))
+	assert.Nil(t, snapshot.Status.GetGroupOffsets("test-topic-name", "test-group-id-3"))
+}
+
+func TestRegisterAlternateConsumerGroupSnapshotConditionSet(t *testing.T) {
+	originalConditionSet := snapshotCondSet
+	defer RegisterAlternateConsumerGroupSnapshotConditionSet(originalConditionSet)
+	conditionSet := apis.NewLivingConditionSet(apis.ConditionReady, "test")
+	RegisterAlternateConsumerGroupSnapshotConditionSet(conditionSet)
+	snapshot := ConsumerGroupSnapshot{}
+	assert.Equal(t, conditionSet, snapshot.GetConditionSet())
+	assert.Equal(t, conditionSet, snapshot.Status.GetConditionSet())
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsumerGroupSnapshot is a resource representing a point-in-time capture of the
+// committed offsets of the Kafka ConsumerGroup(s) related to a specific Kafka resource
+// (Subscription, KafkaChannel, etc.)  A ResetOffset may later restore the offsets
+// from the snapshot by name.
+type ConsumerGroupSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the ConsumerGroupSnapshot.
+	Spec ConsumerGroupSnapshotSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the ConsumerGroupSnapshot.
+	// This data may be out of date.
+	// +optional
+	Status ConsumerGroupSnapshotStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that this resource can be validated and defaulted.
+	_ apis.Validatable = (*ConsumerGroupSnapshot)(nil)
+	_ apis.Defaultable = (*ConsumerGroupSnapshot)(nil)
+
+	_ runtime.Object = (*ConsumerGroupSnapshot)(nil)
+
+	// Check that we can create OwnerReferences to an this resource.
+	_ kmeta.OwnerRefable = (*ConsumerGroupSnapshot)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*ConsumerGroupSnapshot)(nil)
+)
+
+// ConsumerGroupSnapshotSpec defines the specification for a ConsumerGroupSnapshot.
+type ConsumerGroupSnapshotSpec struct {
+
+	// Ref is a KReference specifying the Knative resource, related to one or more Kafka
+	// ConsumerGroups, whose committed partition offsets will be captured.  The supported
+	// values are the same as those of ResetOffsetSpec.Ref for the Controller in question.
+	Ref duckv1.KReference `json:"ref"`
+}
+
+// ConsumerGroupSnapshotStatus represents the current state of a ConsumerGroupSnapshot.
+type ConsumerGroupSnapshotStatus struct {
+
+	// SnapshotTime is the time at which the offsets were captured.
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+
+	// Groups is an array of ConsumerGroupOffsets structs which represent the captured
+	// committed offsets of each Kafka ConsumerGroup associated with the ConsumerGroupSnapshotSpec.Ref
+	// +optional
+	Groups []ConsumerGroupOffsets `json:"groups,omitempty"`
+
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	// * Annotations - optional status information to be conveyed to users.
+	duckv1.Status `json:",inline"`
+}
+
+// ConsumerGroupOffsets represents the committed Offsets of a single Kafka ConsumerGroup / Topic.
+type ConsumerGroupOffsets struct {
+
+	// Topic is a string representing the Kafka Topic name of the ConsumerGroup.
+	Topic string `json:"topic"`
+
+	// Group is a string representing the Kafka ConsumerGroup ID.
+	Group string `json:"group"`
+
+	// Partitions is an array of PartitionOffset structs which represent the committed Offset
+	// of each Kafka Partition of the Topic.
+	// +optional
+	Partitions []PartitionOffset `json:"partitions,omitempty"`
+}
+
+// PartitionOffset represents a single Kafka Partition's committed Offset value.  A negative
+// Offset indicates the ConsumerGroup had not committed an Offset for the Partition.
+type PartitionOffset struct {
+	Partition int32 `json:"partition"`
+	Offset    int64 `json:"offset"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsumerGroupSnapshotList is a collection of ConsumerGroupSnapshots.
+type ConsumerGroupSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroupSnapshot `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for ConsumerGroupSnapshot
+func (cgs *ConsumerGroupSnapshot) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ConsumerGroupSnapshot")
+}
+
+// GetStatus retrieves the duck status for this resource. Implements the KRShaped interface.
+func (cgs *ConsumerGroupSnapshot) GetStatus() *duckv1.Status {
+	return &cgs.Status.Status
+}
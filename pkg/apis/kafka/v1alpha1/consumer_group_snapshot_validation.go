@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
+)
+
+// Validate verifies the ConsumerGroupSnapshot and returns errors for any invalid fields.
+func (cgs *ConsumerGroupSnapshot) Validate(ctx context.Context) *apis.FieldError {
+	errs := cgs.Spec.Validate(ctx).ViaField("spec")
+
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*ConsumerGroupSnapshot)
+		errs = errs.Also(cgs.CheckImmutableFields(ctx, original))
+	}
+
+	return errs
+}
+
+// Validate verifies the ConsumerGroupSnapshotSpec and returns errors for an invalid fields.
+func (cgss *ConsumerGroupSnapshotSpec) Validate(ctx context.Context) *apis.FieldError {
+
+	// Validate The Ref KReference Basics (Kafka Topic relation which is expected to be done in Controllers!)
+	return cgss.Ref.Validate(ctx)
+}
+
+// CheckImmutableFields verifies the immutable spec fields have not been changed from the original.
+func (cgs *ConsumerGroupSnapshot) CheckImmutableFields(_ context.Context, original *ConsumerGroupSnapshot) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+
+	if diff, err := kmp.ShortDiff(original.Spec, cgs.Spec); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff ConsumerGroupSnapshot",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		}
+	}
+	return nil
+}
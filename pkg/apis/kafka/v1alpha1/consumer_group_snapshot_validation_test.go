@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestConsumerGroupSnapshot_Validate(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		snapshot *ConsumerGroupSnapshot
+		want     *apis.FieldError
+	}{
+		{
+			name: "valid",
+			snapshot: &ConsumerGroupSnapshot{
+				Spec: ConsumerGroupSnapshotSpec{
+					Ref: duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+				},
+			},
+		},
+		{
+			name:     "invalid ref nil",
+			snapshot: &ConsumerGroupSnapshot{},
+			want:     apis.ErrMissingField("spec.apiVersion", "spec.kind", "spec.name"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.snapshot.Validate(context.Background())
+			if test.want == nil {
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Errorf("validate (-want, +got) = %v", diff)
+				}
+			} else if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestConsumerGroupSnapshotImmutability(t *testing.T) {
+	original := &ConsumerGroupSnapshot{
+		Spec: ConsumerGroupSnapshotSpec{
+			Ref: duckv1.KReference{APIVersion: refAPIVersion, Kind: refKind, Namespace: refNamespace, Name: refName},
+		},
+	}
+	updated := original.DeepCopy()
+	updated.Spec.Ref.Name = "FOO"
+	want := &apis.FieldError{
+		Message: "Immutable fields changed (-old +new)",
+		Paths:   []string{"spec"},
+		Details: fmt.Sprintf("{v1alpha1.ConsumerGroupSnapshotSpec}.Ref.Name:\n\t-: \"%s\"\n\t+: \"%s\"\n", refName, "FOO"),
+	}
+	ctx := apis.WithinUpdate(context.Background(), original)
+	got := updated.Validate(ctx)
+	if diff := cmp.Diff(want.Error(), got.Error()); diff != "" {
+		t.Errorf("validate (-want, +got) = %v", diff)
+	}
+	if got := original.CheckImmutableFields(context.Background(), nil); got != nil {
+		t.Errorf("expected nil for nil original, got %v", got)
+	}
+}
@@ -47,6 +47,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ResetOffset{},
 		&ResetOffsetList{},
+		&ConsumerGroupSnapshot{},
+		&ConsumerGroupSnapshotList{},
+		&ConsumerGroupPause{},
+		&ConsumerGroupPauseList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -46,4 +46,12 @@ func TestAddKnownTypes(t *testing.T) {
 	assert.NotNil(t, roType)
 	roListType := types["ResetOffsetList"]
 	assert.NotNil(t, roListType)
+	cgsType := types["ConsumerGroupSnapshot"]
+	assert.NotNil(t, cgsType)
+	cgsListType := types["ConsumerGroupSnapshotList"]
+	assert.NotNil(t, cgsListType)
+	cgpType := types["ConsumerGroupPause"]
+	assert.NotNil(t, cgpType)
+	cgpListType := types["ConsumerGroupPauseList"]
+	assert.NotNil(t, cgpListType)
 }
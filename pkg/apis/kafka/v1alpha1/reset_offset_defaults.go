@@ -18,6 +18,14 @@ package v1alpha1
 
 import (
 	"context"
+
+	"knative.dev/pkg/ptr"
+)
+
+const (
+	// DefaultUndoMaxProgress is the default OffsetUndoSpec.MaxProgress, refusing to undo a ResetOffset
+	// once the ConsumerGroup has consumed any messages since it completed.
+	DefaultUndoMaxProgress = int64(0)
 )
 
 func (ro *ResetOffset) SetDefaults(ctx context.Context) {
@@ -25,5 +33,7 @@ func (ro *ResetOffset) SetDefaults(ctx context.Context) {
 }
 
 func (ros *ResetOffsetSpec) SetDefaults(_ context.Context) {
-	// Currently no fields can be defaulted.
+	if ros.Offset.Undo != nil && ros.Offset.Undo.MaxProgress == nil {
+		ros.Offset.Undo.MaxProgress = ptr.Int64(DefaultUndoMaxProgress)
+	}
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"knative.dev/pkg/ptr"
 )
 
 func TestResetOffset_SetDefaults(t *testing.T) {
@@ -29,3 +30,13 @@ func TestResetOffset_SetDefaults(t *testing.T) {
 	resultResetOffset.SetDefaults(context.TODO())
 	assert.Equal(t, initialResetOffset, *resultResetOffset)
 }
+
+func TestResetOffset_SetDefaults_Undo(t *testing.T) {
+	resetOffset := ResetOffset{Spec: ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name"}}}}
+	resetOffset.SetDefaults(context.TODO())
+	assert.Equal(t, ptr.Int64(DefaultUndoMaxProgress), resetOffset.Spec.Offset.Undo.MaxProgress)
+
+	resetOffset = ResetOffset{Spec: ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name", MaxProgress: ptr.Int64(10)}}}}
+	resetOffset.SetDefaults(context.TODO())
+	assert.Equal(t, ptr.Int64(10), resetOffset.Spec.Offset.Undo.MaxProgress)
+}
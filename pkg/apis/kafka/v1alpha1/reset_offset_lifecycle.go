@@ -162,3 +162,35 @@ func (ros *ResetOffsetStatus) GetPartitions() []OffsetMapping {
 func (ros *ResetOffsetStatus) SetPartitions(offsetMappings []OffsetMapping) {
 	ros.Partitions = offsetMappings
 }
+
+func (ros *ResetOffsetStatus) GetGroups() []ConsumerGroupResult {
+	return ros.Groups
+}
+
+func (ros *ResetOffsetStatus) SetGroups(consumerGroupResults []ConsumerGroupResult) {
+	ros.Groups = consumerGroupResults
+}
+
+// GetGroupResult returns the ConsumerGroupResult for the specified ConsumerGroup ID, or nil if not present.
+func (ros *ResetOffsetStatus) GetGroupResult(group string) *ConsumerGroupResult {
+	for index := range ros.Groups {
+		if ros.Groups[index].Group == group {
+			return &ros.Groups[index]
+		}
+	}
+	return nil
+}
+
+// GetGroupOffsetMappings returns the OffsetMappings recorded for the specified Topic / ConsumerGroup, from either
+// the single Topic / Group / Partitions fields or the multiple Groups, or nil if the ConsumerGroup was not updated.
+func (ros *ResetOffsetStatus) GetGroupOffsetMappings(topic string, group string) []OffsetMapping {
+	if ros.Topic == topic && ros.Group == group && len(ros.Partitions) > 0 {
+		return ros.Partitions
+	}
+	for index := range ros.Groups {
+		if ros.Groups[index].Topic == topic && ros.Groups[index].Group == group && ros.Groups[index].OffsetsUpdated {
+			return ros.Groups[index].Partitions
+		}
+	}
+	return nil
+}
@@ -410,3 +410,41 @@ func TestResetOffsetStatus_Partitions(t *testing.T) {
 	assert.Equal(t, partitions, resetOffset.Status.GetPartitions())
 	assert.Equal(t, resetOffset.Status.Partitions, resetOffset.Status.GetPartitions())
 }
+
+func TestResetOffsetStatus_Groups(t *testing.T) {
+	groups := []ConsumerGroupResult{
+		{Topic: "test-topic-name", Group: "test-group-id-1", OffsetsUpdated: true, Succeeded: true},
+		{Topic: "test-topic-name", Group: "test-group-id-2", Message: "test-message"},
+	}
+	resetOffset := ResetOffset{}
+	assert.Nil(t, resetOffset.Status.GetGroups())
+	assert.Nil(t, resetOffset.Status.GetGroupResult("test-group-id-1"))
+	resetOffset.Status.SetGroups(groups)
+	assert.Equal(t, groups, resetOffset.Status.GetGroups())
+	assert.Equal(t, resetOffset.Status.Groups, resetOffset.Status.GetGroups())
+	assert.Equal(t, &resetOffset.Status.Groups[1], resetOffset.Status.GetGroupResult("test-group-id-2"))
+	assert.Nil(t, resetOffset.Status.GetGroupResult("test-group-id-3"))
+}
+
+func TestResetOffsetStatus_GetGroupOffsetMappings(t *testing.T) {
+	partitions1 := []OffsetMapping{{Partition: 0, OldOffset: 1, NewOffset: 2}}
+	partitions2 := []OffsetMapping{{Partition: 0, OldOffset: 3, NewOffset: 4}}
+	partitions3 := []OffsetMapping{{Partition: 0, OldOffset: 5, NewOffset: 6}}
+
+	resetOffset := ResetOffset{}
+	assert.Nil(t, resetOffset.Status.GetGroupOffsetMappings("test-topic-name", "test-group-id-1"))
+
+	resetOffset.Status.SetTopic("test-topic-name")
+	resetOffset.Status.SetGroup("test-group-id-1")
+	resetOffset.Status.SetPartitions(partitions1)
+	assert.Equal(t, partitions1, resetOffset.Status.GetGroupOffsetMappings("test-topic-name", "test-group-id-1"))
+	assert.Nil(t, resetOffset.Status.GetGroupOffsetMappings("other-topic-name", "test-group-id-1"))
+
+	resetOffset = ResetOffset{}
+	resetOffset.Status.SetGroups([]ConsumerGroupResult{
+		{Topic: "test-topic-name", Group: "test-group-id-2", Partitions: partitions2, OffsetsUpdated: true, Succeeded: true},
+		{Topic: "test-topic-name", Group: "test-group-id-3", Partitions: partitions3},
+	})
+	assert.Equal(t, partitions2, resetOffset.Status.GetGroupOffsetMappings("test-topic-name", "test-group-id-2"))
+	assert.Nil(t, resetOffset.Status.GetGroupOffsetMappings("test-topic-name", "test-group-id-3"))
+}
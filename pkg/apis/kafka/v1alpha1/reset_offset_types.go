@@ -81,7 +81,10 @@ type ResetOffsetSpec struct {
 	// can be identified.  Thus, even though the KReference is a wide-open type, it is up
 	// to the user to provide an appropriate value as supported by the Controller in question
 	// (KafkaChannel vs KafkaBroker, etc).  Failure to provide a valid value will result in
-	// the ResetOffset operation being rejected as failed.
+	// the ResetOffset operation being rejected as failed.  Some Controllers also support
+	// referencing a KafkaChannel, in which case the offsets of the ConsumerGroups of ALL
+	// the Subscriptions to that KafkaChannel will be reset, or a Trigger of a KafkaChannel
+	// backed channel-based Broker, in which case the Trigger's Subscription will be reset.
 	Ref duckv1.KReference `json:"ref"`
 }
 
@@ -95,8 +98,40 @@ type OffsetSpec struct {
 	// string in the time.RFC3339 format. The "earliest" and "latest" values indicate the
 	// beginning and end, respectively, of the persistence window of the Topic.  There is no
 	// default value, and invalid values will result in the ResetOffset operation being
-	// rejected as failed.
-	Time string `json:"time"`
+	// rejected as failed.  Mutually exclusive with Snapshot and Undo.
+	// +optional
+	Time string `json:"time,omitempty"`
+
+	// Snapshot is the name of a ConsumerGroupSnapshot, in the same namespace as the ResetOffset,
+	// whose captured offsets will be restored.  The ConsumerGroupSnapshot must have succeeded and
+	// must contain the ConsumerGroup(s) associated with the ResetOffsetSpec.Ref.  Partitions which
+	// are not present in the snapshot, or which had no committed offset, are left unchanged.
+	// Mutually exclusive with Time and Undo.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Undo identifies a previously completed ResetOffset whose recorded old offsets will be
+	// restored, thereby reverting its effect.  Mutually exclusive with Time and Snapshot.
+	// +optional
+	Undo *OffsetUndoSpec `json:"undo,omitempty"`
+}
+
+// OffsetUndoSpec defines the ResetOffset to be reverted and the guard against reverting
+// a ConsumerGroup which has since made significant progress.
+type OffsetUndoSpec struct {
+
+	// ResetOffset is the name of a succeeded ResetOffset, in the same namespace, whose
+	// Status contains the Partition OldOffsets to be restored.  It must have been applied
+	// to the same ConsumerGroup(s) as are associated with the ResetOffsetSpec.Ref.
+	// Partitions which had no committed offset before that ResetOffset are left unchanged.
+	ResetOffset string `json:"resetOffset"`
+
+	// MaxProgress is the maximum number of messages, on any single Partition, which the
+	// ConsumerGroup may have consumed beyond the NewOffset set by the ResetOffset being
+	// reverted.  If any Partition has progressed further the undo is refused, and no
+	// offsets are changed, before the ConsumerGroup is stopped.  Defaults to 0.
+	// +optional
+	MaxProgress *int64 `json:"maxProgress,omitempty"`
 }
 
 // IsOffsetEarliest returns True if the Offset value is "earliest"
@@ -109,6 +144,24 @@ func (ros *ResetOffsetSpec) IsOffsetLatest() bool {
 	return ros.Offset.Time == OffsetLatest
 }
 
+// IsOffsetSnapshot returns True if the Offset is to be restored from a ConsumerGroupSnapshot
+func (ros *ResetOffsetSpec) IsOffsetSnapshot() bool {
+	return len(ros.Offset.Snapshot) > 0
+}
+
+// IsOffsetUndo returns True if the Offset is to be restored from a previous ResetOffset
+func (ros *ResetOffsetSpec) IsOffsetUndo() bool {
+	return ros.Offset.Undo != nil
+}
+
+// GetMaxProgress returns the MaxProgress value, or the DefaultUndoMaxProgress if not specified.
+func (ous *OffsetUndoSpec) GetMaxProgress() int64 {
+	if ous.MaxProgress == nil {
+		return DefaultUndoMaxProgress
+	}
+	return *ous.MaxProgress
+}
+
 // ParseOffsetTime returns the parsed Offset Time if valid (RFC3339 format) or an error for invalid content.
 func (ros *ResetOffsetSpec) ParseOffsetTime() (time.Time, error) {
 	return time.Parse(time.RFC3339, ros.Offset.Time)
@@ -147,6 +200,13 @@ type ResetOffsetStatus struct {
 	// +optional
 	Partitions []OffsetMapping `json:"partitions,omitempty"`
 
+	// Groups is an array of ConsumerGroupResult structs which represent the individual results
+	// for each Kafka ConsumerGroup when the ResetOffsetSpec.Ref maps to more than one ConsumerGroup
+	// (e.g. a KafkaChannel with multiple Subscriptions).  The Topic, Group and Partitions fields
+	// above are not used in that case.
+	// +optional
+	Groups []ConsumerGroupResult `json:"groups,omitempty"`
+
 	// inherits duck/v1 Status, which currently provides:
 	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
 	// * Conditions - the latest available observations of a resource's current state.
@@ -180,3 +240,42 @@ type OffsetMapping struct {
 	OldOffset int64 `json:"oldOffset"`
 	NewOffset int64 `json:"newOffset"`
 }
+
+// ConsumerGroupResult represents the outcome of repositioning the Offsets of a single Kafka ConsumerGroup
+// when the ResetOffsetSpec.Ref maps to multiple ConsumerGroups.
+type ConsumerGroupResult struct {
+
+	// Topic is a string representing the Kafka Topic name of the ConsumerGroup.
+	Topic string `json:"topic"`
+
+	// Group is a string representing the Kafka ConsumerGroup ID.
+	Group string `json:"group"`
+
+	// Partitions is an array of OffsetMapping structs which represent the Offsets (old / new) of
+	// all Kafka Partitions of the ConsumerGroup.
+	// +optional
+	Partitions []OffsetMapping `json:"partitions,omitempty"`
+
+	// OffsetsUpdated is true once the Offsets of the ConsumerGroup have been committed, and
+	// ensures they are never repositioned a second time when re-reconciling partial failures.
+	// +optional
+	OffsetsUpdated bool `json:"offsetsUpdated,omitempty"`
+
+	// Succeeded is true once the ConsumerGroup has been stopped, repositioned and restarted.
+	// +optional
+	Succeeded bool `json:"succeeded,omitempty"`
+
+	// UndoRefused is true if the ConsumerGroup had progressed too far to undo the ResetOffset
+	// referenced by an OffsetUndoSpec.  The ConsumerGroup is then never repositioned.
+	// +optional
+	UndoRefused bool `json:"undoRefused,omitempty"`
+
+	// Stopped is true while the ConsumerGroup has been stopped and not yet restarted, and ensures
+	// it is restarted even if the reset is subsequently abandoned (e.g. a refused undo).
+	// +optional
+	Stopped bool `json:"stopped,omitempty"`
+
+	// Message is a human readable description of the most recent failure for the ConsumerGroup.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
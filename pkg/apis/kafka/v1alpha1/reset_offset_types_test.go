@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestResetOffset_GetGroupVersionKind(t *testing.T) {
@@ -126,6 +127,27 @@ func TestResetOffsetSpec_IsOffsetLatest(t *testing.T) {
 	}
 }
 
+func TestResetOffsetSpec_IsOffsetSnapshot(t *testing.T) {
+	resetOffsetSpec := &ResetOffsetSpec{Offset: OffsetSpec{Time: OffsetEarliest}}
+	assert.False(t, resetOffsetSpec.IsOffsetSnapshot())
+	resetOffsetSpec = &ResetOffsetSpec{Offset: OffsetSpec{Snapshot: "snapshot-name"}}
+	assert.True(t, resetOffsetSpec.IsOffsetSnapshot())
+}
+
+func TestResetOffsetSpec_IsOffsetUndo(t *testing.T) {
+	resetOffsetSpec := &ResetOffsetSpec{Offset: OffsetSpec{Time: OffsetEarliest}}
+	assert.False(t, resetOffsetSpec.IsOffsetUndo())
+	resetOffsetSpec = &ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name"}}}
+	assert.True(t, resetOffsetSpec.IsOffsetUndo())
+}
+
+func TestOffsetUndoSpec_GetMaxProgress(t *testing.T) {
+	offsetUndoSpec := &OffsetUndoSpec{ResetOffset: "resetoffset-name"}
+	assert.Equal(t, DefaultUndoMaxProgress, offsetUndoSpec.GetMaxProgress())
+	offsetUndoSpec.MaxProgress = ptr.Int64(10)
+	assert.Equal(t, int64(10), offsetUndoSpec.GetMaxProgress())
+}
+
 func TestResetOffsetSpec_ParseOffsetTime(t *testing.T) {
 
 	offsetRFC3339 := time.Now().UTC().Add(-1 * time.Hour).Format(time.RFC3339)
@@ -41,8 +41,22 @@ func (ros *ResetOffsetSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	var errs *apis.FieldError
 
-	// Validate The Offset String ("earliest", "latest", or valid date string)
-	if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
+	// Validate The Offset Is Either A Time String ("earliest", "latest", or valid date string), A Snapshot Name Or An Undo
+	if ros.IsOffsetUndo() {
+		if len(ros.Offset.Time) > 0 || ros.IsOffsetSnapshot() {
+			errs = errs.Also(apis.ErrMultipleOneOf("offset.time", "offset.snapshot", "offset.undo"))
+		}
+		if len(ros.Offset.Undo.ResetOffset) == 0 {
+			errs = errs.Also(apis.ErrMissingField("offset.undo.resetOffset"))
+		}
+		if ros.Offset.Undo.GetMaxProgress() < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Undo.GetMaxProgress(), "offset.undo.maxProgress"))
+		}
+	} else if ros.IsOffsetSnapshot() {
+		if len(ros.Offset.Time) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("offset.time", "offset.snapshot"))
+		}
+	} else if !ros.IsOffsetEarliest() && !ros.IsOffsetLatest() {
 		offsetTime, err := ros.ParseOffsetTime()
 		if err != nil || offsetTime.After(time.Now()) {
 			errs = errs.Also(apis.ErrInvalidValue(ros.Offset.Time, "offset"))
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/webhook/resourcesemantics"
 )
 
@@ -70,6 +71,66 @@ func TestResetOffset_Validate(t *testing.T) {
 				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: pastTime}, Ref: reference},
 			},
 		},
+		{
+			name: "valid offset snapshot",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Snapshot: "snapshot-name"}, Ref: reference},
+			},
+		},
+		{
+			name: "invalid offset time and snapshot",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Time: OffsetEarliest, Snapshot: "snapshot-name"}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrMultipleOneOf("spec.offset.time", "spec.offset.snapshot")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		{
+			name: "valid offset undo",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name", MaxProgress: ptr.Int64(10)}}, Ref: reference},
+			},
+		},
+		{
+			name: "invalid offset undo and snapshot",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Snapshot: "snapshot-name", Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name"}}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrMultipleOneOf("spec.offset.time", "spec.offset.snapshot", "spec.offset.undo")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		{
+			name: "invalid offset undo missing resetoffset",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{}}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrMissingField("spec.offset.undo.resetOffset")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
+		{
+			name: "invalid offset undo negative maxprogress",
+			cr: &ResetOffset{
+				Spec: ResetOffsetSpec{Offset: OffsetSpec{Undo: &OffsetUndoSpec{ResetOffset: "resetoffset-name", MaxProgress: ptr.Int64(-1)}}, Ref: reference},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				fe := apis.ErrInvalidValue(int64(-1), "spec.offset.undo.maxProgress")
+				errs = errs.Also(fe)
+				return errs
+			}(),
+		},
 		{
 			name: "invalid offset time",
 			cr: &ResetOffset{
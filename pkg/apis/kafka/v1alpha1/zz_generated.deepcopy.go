@@ -25,6 +25,267 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupOffsets) DeepCopyInto(out *ConsumerGroupOffsets) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionOffset, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupOffsets.
+func (in *ConsumerGroupOffsets) DeepCopy() *ConsumerGroupOffsets {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupOffsets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupPause) DeepCopyInto(out *ConsumerGroupPause) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupPause.
+func (in *ConsumerGroupPause) DeepCopy() *ConsumerGroupPause {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupPause) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupPauseList) DeepCopyInto(out *ConsumerGroupPauseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsumerGroupPause, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupPauseList.
+func (in *ConsumerGroupPauseList) DeepCopy() *ConsumerGroupPauseList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupPauseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupPauseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupPauseSpec) DeepCopyInto(out *ConsumerGroupPauseSpec) {
+	*out = *in
+	out.Ref = in.Ref
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]PauseWindow, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupPauseSpec.
+func (in *ConsumerGroupPauseSpec) DeepCopy() *ConsumerGroupPauseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupPauseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupPauseStatus) DeepCopyInto(out *ConsumerGroupPauseStatus) {
+	*out = *in
+	if in.WindowEnd != nil {
+		in, out := &in.WindowEnd, &out.WindowEnd
+		*out = (*in).DeepCopy()
+	}
+	if in.NextWindowStart != nil {
+		in, out := &in.NextWindowStart, &out.NextWindowStart
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]PausedGroup, len(*in))
+		copy(*out, *in)
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupPauseStatus.
+func (in *ConsumerGroupPauseStatus) DeepCopy() *ConsumerGroupPauseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupPauseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupResult) DeepCopyInto(out *ConsumerGroupResult) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]OffsetMapping, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupResult.
+func (in *ConsumerGroupResult) DeepCopy() *ConsumerGroupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSnapshot) DeepCopyInto(out *ConsumerGroupSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSnapshot.
+func (in *ConsumerGroupSnapshot) DeepCopy() *ConsumerGroupSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSnapshotList) DeepCopyInto(out *ConsumerGroupSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsumerGroupSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSnapshotList.
+func (in *ConsumerGroupSnapshotList) DeepCopy() *ConsumerGroupSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsumerGroupSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSnapshotSpec) DeepCopyInto(out *ConsumerGroupSnapshotSpec) {
+	*out = *in
+	out.Ref = in.Ref
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSnapshotSpec.
+func (in *ConsumerGroupSnapshotSpec) DeepCopy() *ConsumerGroupSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerGroupSnapshotStatus) DeepCopyInto(out *ConsumerGroupSnapshotStatus) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]ConsumerGroupOffsets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerGroupSnapshotStatus.
+func (in *ConsumerGroupSnapshotStatus) DeepCopy() *ConsumerGroupSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerGroupSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OffsetMapping) DeepCopyInto(out *OffsetMapping) {
 	*out = *in
@@ -44,6 +305,11 @@ func (in *OffsetMapping) DeepCopy() *OffsetMapping {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OffsetSpec) DeepCopyInto(out *OffsetSpec) {
 	*out = *in
+	if in.Undo != nil {
+		in, out := &in.Undo, &out.Undo
+		*out = new(OffsetUndoSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -57,12 +323,81 @@ func (in *OffsetSpec) DeepCopy() *OffsetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffsetUndoSpec) DeepCopyInto(out *OffsetUndoSpec) {
+	*out = *in
+	if in.MaxProgress != nil {
+		in, out := &in.MaxProgress, &out.MaxProgress
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffsetUndoSpec.
+func (in *OffsetUndoSpec) DeepCopy() *OffsetUndoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OffsetUndoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionOffset) DeepCopyInto(out *PartitionOffset) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionOffset.
+func (in *PartitionOffset) DeepCopy() *PartitionOffset {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionOffset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseWindow) DeepCopyInto(out *PauseWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseWindow.
+func (in *PauseWindow) DeepCopy() *PauseWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PausedGroup) DeepCopyInto(out *PausedGroup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PausedGroup.
+func (in *PausedGroup) DeepCopy() *PausedGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(PausedGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResetOffset) DeepCopyInto(out *ResetOffset) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -121,7 +456,7 @@ func (in *ResetOffsetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResetOffsetSpec) DeepCopyInto(out *ResetOffsetSpec) {
 	*out = *in
-	out.Offset = in.Offset
+	in.Offset.DeepCopyInto(&out.Offset)
 	out.Ref = in.Ref
 	return
 }
@@ -144,6 +479,13 @@ func (in *ResetOffsetStatus) DeepCopyInto(out *ResetOffsetStatus) {
 		*out = make([]OffsetMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]ConsumerGroupResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
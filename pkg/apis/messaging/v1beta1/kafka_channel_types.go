@@ -27,6 +27,8 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
 // +genclient
@@ -62,6 +64,13 @@ var (
 	_ duckv1.KRShaped = (*KafkaChannel)(nil)
 )
 
+const (
+	// IsolationLevelAnnotationKey is the annotation overriding the isolation level (read_uncommitted
+	// or read_committed) of the consumers of a KafkaChannel, which defaults to the one of the
+	// config-kafka ConfigMap.
+	IsolationLevelAnnotationKey = "kafka.eventing.knative.dev/isolation-level"
+)
+
 // KafkaChannelSpec defines the specification for a KafkaChannel.
 type KafkaChannelSpec struct {
 	// NumPartitions is the number of partitions of a Kafka topic. By default, it is set to 1.
@@ -77,10 +86,40 @@ type KafkaChannelSpec struct {
 	//  - https://en.wikipedia.org/wiki/ISO_8601
 	RetentionDuration string `json:"retentionDuration"`
 
+	// SubscriberAuth lists the credentials presented to the subscribers of the
+	// channel, matched by the subscriber URI of their subscription. They are
+	// not presented to the reply and dead letter sinks.
+	// +optional
+	SubscriberAuth []SubscriberAuthSpec `json:"subscriberAuth,omitempty"`
+
 	// Channel conforms to Duck type Channelable.
 	eventingduck.ChannelableSpec `json:",inline"`
 }
 
+// SubscriberAuthSpec defines the credentials presented to a subscriber.
+type SubscriberAuthSpec struct {
+	// SubscriberURI is the resolved subscriber URI of the subscriptions
+	// presenting the credentials.
+	// +required
+	SubscriberURI *apis.URL `json:"subscriberUri"`
+
+	sourcesv1beta1.SinkAuthSpec `json:",inline"`
+}
+
+// SubscriberAuthFor returns the credentials presented to the given subscriber
+// URI, or nil when there are none.
+func (kcs *KafkaChannelSpec) SubscriberAuthFor(subscriberURI *apis.URL) *sourcesv1beta1.SinkAuthSpec {
+	if subscriberURI == nil {
+		return nil
+	}
+	for i := range kcs.SubscriberAuth {
+		if kcs.SubscriberAuth[i].SubscriberURI.String() == subscriberURI.String() {
+			return &kcs.SubscriberAuth[i].SinkAuthSpec
+		}
+	}
+	return nil
+}
+
 // ParseRetentionDuration returns the parsed Offset Time if valid (RFC3339 format) or an error for invalid content.
 // Note - If the optional RetentionDuration field is not present, or is invalid, a Duration of "-1" will be returned.
 func (kcs *KafkaChannelSpec) ParseRetentionDuration() (time.Duration, error) {
@@ -92,6 +131,12 @@ func (kcs *KafkaChannelSpec) ParseRetentionDuration() (time.Duration, error) {
 	return retentionDuration, nil
 }
 
+// IsolationLevel returns the isolation level set by the IsolationLevelAnnotationKey annotation,
+// or an empty string when the default one should be used.
+func (kc *KafkaChannel) IsolationLevel() sourcesv1beta1.IsolationLevel {
+	return sourcesv1beta1.IsolationLevel(kc.Annotations[IsolationLevelAnnotationKey])
+}
+
 // KafkaChannelStatus represents the current state of a KafkaChannel.
 type KafkaChannelStatus struct {
 	// Channel conforms to Duck type ChannelableStatus.
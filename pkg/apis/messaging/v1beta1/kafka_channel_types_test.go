@@ -23,7 +23,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
 func TestKafkaChannelGetGroupVersionKind(t *testing.T) {
@@ -100,3 +103,18 @@ func TestKafkaChannelSpecParseRetentionDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaChannelSpecSubscriberAuthFor(t *testing.T) {
+	spec := KafkaChannelSpec{
+		SubscriberAuth: []SubscriberAuthSpec{{
+			SubscriberURI: apis.HTTPS("gateway.example.com"),
+			SinkAuthSpec: sourcesv1beta1.SinkAuthSpec{
+				ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "gateway"},
+			},
+		}},
+	}
+
+	assert.Equal(t, &spec.SubscriberAuth[0].SinkAuthSpec, spec.SubscriberAuthFor(apis.HTTPS("gateway.example.com")))
+	assert.Nil(t, spec.SubscriberAuthFor(apis.HTTP("gateway.example.com")))
+	assert.Nil(t, spec.SubscriberAuthFor(nil))
+}
@@ -40,6 +40,11 @@ func (kc *KafkaChannel) Validate(ctx context.Context) *apis.FieldError {
 				errs = errs.Also(iv.ViaFieldKey("annotations", eventing.ScopeAnnotationKey).ViaField("metadata"))
 			}
 		}
+		if level, ok := kc.Annotations[IsolationLevelAnnotationKey]; ok && (level == "" || !kc.IsolationLevel().IsValid()) {
+			iv := apis.ErrInvalidValue(level, "")
+			iv.Details = "expected either 'read_uncommitted' or 'read_committed'"
+			errs = errs.Also(iv.ViaFieldKey("annotations", IsolationLevelAnnotationKey).ViaField("metadata"))
+		}
 	}
 
 	if apis.IsInUpdate(ctx) {
@@ -76,6 +81,18 @@ func (kcs *KafkaChannelSpec) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(fe.ViaField(fmt.Sprintf("subscriber[%d]", i)).ViaField("subscribable"))
 		}
 	}
+
+	subscriberURIs := make(map[string]bool, len(kcs.SubscriberAuth))
+	for i, auth := range kcs.SubscriberAuth {
+		if auth.SubscriberURI.IsEmpty() {
+			errs = errs.Also(apis.ErrMissingField("subscriberUri").ViaIndex(i).ViaField("subscriberAuth"))
+		} else if subscriberURIs[auth.SubscriberURI.String()] {
+			errs = errs.Also(apis.ErrInvalidValue(auth.SubscriberURI, "subscriberUri", "duplicate subscriber URI").ViaIndex(i).ViaField("subscriberAuth"))
+		} else {
+			subscriberURIs[auth.SubscriberURI.String()] = true
+		}
+		errs = errs.Also(auth.SinkAuthSpec.Validate(ctx).ViaIndex(i).ViaField("subscriberAuth"))
+	}
 	return errs
 }
 
@@ -84,7 +101,7 @@ func (kc *KafkaChannel) CheckImmutableFields(_ context.Context, original *KafkaC
 		return nil
 	}
 
-	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec")}
+	ignoreArguments := []cmp.Option{cmpopts.IgnoreFields(KafkaChannelSpec{}, "ChannelableSpec", "SubscriberAuth")}
 
 	// In the specific case of the original RetentionDuration being an empty string, allow it
 	// as an exception to the immutability requirement.
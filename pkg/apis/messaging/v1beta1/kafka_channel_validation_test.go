@@ -28,6 +28,7 @@ import (
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/webhook/resourcesemantics"
 
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/constants"
 )
 
@@ -194,6 +195,84 @@ func TestKafkaChannelValidation(t *testing.T) {
 				return fe
 			}(),
 		},
+		"read committed isolation level annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						IsolationLevelAnnotationKey: "read_committed",
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			want: nil,
+		},
+		"valid subscriber auth": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					SubscriberAuth: []SubscriberAuthSpec{{
+						SubscriberURI: apis.HTTPS("gateway.example.com"),
+						SinkAuthSpec: sourcesv1beta1.SinkAuthSpec{
+							ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "gateway"},
+						},
+					}},
+				},
+			},
+			want: nil,
+		},
+		"invalid subscriber auth": {
+			cr: &KafkaChannel{
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+					SubscriberAuth: []SubscriberAuthSpec{{
+						SubscriberURI: apis.HTTPS("gateway.example.com"),
+						SinkAuthSpec: sourcesv1beta1.SinkAuthSpec{
+							ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "gateway"},
+						},
+					}, {
+						SubscriberURI: apis.HTTPS("gateway.example.com"),
+					}, {
+						SinkAuthSpec: sourcesv1beta1.SinkAuthSpec{
+							ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{},
+						},
+					}},
+				},
+			},
+			want: func() *apis.FieldError {
+				var errs *apis.FieldError
+				errs = errs.Also(apis.ErrInvalidValue(apis.HTTPS("gateway.example.com"), "spec.subscriberAuth[1].subscriberUri", "duplicate subscriber URI"))
+				errs = errs.Also(apis.ErrMissingField("spec.subscriberAuth[2].subscriberUri"))
+				errs = errs.Also(apis.ErrMissingField("spec.subscriberAuth[2].serviceAccountToken.audience"))
+				return errs
+			}(),
+		},
+		"invalid isolation level annotation": {
+			cr: &KafkaChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						IsolationLevelAnnotationKey: "serializable",
+					},
+				},
+				Spec: KafkaChannelSpec{
+					NumPartitions:     1,
+					ReplicationFactor: 1,
+					RetentionDuration: "P1D",
+				},
+			},
+			want: func() *apis.FieldError {
+				fe := apis.ErrInvalidValue("serializable", "metadata.annotations.[kafka.eventing.knative.dev/isolation-level]")
+				fe.Details = "expected either 'read_uncommitted' or 'read_committed'"
+				return fe
+			}(),
+		},
 	}
 
 	for n, test := range testCases {
@@ -23,6 +23,7 @@ package v1beta1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apis "knative.dev/pkg/apis"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -89,6 +90,13 @@ func (in *KafkaChannelList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaChannelSpec) DeepCopyInto(out *KafkaChannelSpec) {
 	*out = *in
+	if in.SubscriberAuth != nil {
+		in, out := &in.SubscriberAuth, &out.SubscriberAuth
+		*out = make([]SubscriberAuthSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.ChannelableSpec.DeepCopyInto(&out.ChannelableSpec)
 	return
 }
@@ -119,3 +127,25 @@ func (in *KafkaChannelStatus) DeepCopy() *KafkaChannelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriberAuthSpec) DeepCopyInto(out *SubscriberAuthSpec) {
+	*out = *in
+	if in.SubscriberURI != nil {
+		in, out := &in.SubscriberURI, &out.SubscriberURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	in.SinkAuthSpec.DeepCopyInto(&out.SinkAuthSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriberAuthSpec.
+func (in *SubscriberAuthSpec) DeepCopy() *SubscriberAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriberAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
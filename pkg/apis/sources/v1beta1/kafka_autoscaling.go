@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/config"
+)
+
+// AutoscalingSettings are the autoscaling settings of a KafkaSource, read from
+// its autoscaling annotations.
+// +k8s:deepcopy-gen=false
+type AutoscalingSettings struct {
+	// MinScale is the minimum number of replicas (or vreplicas) of the source.
+	MinScale int32
+	// MaxScale is the maximum number of replicas (or vreplicas) of the source.
+	MaxScale int32
+	// PollingInterval is the interval between two measures of the lag.
+	PollingInterval time.Duration
+	// CooldownPeriod is the minimum period between a scale change and a scale down.
+	CooldownPeriod time.Duration
+	// LagThreshold is the lag each replica (or vreplica) is expected to handle.
+	LagThreshold int64
+}
+
+// IsAutoscaled returns true when the source uses the KEDA or the built-in
+// autoscaling class.
+func (k *KafkaSource) IsAutoscaled() bool {
+	class := k.Annotations[classAnnotation]
+	return class == config.KedaAutoscalingClass || class == config.KafkaAutoscalingClass
+}
+
+// IsNativelyAutoscaled returns true when the source uses the autoscaling class
+// built in the KafkaSource controllers, instead of KEDA.
+func (k *KafkaSource) IsNativelyAutoscaled() bool {
+	return k.Annotations[classAnnotation] == config.KafkaAutoscalingClass
+}
+
+// GetAutoscalingSettings returns the autoscaling settings of the source, or nil
+// when the source is not autoscaled. The missing annotations take their default
+// value.
+func (k *KafkaSource) GetAutoscalingSettings() (*AutoscalingSettings, error) {
+	if !k.IsAutoscaled() {
+		return nil, nil
+	}
+	errs := k.validateAutoscaling()
+	if errs != nil {
+		return nil, errs
+	}
+
+	// The annotations have been validated above
+	minScale, _ := k.autoscalingAnnotation(minScaleAnnotation, config.DefaultMinScaleValue)
+	maxScale, _ := k.autoscalingAnnotation(maxScaleAnnotation, config.DefaultMaxScaleValue)
+	pollingInterval, _ := k.autoscalingAnnotation(pollingIntervalAnnotation, config.DefaultPollingIntervalValue)
+	cooldownPeriod, _ := k.autoscalingAnnotation(cooldownPeriodAnnotation, config.DefaultCooldownPeriodValue)
+	lagThreshold, _ := k.autoscalingAnnotation(kafkaLagThresholdAnnotation, config.DefaultKafkaLagThresholdValue)
+
+	return &AutoscalingSettings{
+		MinScale:        int32(minScale),
+		MaxScale:        int32(maxScale),
+		PollingInterval: time.Duration(pollingInterval) * time.Second,
+		CooldownPeriod:  time.Duration(cooldownPeriod) * time.Second,
+		LagThreshold:    lagThreshold,
+	}, nil
+}
+
+// validateAutoscaling validates the autoscaling annotations of an autoscaled source.
+func (k *KafkaSource) validateAutoscaling() *apis.FieldError {
+	if !k.IsAutoscaled() {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	for _, annotation := range []struct {
+		key      string
+		defaults int64
+		min      int64
+	}{
+		{key: minScaleAnnotation, defaults: config.DefaultMinScaleValue, min: 0},
+		{key: maxScaleAnnotation, defaults: config.DefaultMaxScaleValue, min: 1},
+		{key: pollingIntervalAnnotation, defaults: config.DefaultPollingIntervalValue, min: 1},
+		{key: cooldownPeriodAnnotation, defaults: config.DefaultCooldownPeriodValue, min: 0},
+		{key: kafkaLagThresholdAnnotation, defaults: config.DefaultKafkaLagThresholdValue, min: 1},
+	} {
+		value, err := k.autoscalingAnnotation(annotation.key, annotation.defaults)
+		if err != nil || value < annotation.min || value > int64(^uint32(0)>>1) {
+			errs = errs.Also(apis.ErrInvalidValue(k.Annotations[annotation.key], annotation.key))
+		}
+	}
+	if errs != nil {
+		return errs.ViaField("metadata", "annotations")
+	}
+
+	minScale, _ := k.autoscalingAnnotation(minScaleAnnotation, config.DefaultMinScaleValue)
+	maxScale, _ := k.autoscalingAnnotation(maxScaleAnnotation, config.DefaultMaxScaleValue)
+	if minScale > maxScale {
+		return apis.ErrGeneric(fmt.Sprintf("%s must be less than or equal to %s", minScaleAnnotation, maxScaleAnnotation)).
+			ViaField("metadata", "annotations")
+	}
+	return nil
+}
+
+func (k *KafkaSource) autoscalingAnnotation(key string, defaults int64) (int64, error) {
+	value, ok := k.Annotations[key]
+	if !ok {
+		return defaults, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetAutoscalingSettings(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		want        *AutoscalingSettings
+		wantErr     bool
+	}{
+		"not autoscaled": {
+			annotations: map[string]string{minScaleAnnotation: "2"},
+		},
+		"defaults": {
+			annotations: map[string]string{classAnnotation: "keda.autoscaling.knative.dev"},
+			want: &AutoscalingSettings{
+				MinScale:        1,
+				MaxScale:        1,
+				PollingInterval: 30 * time.Second,
+				CooldownPeriod:  300 * time.Second,
+				LagThreshold:    10,
+			},
+		},
+		"all set": {
+			annotations: map[string]string{
+				classAnnotation:             "keda.autoscaling.knative.dev",
+				minScaleAnnotation:          "0",
+				maxScaleAnnotation:          "8",
+				pollingIntervalAnnotation:   "10",
+				cooldownPeriodAnnotation:    "60",
+				kafkaLagThresholdAnnotation: "100",
+			},
+			want: &AutoscalingSettings{
+				MinScale:        0,
+				MaxScale:        8,
+				PollingInterval: 10 * time.Second,
+				CooldownPeriod:  60 * time.Second,
+				LagThreshold:    100,
+			},
+		},
+		"built-in autoscaler": {
+			annotations: map[string]string{
+				classAnnotation:    "kafka.autoscaling.knative.dev",
+				maxScaleAnnotation: "3",
+			},
+			want: &AutoscalingSettings{
+				MinScale:        1,
+				MaxScale:        3,
+				PollingInterval: 30 * time.Second,
+				CooldownPeriod:  300 * time.Second,
+				LagThreshold:    10,
+			},
+		},
+		"invalid value": {
+			annotations: map[string]string{
+				classAnnotation:    "keda.autoscaling.knative.dev",
+				maxScaleAnnotation: "many",
+			},
+			wantErr: true,
+		},
+		"zero lag threshold": {
+			annotations: map[string]string{
+				classAnnotation:             "keda.autoscaling.knative.dev",
+				kafkaLagThresholdAnnotation: "0",
+			},
+			wantErr: true,
+		},
+		"min scale greater than max scale": {
+			annotations: map[string]string{
+				classAnnotation:    "keda.autoscaling.knative.dev",
+				minScaleAnnotation: "3",
+				maxScaleAnnotation: "2",
+			},
+			wantErr: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &KafkaSource{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got, err := src.GetAutoscalingSettings()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected settings (-want, +got):", diff)
+			}
+			wantNative := tc.annotations[classAnnotation] == "kafka.autoscaling.knative.dev"
+			if src.IsNativelyAutoscaled() != wantNative {
+				t.Errorf("unexpected natively autoscaled, want %t", wantNative)
+			}
+			if (src.validateAutoscaling() != nil) != tc.wantErr {
+				t.Errorf("unexpected validation error: %v", src.validateAutoscaling())
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func (k *KafkaSource) SetDefaults(ctx context.Context) {
 
 	kafkaConfig := config.FromContextOrDefaults(ctx)
 	kafkaDefaults := kafkaConfig.KafkaSourceDefaults
-	if kafkaDefaults.AutoscalingClass == config.KedaAutoscalingClass {
+	if kafkaDefaults.AutoscalingClass == config.KedaAutoscalingClass || kafkaDefaults.AutoscalingClass == config.KafkaAutoscalingClass {
 		if k.Annotations == nil {
 			k.Annotations = map[string]string{}
 		}
@@ -156,6 +156,26 @@ func TestSetDefaults(t *testing.T) {
 				kafkaLagThresholdAnnotation: "100",
 			},
 			AssertFuncs: []assertFnType{assertAnnotations},
+		}, {
+			Name: "built-in autoscaling config",
+			Defaults: config.KafkaSourceDefaults{
+				AutoscalingClass:  "kafka.autoscaling.knative.dev",
+				MinScale:          1,
+				MaxScale:          5,
+				PollingInterval:   30,
+				CooldownPeriod:    300,
+				KafkaLagThreshold: 10,
+			},
+			Initial: KafkaSource{},
+			Expected: map[string]string{
+				classAnnotation:             "kafka.autoscaling.knative.dev",
+				minScaleAnnotation:          "1",
+				maxScaleAnnotation:          "5",
+				pollingIntervalAnnotation:   "30",
+				cooldownPeriodAnnotation:    "300",
+				kafkaLagThresholdAnnotation: "10",
+			},
+			AssertFuncs: []assertFnType{assertAnnotations},
 		},
 	}
 
@@ -31,6 +31,14 @@ const (
 	// KafkaConditionSinkProvided has status True when the KafkaSource has been configured with a sink target.
 	KafkaConditionSinkProvided apis.ConditionType = "SinkProvided"
 
+	// KafkaConditionDeadLetterSinkResolved has status True when the dead letter sink of the KafkaSource
+	// has been resolved, or when it does not have any.
+	KafkaConditionDeadLetterSinkResolved apis.ConditionType = "DeadLetterSinkResolved"
+
+	// KafkaConditionReplySinkResolved has status True when the reply sink of the KafkaSource has been
+	// resolved, or when it does not have any.
+	KafkaConditionReplySinkResolved apis.ConditionType = "ReplySinkResolved"
+
 	// KafkaConditionDeployed has status True when the KafkaSource has had it's receive adapter deployment created.
 	KafkaConditionDeployed apis.ConditionType = "Deployed"
 
@@ -50,6 +58,8 @@ const (
 var (
 	KafkaSourceCondSet = apis.NewLivingConditionSet(
 		KafkaConditionSinkProvided,
+		KafkaConditionDeadLetterSinkResolved,
+		KafkaConditionReplySinkResolved,
 		KafkaConditionDeployed,
 		KafkaConditionConnectionEstablished,
 		KafkaConditionInitialOffsetsCommitted,
@@ -100,6 +110,40 @@ func (s *KafkaSourceStatus) MarkNoSink(reason, messageFormat string, messageA ..
 	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+// MarkDeadLetterSinkResolved sets the condition that the dead letter sink of the source has been resolved.
+func (s *KafkaSourceStatus) MarkDeadLetterSinkResolved(uri *apis.URL) {
+	s.DeadLetterSinkURI = uri
+	KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionDeadLetterSinkResolved)
+}
+
+// MarkDeadLetterSinkNotConfigured sets the condition that the source does not have a dead letter sink.
+func (s *KafkaSourceStatus) MarkDeadLetterSinkNotConfigured() {
+	s.DeadLetterSinkURI = nil
+	KafkaSourceCondSet.Manage(s).MarkTrueWithReason(KafkaConditionDeadLetterSinkResolved, "DeadLetterSinkNotConfigured", "No dead letter sink is configured.")
+}
+
+// MarkDeadLetterSinkNotResolved sets the condition that the dead letter sink of the source could not be resolved.
+func (s *KafkaSourceStatus) MarkDeadLetterSinkNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionDeadLetterSinkResolved, reason, messageFormat, messageA...)
+}
+
+// MarkReplySinkResolved sets the condition that the reply sink of the source has been resolved.
+func (s *KafkaSourceStatus) MarkReplySinkResolved(uri *apis.URL) {
+	s.ReplyURI = uri
+	KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionReplySinkResolved)
+}
+
+// MarkReplySinkNotConfigured sets the condition that the source does not have a reply sink.
+func (s *KafkaSourceStatus) MarkReplySinkNotConfigured() {
+	s.ReplyURI = nil
+	KafkaSourceCondSet.Manage(s).MarkTrueWithReason(KafkaConditionReplySinkResolved, "ReplySinkNotConfigured", "No reply sink is configured.")
+}
+
+// MarkReplySinkNotResolved sets the condition that the reply sink of the source could not be resolved.
+func (s *KafkaSourceStatus) MarkReplySinkNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionReplySinkResolved, reason, messageFormat, messageA...)
+}
+
 func DeploymentIsAvailable(d *appsv1.DeploymentStatus, def bool) bool {
 	// Check if the Deployment is available.
 	for _, cond := range d.Conditions {
@@ -161,3 +205,8 @@ func (s *KafkaSourceStatus) MarkInitialOffsetNotCommitted(reason, messageFormat
 func (s *KafkaSourceStatus) UpdateConsumerGroupStatus(status string) {
 	s.Claims = status
 }
+
+// UpdateClaimedPartitions sets the partitions claimed by the consumers.
+func (s *KafkaSourceStatus) UpdateClaimedPartitions(claims []TopicClaims) {
+	s.ClaimedPartitions = claims
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	"knative.dev/pkg/apis"
 )
 
@@ -24,10 +26,14 @@ const (
 
 	// KafkaConditionScheduled is True when all KafkaSource consumers has been scheduled
 	KafkaConditionScheduled apis.ConditionType = "Scheduled"
+
+	// KafkaConditionDispatching is True when the adapter of the KafkaSource is running on the
+	// pods of all its placements. It does not affect the readiness of the KafkaSource.
+	KafkaConditionDispatching apis.ConditionType = "Dispatching"
 )
 
 var (
-	KafkaMTSourceCondSet = apis.NewLivingConditionSet(KafkaConditionSinkProvided, KafkaConditionScheduled, KafkaConditionInitialOffsetsCommitted, KafkaConditionConnectionEstablished)
+	KafkaMTSourceCondSet = apis.NewLivingConditionSet(KafkaConditionSinkProvided, KafkaConditionDeadLetterSinkResolved, KafkaConditionReplySinkResolved, KafkaConditionScheduled, KafkaConditionInitialOffsetsCommitted, KafkaConditionConnectionEstablished)
 )
 
 func (s *KafkaSourceStatus) MarkScheduled() {
@@ -37,3 +43,26 @@ func (s *KafkaSourceStatus) MarkScheduled() {
 func (s *KafkaSourceStatus) MarkNotScheduled(reason, messageFormat string, messageA ...interface{}) {
 	KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionScheduled, reason, messageFormat, messageA...)
 }
+
+// UpdatePlacementHealth sets the health of the adapter on the pods of the
+// placements, and whether the adapter is dispatching on all of them.
+func (s *KafkaSourceStatus) UpdatePlacementHealth(health []PlacementHealth) {
+	s.PlacementHealth = health
+	if len(health) == 0 {
+		KafkaSourceCondSet.Manage(s).ClearCondition(KafkaConditionDispatching)
+		return
+	}
+
+	var failing []string
+	for _, h := range health {
+		if !h.Dispatching {
+			failing = append(failing, h.PodName)
+		}
+	}
+	if len(failing) > 0 {
+		KafkaSourceCondSet.Manage(s).MarkFalse(KafkaConditionDispatching, "AdapterFailing",
+			"The adapter is failing on the pods: %s", strings.Join(failing, ", "))
+		return
+	}
+	KafkaSourceCondSet.Manage(s).MarkTrue(KafkaConditionDispatching)
+}
@@ -206,6 +206,8 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 			s.MarkSink(nil)
 			s.MarkDeployed(availableDeployment)
 			s.MarkSink(apis.HTTP("example"))
+			s.MarkDeadLetterSinkNotConfigured()
+			s.MarkReplySinkNotConfigured()
 			s.MarkConnectionEstablished()
 			s.MarkInitialOffsetCommitted()
 			return s
@@ -215,6 +217,57 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 			Type:   KafkaConditionReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "mark all resolved and deployed then dead letter sink not resolved",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkDeadLetterSinkResolved(apis.HTTP("dls"))
+			s.MarkReplySinkResolved(apis.HTTP("reply"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkConnectionEstablished()
+			s.MarkInitialOffsetCommitted()
+			s.MarkDeadLetterSinkNotResolved("Testing", "hi%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionReady,
+		want: &apis.Condition{
+			Type:    KafkaConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Testing",
+			Message: "hi",
+		},
+	}, {
+		name: "mark reply sink not resolved does not affect the sink",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("example"))
+			s.MarkReplySinkNotResolved("Testing", "hi%s", "")
+			return s
+		}(),
+		condQuery: KafkaConditionSinkProvided,
+		want: &apis.Condition{
+			Type:   KafkaConditionSinkProvided,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark dead letter sink not configured",
+		s: func() *KafkaSourceStatus {
+			s := &KafkaSourceStatus{}
+			s.InitializeConditions()
+			s.MarkDeadLetterSinkResolved(apis.HTTP("dls"))
+			s.MarkDeadLetterSinkNotConfigured()
+			return s
+		}(),
+		condQuery: KafkaConditionDeadLetterSinkResolved,
+		want: &apis.Condition{
+			Type:    KafkaConditionDeadLetterSinkResolved,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DeadLetterSinkNotConfigured",
+			Message: "No dead letter sink is configured.",
+		},
 	}}
 
 	for _, test := range tests {
@@ -228,3 +281,40 @@ func TestKafkaSourceStatusGetCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestKafkaSourceStatusUpdatePlacementHealth(t *testing.T) {
+	s := &KafkaSourceStatus{}
+	s.InitializeConditions()
+
+	s.UpdatePlacementHealth([]PlacementHealth{
+		{PodName: "pod-0", Dispatching: true},
+		{PodName: "pod-1", Dispatching: true, Restarts: 1},
+	})
+	if got := s.GetCondition(KafkaConditionDispatching); got == nil || got.Status != corev1.ConditionTrue {
+		t.Errorf("Dispatching=%v, want True", got)
+	}
+
+	s.UpdatePlacementHealth([]PlacementHealth{
+		{PodName: "pod-0", Dispatching: true},
+		{PodName: "pod-1", Restarts: 2, Message: "panic while handling messages"},
+	})
+	want := &apis.Condition{
+		Type:     KafkaConditionDispatching,
+		Status:   corev1.ConditionFalse,
+		Reason:   "AdapterFailing",
+		Message:  "The adapter is failing on the pods: pod-1",
+		Severity: apis.ConditionSeverityInfo,
+	}
+	ignoreTime := cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime")
+	if diff := cmp.Diff(want, s.GetCondition(KafkaConditionDispatching), ignoreTime); diff != "" {
+		t.Error("unexpected condition (-want, +got) =", diff)
+	}
+	if got := s.GetCondition(KafkaConditionReady); got.Status != corev1.ConditionUnknown {
+		t.Errorf("Ready=%v, want Unknown", got.Status)
+	}
+
+	s.UpdatePlacementHealth(nil)
+	if got := s.GetCondition(KafkaConditionDispatching); got != nil {
+		t.Errorf("Dispatching=%v, want nil", got)
+	}
+}
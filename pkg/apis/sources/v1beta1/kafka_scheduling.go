@@ -28,16 +28,22 @@ func (k *KafkaSource) GetKey() types.NamespacedName {
 	}
 }
 
+// GetVReplicas returns the vreplicas decided by the autoscaler when the source
+// is autoscaled, or Spec.Consumers otherwise, capped by MaxAllowedVReplicas.
 func (k *KafkaSource) GetVReplicas() int32 {
-	if k.Spec.Consumers == nil {
+	vreplicas := k.Spec.Consumers
+	if k.Status.Autoscaling != nil {
+		vreplicas = &k.Status.Autoscaling.Replicas
+	}
+	if vreplicas == nil {
 		return 1
 	}
 	if k.Status.MaxAllowedVReplicas != nil {
-		if *k.Spec.Consumers > *k.Status.MaxAllowedVReplicas {
+		if *vreplicas > *k.Status.MaxAllowedVReplicas {
 			return *k.Status.MaxAllowedVReplicas
 		}
 	}
-	return *k.Spec.Consumers
+	return *vreplicas
 }
 
 func (k *KafkaSource) GetPlacements() []v1alpha1.Placement {
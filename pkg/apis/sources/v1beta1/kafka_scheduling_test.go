@@ -67,6 +67,29 @@ func TestScheduling(t *testing.T) {
 			},
 			rsrcversion: "12345",
 		},
+		"autoscaled": {
+			source: KafkaSource{
+				Spec: KafkaSourceSpec{
+					Consumers: pointer.Int32Ptr(4),
+				},
+				Status: KafkaSourceStatus{
+					Autoscaling: &AutoscalingStatus{Replicas: 6},
+					Placeable:   v1alpha1.Placeable{MaxAllowedVReplicas: pointer.Int32Ptr(5)},
+				},
+			},
+			vreplicas: int32(5),
+		},
+		"scaled to zero": {
+			source: KafkaSource{
+				Spec: KafkaSourceSpec{
+					Consumers: pointer.Int32Ptr(4),
+				},
+				Status: KafkaSourceStatus{
+					Autoscaling: &AutoscalingStatus{Replicas: 0},
+				},
+			},
+			vreplicas: int32(0),
+		},
 	}
 
 	for n, tc := range testCases {
@@ -18,7 +18,15 @@ package v1beta1
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/Shopify/sarama"
+	"github.com/rickb777/date/period"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/duck/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,18 +73,103 @@ type KafkaSourceSpec struct {
 	bindingsv1beta1.KafkaAuthSpec `json:",inline"`
 
 	// Topic topics to consume messages from
-	// +required
-	Topics []string `json:"topics"`
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
+	// TopicPattern is a regular expression matched against the full name of
+	// the topics of the cluster, as an alternative to Topics. The matching
+	// topics are periodically refreshed, so that topics created after the
+	// source are consumed as well.
+	// +optional
+	TopicPattern string `json:"topicPattern,omitempty"`
 
 	// ConsumerGroupID is the consumer group ID.
 	// +optional
 	ConsumerGroup string `json:"consumerGroup,omitempty"`
 
+	// ConsumerGroupCleanup is the policy applied to the consumer group when
+	// the source is deleted, either delete (the default) or retain. The
+	// deletion of the consumer group, and of its committed offsets, is
+	// retried until the group has no active member left.
+	// +optional
+	ConsumerGroupCleanup ConsumerGroupCleanupPolicy `json:"consumerGroupCleanup,omitempty"`
+
 	// InitialOffset is the Initial Offset for the consumer group.
-	// should be earliest or latest
+	// should be earliest, latest, a timestamp in the time.RFC3339 format
+	// or a negative duration (e.g. -1h) relative to the time the consumer
+	// group offsets are initialized
 	// +optional
 	InitialOffset Offset `json:"initialOffset,omitempty"`
 
+	// IsolationLevel is the isolation level of the consumer group, either
+	// read_uncommitted (the default) or read_committed. With read_committed
+	// the records of aborted transactions are not delivered, and the
+	// records of open transactions are delivered once committed.
+	// +optional
+	IsolationLevel IsolationLevel `json:"isolationLevel,omitempty"`
+
+	// ConsumerConfig optionally overrides the Sarama consumer settings of
+	// the global configuration (config-kafka) for this source.
+	// +optional
+	ConsumerConfig *ConsumerConfigSpec `json:"consumerConfig,omitempty"`
+
+	// Deserializer optionally decodes Confluent wire format Avro or Protobuf
+	// message values and keys to JSON, resolving their schemas against a
+	// schema registry.
+	// +optional
+	Deserializer *DeserializerSpec `json:"deserializer,omitempty"`
+
+	// CloudEventMapping optionally sets the attributes of the CloudEvents
+	// created from the Kafka records which are not CloudEvents.
+	// +optional
+	CloudEventMapping *CloudEventMappingSpec `json:"cloudEventMapping,omitempty"`
+
+	// HeaderMapping optionally configures how the headers of the Kafka
+	// records which are not CloudEvents are converted to CloudEvent
+	// extensions. Every header is set as the kafkaheader<name> extension
+	// by default.
+	// +optional
+	HeaderMapping *HeaderMappingSpec `json:"headerMapping,omitempty"`
+
+	// DetectStructuredCloudEvents enables the detection of the structured
+	// CloudEvents written without the application/cloudevents+json
+	// content-type header. Record values which are valid structured
+	// CloudEvents JSON are sent as the CloudEvent itself instead of being
+	// wrapped in a new CloudEvent.
+	// +optional
+	DetectStructuredCloudEvents bool `json:"detectStructuredCloudEvents,omitempty"`
+
+	// Tombstones optionally configures how the records without value (e.g.
+	// the tombstones of compacted topics) are handled. They are sent as
+	// events without data by default.
+	// +optional
+	Tombstones *TombstoneSpec `json:"tombstones,omitempty"`
+
+	// Batching optionally aggregates the records of each partition into
+	// batches, which are sent to the sink as a single CloudEvents batch
+	// (application/cloudevents-batch+json) request.
+	// +optional
+	Batching *BatchingSpec `json:"batching,omitempty"`
+
+	// Reply optionally forwards the CloudEvents returned by the sink in its
+	// responses, either to another sink or to a Kafka topic. They are
+	// discarded by default.
+	// +optional
+	Reply *ReplySpec `json:"reply,omitempty"`
+
+	// Delivery contains the retry, backoff, timeout and dead letter sink
+	// options used when sending events to the sink. Events which can not
+	// be delivered are sent to the dead letter sink (if any) so that the
+	// consumption of the partition is not blocked.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// SinkAuth optionally configures the credentials presented to the sink:
+	// a bearer token and/or a client certificate. They are not presented to
+	// the dead letter and reply sinks.
+	// +optional
+	SinkAuth *SinkAuthSpec `json:"sinkAuth,omitempty"`
+
 	// inherits duck/v1 SourceSpec, which currently provides:
 	// * Sink - a reference to an object that will resolve to a domain name or
 	//   a URI directly to use as the sink.
@@ -85,12 +178,344 @@ type KafkaSourceSpec struct {
 	duckv1.SourceSpec `json:",inline"`
 }
 
+// DeserializerSpec defines how message values and keys are decoded.
+type DeserializerSpec struct {
+	// SchemaRegistryURL is the URL of the Confluent compatible schema
+	// registry used to resolve the schema IDs of the messages.
+	// +required
+	SchemaRegistryURL string `json:"schemaRegistryUrl"`
+
+	// Value is the schema format of the message values. Values are
+	// forwarded unchanged when not specified.
+	// +optional
+	Value SchemaFormat `json:"value,omitempty"`
+
+	// Key is the schema format of the message keys. When specified it
+	// takes precedence over the key type label.
+	// +optional
+	Key SchemaFormat `json:"key,omitempty"`
+}
+
+// CloudEventMappingSpec defines the Go templates (text/template) of the
+// CloudEvent attributes. The templates are executed against the Kafka record,
+// which provides the .Topic, .Partition, .Offset, .Key and .Headers (by name)
+// fields, as well as the .Value parsed as JSON. The default attribute is used
+// when a template fails or renders an empty string (e.g. a missing field).
+type CloudEventMappingSpec struct {
+	// Type is the template of the CloudEvent type attribute.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// ID is the template of the CloudEvent id attribute.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Subject is the template of the CloudEvent subject attribute.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Source is the template of the CloudEvent source attribute, which
+	// must render a URI reference.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// ReplySpec defines where the CloudEvents returned by the sink are forwarded.
+// Exactly one of Sink and Topic must be set.
+type ReplySpec struct {
+	// Sink is the destination the replies are sent to.
+	// +optional
+	Sink *duckv1.Destination `json:"sink,omitempty"`
+
+	// Topic is the Kafka topic the replies are produced to, on the cluster
+	// of the KafkaSource.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+}
+
+// SinkAuthSpec defines the credentials presented to a sink. At most one of
+// BearerToken and ServiceAccountToken can be set.
+type SinkAuthSpec struct {
+	// BearerToken is the Kubernetes secret containing the token sent to the
+	// sink in the Authorization header.
+	// +optional
+	BearerToken *bindingsv1beta1.SecretValueFromSource `json:"bearerToken,omitempty"`
+
+	// ServiceAccountToken requests a token of a service account, sent to the
+	// sink in the Authorization header.
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSpec `json:"serviceAccountToken,omitempty"`
+
+	// TLS is the client certificate presented to the sink, and the CA
+	// certificate verifying the sink.
+	// +optional
+	TLS *SinkTLSSpec `json:"tls,omitempty"`
+}
+
+// ServiceAccountTokenSpec defines a token of a service account, bound to an
+// audience. The service account must allow the audience in its
+// ServiceAccountTokenAudiencesAnnotation.
+type ServiceAccountTokenSpec struct {
+	// ServiceAccountName is the name of the service account of the token, in
+	// the namespace of the resource. Defaults to the default service account.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Audience is the intended audience of the token, usually the identifier
+	// of the sink. It cannot be an audience of the Kubernetes API server.
+	// +required
+	Audience string `json:"audience"`
+
+	// ExpirationSeconds is the requested validity of the token, renewed before
+	// expiring. Defaults to 1 hour, and must be at least 10 minutes.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+const (
+	// DefaultServiceAccountTokenExpirationSeconds is the default validity of
+	// the service account tokens.
+	DefaultServiceAccountTokenExpirationSeconds = int64(3600)
+
+	// MinServiceAccountTokenExpirationSeconds is the minimum validity of the
+	// service account tokens accepted by the Kubernetes API server.
+	MinServiceAccountTokenExpirationSeconds = int64(600)
+
+	// ServiceAccountTokenAudiencesAnnotation is the annotation of a service
+	// account listing, comma separated, the audiences its tokens can be
+	// requested for by a ServiceAccountTokenSpec. The tokens of the service
+	// accounts without it are never presented to a sink.
+	ServiceAccountTokenAudiencesAnnotation = "kafka.eventing.knative.dev/sink-token-audiences"
+)
+
+// apiServerAudiences are the usual audiences of the Kubernetes API server,
+// which the tokens presented to a sink cannot be requested for.
+var apiServerAudiences = map[string]bool{
+	"api":                                          true,
+	"kubernetes":                                   true,
+	"kubernetes.default":                           true,
+	"kubernetes.default.svc":                       true,
+	"kubernetes.default.svc.cluster.local":         true,
+	"https://kubernetes.default":                   true,
+	"https://kubernetes.default.svc":               true,
+	"https://kubernetes.default.svc.cluster.local": true,
+}
+
+// IsAPIServerAudience returns whether audience is one of the usual audiences
+// of the Kubernetes API server.
+func IsAPIServerAudience(audience string) bool {
+	return apiServerAudiences[strings.TrimSuffix(strings.ToLower(strings.TrimSpace(audience)), "/")]
+}
+
+// SinkTLSSpec defines the client certificate presented to a sink.
+type SinkTLSSpec struct {
+	// Cert is the Kubernetes secret containing the client certificate.
+	// +optional
+	Cert bindingsv1beta1.SecretValueFromSource `json:"cert,omitempty"`
+
+	// Key is the Kubernetes secret containing the client key.
+	// +optional
+	Key bindingsv1beta1.SecretValueFromSource `json:"key,omitempty"`
+
+	// CACert is the Kubernetes secret containing the CA certificate verifying
+	// the sink, instead of the system ones.
+	// +optional
+	CACert bindingsv1beta1.SecretValueFromSource `json:"caCert,omitempty"`
+}
+
+// BatchingSpec defines when the batches of records are sent to the sink.
+type BatchingSpec struct {
+	// MaxEvents is the maximum number of events of a batch.
+	// +required
+	MaxEvents int32 `json:"maxEvents"`
+
+	// MaxBytes is the maximum size of the keys and values of the records of
+	// a batch. A batch is sent as soon as it reaches it. The size of the
+	// batches is not limited when not specified.
+	// +optional
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+
+	// MaxWait is the maximum duration a batch waits for more records after
+	// its first record, in the ISO 8601 format. It is one second by default.
+	// +optional
+	MaxWait *string `json:"maxWait,omitempty"`
+}
+
+// DefaultBatchMaxWait is the default maximum duration a batch waits for more records.
+const DefaultBatchMaxWait = time.Second
+
+// ParseMaxWait returns the maximum duration a batch waits for more records.
+func (bs *BatchingSpec) ParseMaxWait() (time.Duration, error) {
+	if bs.MaxWait == nil {
+		return DefaultBatchMaxWait, nil
+	}
+	return ParseISO8601Duration(*bs.MaxWait)
+}
+
+// ConsumerConfigSpec defines the Sarama consumer settings of a source. The
+// settings which are not specified are taken from the global configuration.
+// Durations are in the ISO 8601 format.
+type ConsumerConfigSpec struct {
+	// Fetch is the number of bytes fetched per partition in each request.
+	// +optional
+	Fetch *FetchSpec `json:"fetch,omitempty"`
+
+	// SessionTimeout is the timeout after which the consumer is removed
+	// from the group when the broker receives no heartbeat.
+	// +optional
+	SessionTimeout *string `json:"sessionTimeout,omitempty"`
+
+	// HeartbeatInterval is the expected time between heartbeats. It must
+	// be lower than the session timeout.
+	// +optional
+	HeartbeatInterval *string `json:"heartbeatInterval,omitempty"`
+
+	// RebalanceTimeout is the maximum time allowed for each consumer to
+	// join the group once a rebalance has begun.
+	// +optional
+	RebalanceTimeout *string `json:"rebalanceTimeout,omitempty"`
+
+	// MaxProcessingTime is the maximum time a record is expected to take
+	// to be processed before the partition stops being fetched.
+	// +optional
+	MaxProcessingTime *string `json:"maxProcessingTime,omitempty"`
+
+	// RebalanceStrategy is the strategy assigning the partitions to the
+	// consumers of the group, either range, roundrobin or sticky.
+	// +optional
+	RebalanceStrategy RebalanceStrategy `json:"rebalanceStrategy,omitempty"`
+}
+
+// FetchSpec defines the number of bytes fetched per partition in each request.
+type FetchSpec struct {
+	// Min is the minimum number of bytes the broker waits for before
+	// answering a request.
+	// +optional
+	Min int32 `json:"min,omitempty"`
+
+	// Default is the number of bytes requested, which should be larger
+	// than most of the records.
+	// +optional
+	Default int32 `json:"default,omitempty"`
+
+	// Max is the maximum number of bytes requested, which the default
+	// grows up to when a record is larger than it.
+	// +optional
+	Max int32 `json:"max,omitempty"`
+}
+
+type RebalanceStrategy string
+
+const (
+	// RebalanceStrategyRange assigns ranges of consecutive partitions to
+	// each consumer
+	RebalanceStrategyRange RebalanceStrategy = "range"
+
+	// RebalanceStrategyRoundRobin assigns the partitions to the consumers
+	// in turns
+	RebalanceStrategyRoundRobin RebalanceStrategy = "roundrobin"
+
+	// RebalanceStrategySticky assigns the partitions evenly while keeping
+	// as many of the previous assignments as possible
+	RebalanceStrategySticky RebalanceStrategy = "sticky"
+)
+
+// IsValid returns true if the RebalanceStrategy is empty (the default) or one
+// of the supported strategies.
+func (rs RebalanceStrategy) IsValid() bool {
+	return rs == "" || rs == RebalanceStrategyRange || rs == RebalanceStrategyRoundRobin || rs == RebalanceStrategySticky
+}
+
+// ParseISO8601Duration returns the duration of an ISO 8601 period (e.g. PT5S).
+func ParseISO8601Duration(s string) (time.Duration, error) {
+	p, err := period.Parse(s)
+	if err != nil {
+		return 0, err
+	}
+	d, _ := p.Duration()
+	return d, nil
+}
+
+// TombstonePolicy defines how the records without value are handled.
+type TombstonePolicy string
+
+const (
+	// TombstonePolicySkip commits the records without value without
+	// sending them to the sink.
+	TombstonePolicySkip TombstonePolicy = "skip"
+
+	// TombstonePolicyEmit sends the records without value as events of the
+	// tombstone type, which carry the record key.
+	TombstonePolicyEmit TombstonePolicy = "emit"
+)
+
+// TombstoneSpec defines how the records without value are handled.
+type TombstoneSpec struct {
+	// Policy is either skip or emit.
+	// +required
+	Policy TombstonePolicy `json:"policy"`
+
+	// Type is the CloudEvent type of the emitted records, which is
+	// dev.knative.kafka.delete by default.
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// EventType returns the CloudEvent type of the emitted records without value.
+func (ts *TombstoneSpec) EventType() string {
+	if ts.Type == "" {
+		return KafkaTombstoneEventType
+	}
+	return ts.Type
+}
+
+// HeaderMappingSpec defines which Kafka record headers are converted to
+// CloudEvent extensions, and how. Header names are matched case-insensitively.
+type HeaderMappingSpec struct {
+	// Drop drops all the headers, so that no header extension is set.
+	// +optional
+	Drop bool `json:"drop,omitempty"`
+
+	// Allow lists the only headers converted to extensions. All the
+	// headers are converted when empty.
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists the headers which are not converted to extensions.
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+
+	// Rename maps header names to the names of their extensions, instead
+	// of the default kafkaheader<name>.
+	// +optional
+	Rename map[string]string `json:"rename,omitempty"`
+
+	// Base64 sets the header values which are not valid UTF-8 as binary
+	// extensions, which are encoded in base64, instead of strings.
+	// +optional
+	Base64 bool `json:"base64,omitempty"`
+}
+
+type SchemaFormat string
+
+const (
+	// SchemaFormatAvro denotes Confluent wire format Avro data
+	SchemaFormatAvro SchemaFormat = "avro"
+
+	// SchemaFormatProtobuf denotes Confluent wire format Protobuf data
+	SchemaFormatProtobuf SchemaFormat = "protobuf"
+)
+
 type Offset string
 
 const (
 	// KafkaEventType is the Kafka CloudEvent type.
 	KafkaEventType = "dev.knative.kafka.event"
 
+	// KafkaTombstoneEventType is the default CloudEvent type of the emitted
+	// records without value.
+	KafkaTombstoneEventType = "dev.knative.kafka.delete"
+
 	KafkaKeyTypeLabel = "kafkasources.sources.knative.dev/key-type"
 
 	// OffsetEarliest denotes the earliest offset in the kafka partition
@@ -100,13 +525,133 @@ const (
 	OffsetLatest Offset = "latest"
 )
 
+type IsolationLevel string
+
+const (
+	// IsolationLevelReadUncommitted denotes that all the records are consumed,
+	// including the ones of aborted transactions
+	IsolationLevelReadUncommitted IsolationLevel = "read_uncommitted"
+
+	// IsolationLevelReadCommitted denotes that only the committed records of
+	// transactional producers are consumed
+	IsolationLevelReadCommitted IsolationLevel = "read_committed"
+)
+
+// IsValid returns true if the IsolationLevel is empty (the default) or one of
+// the supported levels.
+func (il IsolationLevel) IsValid() bool {
+	return il == "" || il == IsolationLevelReadUncommitted || il == IsolationLevelReadCommitted
+}
+
+type ConsumerGroupCleanupPolicy string
+
+const (
+	// ConsumerGroupCleanupDelete denotes that the consumer group is deleted
+	// along with the source
+	ConsumerGroupCleanupDelete ConsumerGroupCleanupPolicy = "delete"
+
+	// ConsumerGroupCleanupRetain denotes that the consumer group and its
+	// committed offsets are kept once the source is deleted
+	ConsumerGroupCleanupRetain ConsumerGroupCleanupPolicy = "retain"
+)
+
+// IsValid returns true if the ConsumerGroupCleanupPolicy is empty (the
+// default) or one of the supported policies.
+func (p ConsumerGroupCleanupPolicy) IsValid() bool {
+	return p == "" || p == ConsumerGroupCleanupDelete || p == ConsumerGroupCleanupRetain
+}
+
 var KafkaKeyTypeAllowed = []string{"string", "int", "float", "byte-array"}
 
+// IsTime returns true if the Offset is a timestamp or a relative duration
+// rather than earliest or latest.
+func (o Offset) IsTime() bool {
+	return o != OffsetEarliest && o != OffsetLatest
+}
+
+// ParseTime returns the point in time denoted by the Offset timestamp (RFC3339
+// format) or negative duration (relative to now).
+func (o Offset) ParseTime(now time.Time) (time.Time, error) {
+	if strings.HasPrefix(string(o), "-") {
+		duration, err := time.ParseDuration(string(o))
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(duration), nil
+	}
+	return time.Parse(time.RFC3339, string(o))
+}
+
+// ParseSaramaOffsetTime returns the Sarama Offset Time (OffsetOldest, OffsetNewest or
+// millis since epoch) denoted by the Offset.
+func (o Offset) ParseSaramaOffsetTime(now time.Time) (int64, error) {
+	switch o {
+	case OffsetEarliest:
+		return sarama.OffsetOldest, nil
+	case OffsetLatest, "":
+		return sarama.OffsetNewest, nil
+	}
+	offsetTime, err := o.ParseTime(now)
+	if err != nil {
+		return 0, err
+	}
+	return offsetTime.UnixNano() / 1000000, nil // Convert Nanos To Millis For Sarama
+}
+
+// ParseCloudEventTemplate parses a CloudEventMappingSpec attribute template. Executing
+// the template fails when it references a missing field.
+func ParseCloudEventTemplate(attribute, text string) (*template.Template, error) {
+	return template.New(attribute).Option("missingkey=error").Parse(text)
+}
+
+// CompileTopicPattern compiles a TopicPattern so that it matches full topic names.
+func CompileTopicPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 // KafkaEventSource returns the Kafka CloudEvent source.
 func KafkaEventSource(namespace, kafkaSourceName, topic string) string {
 	return fmt.Sprintf("/apis/v1/namespaces/%s/kafkasources/%s#%s", namespace, kafkaSourceName, topic)
 }
 
+// TopicClaims lists the claimed partitions of a topic.
+type TopicClaims struct {
+	// Topic is the name of the topic.
+	Topic string `json:"topic"`
+
+	// Partitions are the claimed partitions of the topic.
+	Partitions []PartitionClaim `json:"partitions"`
+}
+
+// PartitionClaim is a partition and the pod consuming it.
+type PartitionClaim struct {
+	// Partition is the ID of the partition.
+	Partition int32 `json:"partition"`
+
+	// Pod is the name of the pod consuming the partition, or its IP when
+	// its name is unknown.
+	Pod string `json:"pod"`
+}
+
+// PlacementHealth is the health of the adapter of a KafkaSource on the pod
+// of one of its placements.
+type PlacementHealth struct {
+	// PodName is the name of the pod, or its IP when its name is unknown.
+	PodName string `json:"podName"`
+
+	// Dispatching is true when the adapter is running on the pod.
+	Dispatching bool `json:"dispatching"`
+
+	// Restarts is the number of times the adapter failed and was restarted
+	// on the pod.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+
+	// Message is the last failure of the adapter on the pod.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // KafkaSourceStatus defines the observed state of KafkaSource.
 type KafkaSourceStatus struct {
 	// inherits duck/v1 SourceStatus, which currently provides:
@@ -130,9 +675,51 @@ type KafkaSourceStatus struct {
 	// +optional
 	Claims string `json:"claims,omitempty"`
 
+	// ClaimedPartitions lists the partitions of each topic claimed by the
+	// consumers of this KafkaSource instance, and the pod consuming them.
+	// +optional
+	ClaimedPartitions []TopicClaims `json:"claimedPartitions,omitempty"`
+
+	// Topics currently matched by Spec.TopicPattern (if any).
+	// +optional
+	Topics []string `json:"topics,omitempty"`
+
 	// Implement Placeable.
 	// +optional
 	v1alpha1.Placeable `json:",inline"`
+
+	// PlacementHealth lists the health of the adapter on the pod of each
+	// placement, as reported by the multi-tenant adapters.
+	// +optional
+	PlacementHealth []PlacementHealth `json:"placementHealth,omitempty"`
+
+	// Autoscaling is the state of the lag-based autoscaling of the source,
+	// when the source is autoscaled.
+	// +optional
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// DeliveryStatus contains the resolved URI of the Spec.Delivery
+	// dead letter sink (if any).
+	// +optional
+	eventingduckv1.DeliveryStatus `json:",inline"`
+
+	// ReplyURI is the resolved URI of the Spec.Reply sink (if any).
+	// +optional
+	ReplyURI *apis.URL `json:"replyUri,omitempty"`
+}
+
+// AutoscalingStatus is the state of the lag-based autoscaling of a KafkaSource.
+type AutoscalingStatus struct {
+	// Replicas is the number of replicas (or vreplicas) decided by the autoscaler.
+	Replicas int32 `json:"replicas"`
+
+	// Lag is the total lag of the consumer group, as last measured by the autoscaler.
+	// +optional
+	Lag int64 `json:"lag,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the number of replicas.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 func (*KafkaSource) GetGroupVersionKind() schema.GroupVersionKind {
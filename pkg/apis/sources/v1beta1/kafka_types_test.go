@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/google/go-cmp/cmp"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -44,3 +46,79 @@ func TestKafkaSourceGetStatus(t *testing.T) {
 		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
 	}
 }
+
+func TestOffsetParseSaramaOffsetTime(t *testing.T) {
+	now := time.Date(2021, 6, 1, 8, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		offset  Offset
+		want    int64
+		wantErr bool
+	}{
+		"earliest": {offset: OffsetEarliest, want: sarama.OffsetOldest},
+		"latest":   {offset: OffsetLatest, want: sarama.OffsetNewest},
+		"empty":    {offset: "", want: sarama.OffsetNewest},
+		"timestamp": {
+			offset: "2021-05-31T08:00:00Z",
+			want:   now.Add(-24*time.Hour).UnixNano() / 1000000,
+		},
+		"relative": {
+			offset: "-1h30m",
+			want:   now.Add(-90*time.Minute).UnixNano() / 1000000,
+		},
+		"invalid": {offset: "invalid", wantErr: true},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := tc.offset.ParseSaramaOffsetTime(now)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected offset time, want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTombstoneSpecEventType(t *testing.T) {
+	if got := (&TombstoneSpec{Policy: TombstonePolicyEmit}).EventType(); got != KafkaTombstoneEventType {
+		t.Errorf("unexpected event type, want %q, got %q", KafkaTombstoneEventType, got)
+	}
+	if got := (&TombstoneSpec{Policy: TombstonePolicyEmit, Type: "com.example.delete"}).EventType(); got != "com.example.delete" {
+		t.Errorf("unexpected event type, want %q, got %q", "com.example.delete", got)
+	}
+}
+
+func TestBatchingSpecParseMaxWait(t *testing.T) {
+	maxWait, invalid := "PT0.5S", "500ms"
+	testCases := map[string]struct {
+		spec    BatchingSpec
+		want    time.Duration
+		wantErr bool
+	}{
+		"default": {
+			spec: BatchingSpec{MaxEvents: 10},
+			want: DefaultBatchMaxWait,
+		},
+		"iso 8601": {
+			spec: BatchingSpec{MaxEvents: 10, MaxWait: &maxWait},
+			want: 500 * time.Millisecond,
+		},
+		"invalid": {
+			spec:    BatchingSpec{MaxEvents: 10, MaxWait: &invalid},
+			wantErr: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := tc.spec.ParseMaxWait()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error, want error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected max wait, want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
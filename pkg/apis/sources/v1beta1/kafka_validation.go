@@ -18,14 +18,20 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 )
 
 // Validate ensures KafkaSource is properly configured.
 func (ks *KafkaSource) Validate(ctx context.Context) *apis.FieldError {
 	errs := ks.Spec.Validate(ctx).ViaField("spec")
+	errs = errs.Also(ks.validateAutoscaling())
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*KafkaSource)
 		errs = errs.Also(ks.CheckImmutableFields(ctx, original))
@@ -40,16 +46,59 @@ func (kss *KafkaSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	errs = errs.Also(kss.Sink.Validate(ctx).ViaField("sink"))
 
 	// Check for mandatory fields
-	if len(kss.Topics) <= 0 {
-		errs = errs.Also(apis.ErrMissingField("topics"))
+	if len(kss.Topics) <= 0 && kss.TopicPattern == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("topics", "topicPattern"))
+	} else if len(kss.Topics) > 0 && kss.TopicPattern != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("topics", "topicPattern"))
+	} else if kss.TopicPattern != "" {
+		if _, err := CompileTopicPattern(kss.TopicPattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(kss.TopicPattern, "topicPattern", err.Error()))
+		}
 	}
 	if len(kss.BootstrapServers) <= 0 {
 		errs = errs.Also(apis.ErrMissingField("bootstrapServer"))
 	}
-	switch kss.InitialOffset {
-	case OffsetEarliest, OffsetLatest:
-	default:
-		errs = errs.Also(apis.ErrInvalidValue(kss.InitialOffset, "initialOffset"))
+	if kss.InitialOffset.IsTime() {
+		// Timestamps in the future and positive durations are not supported
+		now := time.Now()
+		offsetTime, err := kss.InitialOffset.ParseTime(now)
+		if err != nil || offsetTime.After(now) {
+			errs = errs.Also(apis.ErrInvalidValue(kss.InitialOffset, "initialOffset"))
+		}
+	}
+	if !kss.ConsumerGroupCleanup.IsValid() {
+		errs = errs.Also(apis.ErrInvalidValue(kss.ConsumerGroupCleanup, "consumerGroupCleanup"))
+	}
+	if !kss.IsolationLevel.IsValid() {
+		errs = errs.Also(apis.ErrInvalidValue(kss.IsolationLevel, "isolationLevel"))
+	}
+
+	errs = errs.Also(kss.ConsumerConfig.Validate(ctx).ViaField("consumerConfig"))
+	errs = errs.Also(kss.Deserializer.Validate(ctx).ViaField("deserializer"))
+	errs = errs.Also(kss.CloudEventMapping.Validate(ctx).ViaField("cloudEventMapping"))
+	errs = errs.Also(kss.HeaderMapping.Validate(ctx).ViaField("headerMapping"))
+	errs = errs.Also(kss.Tombstones.Validate(ctx).ViaField("tombstones"))
+	errs = errs.Also(kss.Batching.Validate(ctx).ViaField("batching"))
+	errs = errs.Also(kss.Reply.Validate(ctx).ViaField("reply"))
+	errs = errs.Also(kss.SinkAuth.Validate(ctx).ViaField("sinkAuth"))
+	if kss.Reply != nil && kss.Reply.Topic != "" {
+		for _, topic := range kss.Topics {
+			if topic == kss.Reply.Topic {
+				errs = errs.Also(apis.ErrInvalidValue(topic, "reply.topic", "the replies must not be produced to a consumed topic"))
+			}
+		}
+		if kss.TopicPattern != "" {
+			if pattern, err := CompileTopicPattern(kss.TopicPattern); err == nil && pattern.MatchString(kss.Reply.Topic) {
+				errs = errs.Also(apis.ErrInvalidValue(kss.Reply.Topic, "reply.topic", "the replies must not be produced to a consumed topic"))
+			}
+		}
+	}
+
+	// Validate delivery (the request timeout is always honoured by the receive
+	// adapters so it is not subject to the experimental delivery-timeout feature)
+	if kss.Delivery != nil {
+		deliveryCtx := feature.ToContext(ctx, feature.Flags{feature.DeliveryTimeout: feature.Enabled})
+		errs = errs.Also(kss.Delivery.Validate(deliveryCtx).ViaField("delivery"))
 	}
 
 	return errs
@@ -79,3 +128,302 @@ func (ks *KafkaSource) CheckImmutableFields(ctx context.Context, original *Kafka
 
 	return nil
 }
+
+func (ds *DeserializerSpec) Validate(ctx context.Context) *apis.FieldError {
+	if ds == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	if ds.SchemaRegistryURL == "" {
+		errs = errs.Also(apis.ErrMissingField("schemaRegistryUrl"))
+	} else if u, err := apis.ParseURL(ds.SchemaRegistryURL); err != nil || !u.URL().IsAbs() || (u.Scheme != "http" && u.Scheme != "https") {
+		errs = errs.Also(apis.ErrInvalidValue(ds.SchemaRegistryURL, "schemaRegistryUrl"))
+	}
+
+	if ds.Value == "" && ds.Key == "" {
+		errs = errs.Also(apis.ErrMissingOneOf("value", "key"))
+	}
+	errs = errs.Also(ds.Value.validate("value"))
+	errs = errs.Also(ds.Key.validate("key"))
+
+	return errs
+}
+
+func (sf SchemaFormat) validate(field string) *apis.FieldError {
+	switch sf {
+	case "", SchemaFormatAvro, SchemaFormatProtobuf:
+		return nil
+	default:
+		return apis.ErrInvalidValue(sf, field)
+	}
+}
+
+func (cms *CloudEventMappingSpec) Validate(ctx context.Context) *apis.FieldError {
+	if cms == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	for field, text := range map[string]string{
+		"type":    cms.Type,
+		"id":      cms.ID,
+		"subject": cms.Subject,
+		"source":  cms.Source,
+	} {
+		if _, err := ParseCloudEventTemplate(field, text); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(text, field, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func (rs *ReplySpec) Validate(ctx context.Context) *apis.FieldError {
+	if rs == nil {
+		return nil
+	}
+
+	if rs.Sink == nil && rs.Topic == "" {
+		return apis.ErrMissingOneOf("sink", "topic")
+	}
+	if rs.Sink != nil && rs.Topic != "" {
+		return apis.ErrMultipleOneOf("sink", "topic")
+	}
+	if rs.Sink != nil {
+		return rs.Sink.Validate(ctx).ViaField("sink")
+	}
+	return nil
+}
+
+func (sas *SinkAuthSpec) Validate(ctx context.Context) *apis.FieldError {
+	if sas == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	if sas.BearerToken != nil && sas.ServiceAccountToken != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("bearerToken", "serviceAccountToken"))
+	}
+	if sas.BearerToken != nil {
+		errs = errs.Also(validateSecretValue(sas.BearerToken, true).ViaField("bearerToken"))
+	}
+	if sat := sas.ServiceAccountToken; sat != nil {
+		if sat.Audience == "" {
+			errs = errs.Also(apis.ErrMissingField("audience").ViaField("serviceAccountToken"))
+		} else if IsAPIServerAudience(sat.Audience) {
+			errs = errs.Also(apis.ErrInvalidValue(sat.Audience, "audience",
+				"cannot be an audience of the Kubernetes API server").ViaField("serviceAccountToken"))
+		}
+		if sat.ExpirationSeconds != nil && *sat.ExpirationSeconds < MinServiceAccountTokenExpirationSeconds {
+			errs = errs.Also(apis.ErrInvalidValue(*sat.ExpirationSeconds, "expirationSeconds",
+				fmt.Sprintf("must be at least %d", MinServiceAccountTokenExpirationSeconds)).ViaField("serviceAccountToken"))
+		}
+	}
+	if tls := sas.TLS; tls != nil {
+		if (tls.Cert.SecretKeyRef == nil) != (tls.Key.SecretKeyRef == nil) {
+			errs = errs.Also(apis.ErrGeneric("cert and key must be set together", "cert", "key").ViaField("tls"))
+		}
+		errs = errs.Also(validateSecretValue(&tls.Cert, false).ViaField("tls", "cert"))
+		errs = errs.Also(validateSecretValue(&tls.Key, false).ViaField("tls", "key"))
+		errs = errs.Also(validateSecretValue(&tls.CACert, false).ViaField("tls", "caCert"))
+	}
+
+	return errs
+}
+
+func validateSecretValue(value *bindingsv1beta1.SecretValueFromSource, required bool) *apis.FieldError {
+	ref := value.SecretKeyRef
+	if ref == nil {
+		if required {
+			return apis.ErrMissingField("secretKeyRef")
+		}
+		return nil
+	}
+	var errs *apis.FieldError
+	if ref.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if ref.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	return errs.ViaField("secretKeyRef")
+}
+
+func (bs *BatchingSpec) Validate(ctx context.Context) *apis.FieldError {
+	if bs == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	if bs.MaxEvents < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(bs.MaxEvents, "maxEvents", "must be at least 1"))
+	}
+
+	if bs.MaxBytes < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(bs.MaxBytes, "maxBytes", "must not be negative"))
+	}
+
+	if bs.MaxWait != nil {
+		if maxWait, err := bs.ParseMaxWait(); err != nil || maxWait <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*bs.MaxWait, "maxWait"))
+		}
+	}
+
+	return errs
+}
+
+func (ccs *ConsumerConfigSpec) Validate(ctx context.Context) *apis.FieldError {
+	if ccs == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	errs = errs.Also(ccs.Fetch.Validate(ctx).ViaField("fetch"))
+
+	durations := make(map[string]time.Duration)
+	for field, value := range map[string]*string{
+		"sessionTimeout":    ccs.SessionTimeout,
+		"heartbeatInterval": ccs.HeartbeatInterval,
+		"rebalanceTimeout":  ccs.RebalanceTimeout,
+		"maxProcessingTime": ccs.MaxProcessingTime,
+	} {
+		if value == nil {
+			continue
+		}
+		if d, err := ParseISO8601Duration(*value); err != nil || d <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*value, field))
+		} else {
+			durations[field] = d
+		}
+	}
+	if session, ok := durations["sessionTimeout"]; ok {
+		if heartbeat, ok := durations["heartbeatInterval"]; ok && heartbeat >= session {
+			errs = errs.Also(apis.ErrInvalidValue(*ccs.HeartbeatInterval, "heartbeatInterval", "must be lower than the session timeout"))
+		}
+	}
+
+	if !ccs.RebalanceStrategy.IsValid() {
+		errs = errs.Also(apis.ErrInvalidValue(ccs.RebalanceStrategy, "rebalanceStrategy"))
+	}
+
+	return errs
+}
+
+func (fs *FetchSpec) Validate(ctx context.Context) *apis.FieldError {
+	if fs == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	if fs.Min < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fs.Min, "min", "must not be negative"))
+	}
+	if fs.Default < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fs.Default, "default", "must not be negative"))
+	}
+	if fs.Max < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fs.Max, "max", "must not be negative"))
+	}
+	if fs.Min > 0 && fs.Default > 0 && fs.Min > fs.Default {
+		errs = errs.Also(apis.ErrInvalidValue(fs.Default, "default", "must not be lower than min"))
+	}
+	if fs.Default > 0 && fs.Max > 0 && fs.Default > fs.Max {
+		errs = errs.Also(apis.ErrInvalidValue(fs.Max, "max", "must not be lower than default"))
+	}
+
+	return errs
+}
+
+func (ts *TombstoneSpec) Validate(ctx context.Context) *apis.FieldError {
+	if ts == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	switch ts.Policy {
+	case TombstonePolicyEmit:
+	case TombstonePolicySkip:
+		if ts.Type != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("type"))
+		}
+	case "":
+		errs = errs.Also(apis.ErrMissingField("policy"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(ts.Policy, "policy"))
+	}
+
+	return errs
+}
+
+// reservedExtensionNames are the CloudEvent attributes, and the extensions
+// set by the receive adapter, which headers cannot be renamed to.
+var reservedExtensionNames = map[string]bool{
+	"id":              true,
+	"source":          true,
+	"specversion":     true,
+	"type":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"subject":         true,
+	"time":            true,
+	"data":            true,
+	"key":             true,
+}
+
+func (hms *HeaderMappingSpec) Validate(ctx context.Context) *apis.FieldError {
+	if hms == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+
+	if hms.Drop {
+		if len(hms.Allow) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("drop", "allow"))
+		}
+		if len(hms.Deny) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("drop", "deny"))
+		}
+		if len(hms.Rename) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("drop", "rename"))
+		}
+	}
+
+	for i, header := range hms.Allow {
+		if header == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(header, "allow", i))
+		}
+	}
+	for i, header := range hms.Deny {
+		if header == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(header, "deny", i))
+		}
+	}
+
+	for header, extension := range hms.Rename {
+		if header == "" {
+			errs = errs.Also(apis.ErrInvalidKeyName(header, "rename", "header names must not be empty"))
+		}
+		if !isValidExtensionName(extension) {
+			errs = errs.Also(apis.ErrInvalidValue(extension, apis.CurrentField,
+				"extension names must consist of lower-case letters or digits").ViaKey(header).ViaField("rename"))
+		} else if reservedExtensionNames[extension] {
+			errs = errs.Also(apis.ErrInvalidValue(extension, apis.CurrentField,
+				"extension name is reserved").ViaKey(header).ViaField("rename"))
+		}
+	}
+
+	return errs
+}
+
+func isValidExtensionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -19,10 +19,15 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -96,6 +101,518 @@ func TestKafkaSourceCheckRequiredFields(t *testing.T) {
 			orig:    &fullSpec,
 			allowed: true,
 		},
+		"valid delivery": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				backoffPolicy := eventingduckv1.BackoffPolicyExponential
+				spec.Delivery = &eventingduckv1.DeliverySpec{
+					DeadLetterSink: &fullSpec.Sink,
+					Retry:          ptr.Int32(3),
+					BackoffPolicy:  &backoffPolicy,
+					BackoffDelay:   ptr.String("PT0.2S"),
+					Timeout:        ptr.String("PT10S"),
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"invalid delivery retry": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Delivery = &eventingduckv1.DeliverySpec{Retry: ptr.Int32(-1)}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"invalid delivery timeout": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Delivery = &eventingduckv1.DeliverySpec{Timeout: ptr.String("foo")}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"invalid delivery dead letter sink": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: &duckv1.Destination{}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"topic pattern": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				TopicPattern:  "tenant-.*",
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: true,
+		},
+		"topics and topic pattern": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.TopicPattern = "tenant-.*"
+				return spec
+			}(),
+			allowed: false,
+		},
+		"invalid topic pattern": {
+			orig: &KafkaSourceSpec{
+				KafkaAuthSpec: fullSpec.KafkaAuthSpec,
+				TopicPattern:  "tenant-(.*",
+				SourceSpec:    fullSpec.SourceSpec,
+				InitialOffset: OffsetLatest,
+			},
+			allowed: false,
+		},
+		"valid cloudevent mapping": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.CloudEventMapping = &CloudEventMappingSpec{
+					Type:    "com.example.{{ .Value.kind }}",
+					ID:      "{{ .Topic }}/{{ .Partition }}/{{ .Offset }}",
+					Subject: `{{ index .Headers "tenant" }}`,
+					Source:  "/tenants/{{ .Key }}",
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"invalid cloudevent mapping": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.CloudEventMapping = &CloudEventMappingSpec{ID: "{{ .Topic }"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"cloudevent mapping with unknown function": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.CloudEventMapping = &CloudEventMappingSpec{Type: "{{ upper .Topic }}"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"retained consumer group": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerGroupCleanup = ConsumerGroupCleanupRetain
+				return spec
+			}(),
+			allowed: true,
+		},
+		"invalid consumer group cleanup policy": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerGroupCleanup = "archive"
+				return spec
+			}(),
+			allowed: false,
+		},
+		"read committed isolation level": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.IsolationLevel = IsolationLevelReadCommitted
+				return spec
+			}(),
+			allowed: true,
+		},
+		"invalid isolation level": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.IsolationLevel = "serializable"
+				return spec
+			}(),
+			allowed: false,
+		},
+		"valid consumer config": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{
+					Fetch:             &FetchSpec{Min: 1, Default: 1048576, Max: 10485760},
+					SessionTimeout:    ptr.String("PT30S"),
+					HeartbeatInterval: ptr.String("PT3S"),
+					RebalanceTimeout:  ptr.String("PT1M"),
+					MaxProcessingTime: ptr.String("PT0.5S"),
+					RebalanceStrategy: RebalanceStrategySticky,
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"consumer config with negative fetch size": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{Fetch: &FetchSpec{Default: -1}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"consumer config with default fetch size above max": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{Fetch: &FetchSpec{Default: 2048, Max: 1024}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"consumer config with invalid session timeout": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{SessionTimeout: ptr.String("10s")}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"consumer config with heartbeat above session timeout": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{
+					SessionTimeout:    ptr.String("PT10S"),
+					HeartbeatInterval: ptr.String("PT10S"),
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"consumer config with invalid rebalance strategy": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.ConsumerConfig = &ConsumerConfigSpec{RebalanceStrategy: "cooperative"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"valid header mapping": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{
+					Deny:   []string{"Authorization"},
+					Rename: map[string]string{"X-Tenant-Id": "tenant"},
+					Base64: true,
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"header mapping dropping headers": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{Drop: true}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"header mapping dropping and allowing headers": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{Drop: true, Allow: []string{"tenant"}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"header mapping with empty header name": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{Deny: []string{""}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"header mapping with invalid extension name": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{Rename: map[string]string{"X-Tenant-Id": "tenant-id"}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"header mapping with reserved extension name": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.HeaderMapping = &HeaderMappingSpec{Rename: map[string]string{"X-Event-Type": "type"}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"skip tombstones": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Tombstones = &TombstoneSpec{Policy: TombstonePolicySkip}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"emit tombstones": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Tombstones = &TombstoneSpec{Policy: TombstonePolicyEmit, Type: "com.example.delete"}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"tombstones without policy": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Tombstones = &TombstoneSpec{Type: "com.example.delete"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"tombstones with invalid policy": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Tombstones = &TombstoneSpec{Policy: "forward"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"skip tombstones with type": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Tombstones = &TombstoneSpec{Policy: TombstonePolicySkip, Type: "com.example.delete"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"valid batching": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Batching = &BatchingSpec{MaxEvents: 100, MaxBytes: 1048576, MaxWait: ptr.String("PT0.5S")}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"batching without max events": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Batching = &BatchingSpec{MaxBytes: 1048576}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"batching with negative max bytes": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Batching = &BatchingSpec{MaxEvents: 100, MaxBytes: -1}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"batching with invalid max wait": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Batching = &BatchingSpec{MaxEvents: 100, MaxWait: ptr.String("500ms")}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"batching with zero max wait": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Batching = &BatchingSpec{MaxEvents: 100, MaxWait: ptr.String("PT0S")}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"reply sink": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{Sink: fullSpec.Sink.DeepCopy()}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"reply topic": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{Topic: "replies"}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"reply without sink or topic": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"reply sink and topic": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{Sink: fullSpec.Sink.DeepCopy(), Topic: "replies"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"invalid reply sink": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{Sink: &duckv1.Destination{}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"reply to a consumed topic": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Reply = &ReplySpec{Topic: "topics"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"reply to a topic matching the topic pattern": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Topics = nil
+				spec.TopicPattern = "orders-.*"
+				spec.Reply = &ReplySpec{Topic: "orders-replies"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"reply to a topic not matching the topic pattern": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Topics = nil
+				spec.TopicPattern = "orders-.*"
+				spec.Reply = &ReplySpec{Topic: "replies"}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"valid deserializer": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Deserializer = &DeserializerSpec{
+					SchemaRegistryURL: "http://schema-registry:8081",
+					Value:             SchemaFormatAvro,
+					Key:               SchemaFormatProtobuf,
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"deserializer without schema registry": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Deserializer = &DeserializerSpec{Value: SchemaFormatAvro}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"deserializer with relative schema registry": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Deserializer = &DeserializerSpec{SchemaRegistryURL: "schema-registry:8081", Value: SchemaFormatAvro}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"deserializer without formats": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Deserializer = &DeserializerSpec{SchemaRegistryURL: "http://schema-registry:8081"}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					BearerToken: &bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-auth", "token")},
+					TLS: &SinkTLSSpec{
+						Cert:   bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-tls", "tls.crt")},
+						Key:    bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-tls", "tls.key")},
+						CACert: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-tls", "ca.crt")},
+					},
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"sink auth service account token": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					ServiceAccountToken: &ServiceAccountTokenSpec{Audience: "https://gateway.example.com", ExpirationSeconds: ptr.Int64(600)},
+				}
+				return spec
+			}(),
+			allowed: true,
+		},
+		"sink auth bearer token and service account token": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					BearerToken:         &bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-auth", "token")},
+					ServiceAccountToken: &ServiceAccountTokenSpec{Audience: "https://gateway.example.com"},
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth bearer token without key": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					BearerToken: &bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-auth", "")},
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth service account token without audience": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{ServiceAccountToken: &ServiceAccountTokenSpec{}}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth service account token for the API server": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					ServiceAccountToken: &ServiceAccountTokenSpec{Audience: "https://kubernetes.default.svc.cluster.local"},
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth service account token with short expiration": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					ServiceAccountToken: &ServiceAccountTokenSpec{Audience: "https://gateway.example.com", ExpirationSeconds: ptr.Int64(60)},
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"sink auth cert without key": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.SinkAuth = &SinkAuthSpec{
+					TLS: &SinkTLSSpec{Cert: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("sink-tls", "tls.crt")}},
+				}
+				return spec
+			}(),
+			allowed: false,
+		},
+		"deserializer with unknown format": {
+			orig: func() *KafkaSourceSpec {
+				spec := fullSpec.DeepCopy()
+				spec.Deserializer = &DeserializerSpec{SchemaRegistryURL: "http://schema-registry:8081", Value: "json"}
+				return spec
+			}(),
+			allowed: false,
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -325,6 +842,26 @@ func TestKafkaSourceOffset(t *testing.T) {
 			allowed: false,
 			offset:  "invalid",
 		},
+		"timestamp offset": {
+			allowed: true,
+			offset:  "2021-06-01T08:00:00Z",
+		},
+		"future timestamp offset": {
+			allowed: false,
+			offset:  Offset(time.Now().Add(time.Hour).Format(time.RFC3339)),
+		},
+		"relative offset": {
+			allowed: true,
+			offset:  "-1h30m",
+		},
+		"positive relative offset": {
+			allowed: false,
+			offset:  "1h",
+		},
+		"invalid relative offset": {
+			allowed: false,
+			offset:  "-1 hour",
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -341,3 +878,25 @@ func TestKafkaSourceOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileTopicPattern(t *testing.T) {
+	pattern, err := CompileTopicPattern("tenant-[a-z]+|other")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for topic, want := range map[string]bool{
+		"tenant-a":     true,
+		"other":        true,
+		"tenant-a-dlq": false,
+		"my-tenant-a":  false,
+		"others":       false,
+	} {
+		if got := pattern.MatchString(topic); got != want {
+			t.Errorf("unexpected match of %q, want %t, got %t", topic, want, got)
+		}
+	}
+}
+
+func secretKeyRef(name, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
@@ -83,6 +83,8 @@ func TestMTKafkaSourceStatusGetCondition(t *testing.T) {
 			s := &v1beta1.KafkaSourceStatus{}
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
+			s.MarkDeadLetterSinkNotConfigured()
+			s.MarkReplySinkNotConfigured()
 			s.MarkScheduled()
 			s.MarkConnectionEstablished()
 			s.MarkInitialOffsetCommitted()
@@ -135,6 +137,8 @@ func TestMTKafkaSourceStatusGetCondition(t *testing.T) {
 			s.MarkScheduled()
 			s.MarkInitialOffsetCommitted()
 			s.MarkConnectionEstablished()
+			s.MarkDeadLetterSinkNotConfigured()
+			s.MarkReplySinkNotConfigured()
 			s.MarkSink(apis.HTTP("example"))
 			return s
 		}(),
@@ -23,8 +23,175 @@ package v1beta1
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	v1 "knative.dev/eventing/pkg/apis/duck/v1"
+	apis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchingSpec) DeepCopyInto(out *BatchingSpec) {
+	*out = *in
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchingSpec.
+func (in *BatchingSpec) DeepCopy() *BatchingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventMappingSpec) DeepCopyInto(out *CloudEventMappingSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventMappingSpec.
+func (in *CloudEventMappingSpec) DeepCopy() *CloudEventMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsumerConfigSpec) DeepCopyInto(out *ConsumerConfigSpec) {
+	*out = *in
+	if in.Fetch != nil {
+		in, out := &in.Fetch, &out.Fetch
+		*out = new(FetchSpec)
+		**out = **in
+	}
+	if in.SessionTimeout != nil {
+		in, out := &in.SessionTimeout, &out.SessionTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.HeartbeatInterval != nil {
+		in, out := &in.HeartbeatInterval, &out.HeartbeatInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.RebalanceTimeout != nil {
+		in, out := &in.RebalanceTimeout, &out.RebalanceTimeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxProcessingTime != nil {
+		in, out := &in.MaxProcessingTime, &out.MaxProcessingTime
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsumerConfigSpec.
+func (in *ConsumerConfigSpec) DeepCopy() *ConsumerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsumerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeserializerSpec) DeepCopyInto(out *DeserializerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeserializerSpec.
+func (in *DeserializerSpec) DeepCopy() *DeserializerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeserializerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FetchSpec) DeepCopyInto(out *FetchSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FetchSpec.
+func (in *FetchSpec) DeepCopy() *FetchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FetchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderMappingSpec) DeepCopyInto(out *HeaderMappingSpec) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderMappingSpec.
+func (in *HeaderMappingSpec) DeepCopy() *HeaderMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaSource) DeepCopyInto(out *KafkaSource) {
 	*out = *in
@@ -100,6 +267,51 @@ func (in *KafkaSourceSpec) DeepCopyInto(out *KafkaSourceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConsumerConfig != nil {
+		in, out := &in.ConsumerConfig, &out.ConsumerConfig
+		*out = new(ConsumerConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deserializer != nil {
+		in, out := &in.Deserializer, &out.Deserializer
+		*out = new(DeserializerSpec)
+		**out = **in
+	}
+	if in.CloudEventMapping != nil {
+		in, out := &in.CloudEventMapping, &out.CloudEventMapping
+		*out = new(CloudEventMappingSpec)
+		**out = **in
+	}
+	if in.HeaderMapping != nil {
+		in, out := &in.HeaderMapping, &out.HeaderMapping
+		*out = new(HeaderMappingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tombstones != nil {
+		in, out := &in.Tombstones, &out.Tombstones
+		*out = new(TombstoneSpec)
+		**out = **in
+	}
+	if in.Batching != nil {
+		in, out := &in.Batching, &out.Batching
+		*out = new(BatchingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reply != nil {
+		in, out := &in.Reply, &out.Reply
+		*out = new(ReplySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(v1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SinkAuth != nil {
+		in, out := &in.SinkAuth, &out.SinkAuth
+		*out = new(SinkAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
 	return
 }
@@ -118,7 +330,35 @@ func (in *KafkaSourceSpec) DeepCopy() *KafkaSourceSpec {
 func (in *KafkaSourceStatus) DeepCopyInto(out *KafkaSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	if in.ClaimedPartitions != nil {
+		in, out := &in.ClaimedPartitions, &out.ClaimedPartitions
+		*out = make([]TopicClaims, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Placeable.DeepCopyInto(&out.Placeable)
+	if in.PlacementHealth != nil {
+		in, out := &in.PlacementHealth, &out.PlacementHealth
+		*out = make([]PlacementHealth, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	in.DeliveryStatus.DeepCopyInto(&out.DeliveryStatus)
+	if in.ReplyURI != nil {
+		in, out := &in.ReplyURI, &out.ReplyURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -131,3 +371,164 @@ func (in *KafkaSourceStatus) DeepCopy() *KafkaSourceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionClaim) DeepCopyInto(out *PartitionClaim) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionClaim.
+func (in *PartitionClaim) DeepCopy() *PartitionClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementHealth) DeepCopyInto(out *PlacementHealth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementHealth.
+func (in *PlacementHealth) DeepCopy() *PlacementHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplySpec) DeepCopyInto(out *ReplySpec) {
+	*out = *in
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplySpec.
+func (in *ReplySpec) DeepCopy() *ReplySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenSpec) DeepCopyInto(out *ServiceAccountTokenSpec) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenSpec.
+func (in *ServiceAccountTokenSpec) DeepCopy() *ServiceAccountTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkAuthSpec) DeepCopyInto(out *SinkAuthSpec) {
+	*out = *in
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(bindingsv1beta1.SecretValueFromSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ServiceAccountTokenSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(SinkTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkAuthSpec.
+func (in *SinkAuthSpec) DeepCopy() *SinkAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SinkTLSSpec) DeepCopyInto(out *SinkTLSSpec) {
+	*out = *in
+	in.Cert.DeepCopyInto(&out.Cert)
+	in.Key.DeepCopyInto(&out.Key)
+	in.CACert.DeepCopyInto(&out.CACert)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkTLSSpec.
+func (in *SinkTLSSpec) DeepCopy() *SinkTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SinkTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TombstoneSpec) DeepCopyInto(out *TombstoneSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TombstoneSpec.
+func (in *TombstoneSpec) DeepCopy() *TombstoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TombstoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicClaims) DeepCopyInto(out *TopicClaims) {
+	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionClaim, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicClaims.
+func (in *TopicClaims) DeepCopy() *TopicClaims {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicClaims)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,11 +16,16 @@ limitations under the License.
 
 package dispatcher
 
+import (
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
 type ChannelConfig struct {
-	Namespace     string
-	Name          string
-	HostName      string
-	Subscriptions []Subscription
+	Namespace      string
+	Name           string
+	HostName       string
+	IsolationLevel sourcesv1beta1.IsolationLevel
+	Subscriptions  []Subscription
 }
 
 func (cc ChannelConfig) SubscriptionsUIDs() []string {
@@ -28,18 +28,24 @@ import (
 	"github.com/google/uuid"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing-kafka/pkg/common/config"
 
 	eventingchannels "knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/kmeta"
 
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	"knative.dev/eventing-kafka/pkg/common/client"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 	"knative.dev/eventing-kafka/pkg/common/tracing"
 )
 
@@ -49,6 +55,8 @@ type KafkaDispatcherArgs struct {
 	Brokers   []string
 	Config    *config.EventingKafkaConfig
 	TopicFunc TopicFunc
+	// KubeClient reads the credentials presented to the subscribers.
+	KubeClient kubernetes.Interface
 }
 
 type KafkaDispatcher struct {
@@ -68,9 +76,14 @@ type KafkaDispatcher struct {
 	subsConsumerGroups   map[types.UID]sarama.ConsumerGroup
 	subscriptions        map[types.UID]Subscription
 	kafkaConsumerFactory consumer.KafkaConsumerGroupFactory
-
-	topicFunc TopicFunc
-	logger    *zap.SugaredLogger
+	// kafkaConsumerFactories holds the factories of the channels overriding the isolation level,
+	// which are created on demand with newKafkaConsumerFactory
+	kafkaConsumerFactories  map[sourcesv1beta1.IsolationLevel]consumer.KafkaConsumerGroupFactory
+	newKafkaConsumerFactory func(level sourcesv1beta1.IsolationLevel) (consumer.KafkaConsumerGroupFactory, error)
+
+	topicFunc  TopicFunc
+	kubeClient kubernetes.Interface
+	logger     *zap.SugaredLogger
 }
 
 // NewDispatcher creates a new dispatcher struct. enqueue argument is a function that is used to
@@ -83,14 +96,25 @@ func NewDispatcher(ctx context.Context, args *KafkaDispatcherArgs, enqueue func(
 	}
 
 	dispatcher := &KafkaDispatcher{
-		dispatcher:           eventingchannels.NewMessageDispatcher(logging.FromContext(ctx).Desugar()),
-		kafkaConsumerFactory: consumer.NewConsumerGroupFactory(args.Brokers, args.Config.Sarama.Config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue),
+		dispatcher:             eventingchannels.NewMessageDispatcher(logging.FromContext(ctx).Desugar()),
+		kafkaConsumerFactory:   consumer.NewConsumerGroupFactory(args.Brokers, args.Config.Sarama.Config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue),
+		kafkaConsumerFactories: make(map[sourcesv1beta1.IsolationLevel]consumer.KafkaConsumerGroupFactory),
+		newKafkaConsumerFactory: func(level sourcesv1beta1.IsolationLevel) (consumer.KafkaConsumerGroupFactory, error) {
+			// Copy the config, the builder modifies the existing one
+			existing := *args.Config.Sarama.Config
+			config, err := client.NewConfigBuilder().WithExisting(&existing).WithIsolationLevel(level).Build(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return consumer.NewConsumerGroupFactory(args.Brokers, config, &consumer.KafkaConsumerGroupOffsetsChecker{}, enqueue), nil
+		},
 		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
 		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
 		subscriptions:        make(map[types.UID]Subscription),
 		kafkaSyncProducer:    producer,
 		logger:               logging.FromContext(ctx),
 		topicFunc:            args.TopicFunc,
+		kubeClient:           args.KubeClient,
 	}
 
 	podName, err := env.GetRequiredConfigValue(logging.FromContext(ctx).Desugar(), env.PodNameEnvVarKey)
@@ -173,6 +197,29 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 	d.consumerUpdateLock.Lock()
 	defer d.consumerUpdateLock.Unlock()
 
+	// The consumer groups are restarted when the isolation level of the channel changes
+	if thisChannelKafkaSubscriptions, ok := d.channelSubscriptions[channelNamespacedName]; ok &&
+		thisChannelKafkaSubscriptions.isolationLevel != config.IsolationLevel {
+		d.logger.Infow("Isolation level changed, restarting the consumer groups", zap.Any("channel", channelNamespacedName),
+			zap.Any("old", thisChannelKafkaSubscriptions.isolationLevel), zap.Any("new", config.IsolationLevel))
+		for _, subUid := range thisChannelKafkaSubscriptions.subs.UnsortedList() {
+			if err := d.unsubscribe(channelNamespacedName, d.subscriptions[types.UID(subUid)]); err != nil {
+				d.logger.Warnw("Error while unsubscribing", zap.Error(err))
+			}
+		}
+	}
+
+	// The consumer groups are restarted when the credentials presented to their subscriber change
+	for _, subSpec := range config.Subscriptions {
+		if existing, ok := d.subscriptions[subSpec.UID]; ok && !equality.Semantic.DeepEqual(existing.Auth, subSpec.Auth) {
+			d.logger.Infow("Subscriber credentials changed, restarting the consumer group", zap.Any("channel", channelNamespacedName),
+				zap.Any("subscription", subSpec.UID))
+			if err := d.unsubscribe(channelNamespacedName, existing); err != nil {
+				d.logger.Warnw("Error while unsubscribing", zap.Error(err))
+			}
+		}
+	}
+
 	// This loop takes care of filling toAddSubs and toRemoveSubs for new and existing channels
 	thisChannelKafkaSubscriptions := d.channelSubscriptions[channelNamespacedName]
 
@@ -204,7 +251,7 @@ func (d *KafkaDispatcher) ReconcileConsumers(ctx context.Context, config *Channe
 
 	failedToSubscribe := make(UpdateError)
 	for subUid, subSpec := range toAddSubs {
-		if err := d.subscribe(ctx, channelNamespacedName, subSpec); err != nil {
+		if err := d.subscribe(ctx, channelNamespacedName, subSpec, config.IsolationLevel); err != nil {
 			failedToSubscribe[subUid] = err
 		}
 	}
@@ -275,23 +322,36 @@ func (d *KafkaDispatcher) CleanupChannel(name, namespace, hostname string) error
 
 // subscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // subscribe must be called under updateLock.
-func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.NamespacedName, sub Subscription) error {
+func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.NamespacedName, sub Subscription, isolationLevel sourcesv1beta1.IsolationLevel) error {
 	d.logger.Infow("Subscribing to Kafka Channel", zap.Any("channelRef", channelRef), zap.Any("subscription", sub.UID))
 
 	topicName := d.topicFunc(utils.KafkaChannelSeparator, channelRef.Namespace, channelRef.Name)
 	groupID := fmt.Sprintf("kafka.%s.%s.%s", channelRef.Namespace, channelRef.Name, string(sub.UID))
 
+	kafkaConsumerFactory, err := d.consumerFactory(isolationLevel)
+	if err != nil {
+		d.logger.Infow("Could not create consumer group factory", zap.Any("isolationLevel", isolationLevel), zap.Error(err))
+		return err
+	}
+
 	// Get or create the channel kafka subscription
 	kafkaSubscription, ok := d.channelSubscriptions[channelRef]
 	if !ok {
 		kafkaSubscription = NewKafkaSubscription(d.logger)
+		kafkaSubscription.isolationLevel = isolationLevel
 		d.channelSubscriptions[channelRef] = kafkaSubscription
 	}
 
+	messageDispatcher, err := d.messageDispatcher(ctx, channelRef.Namespace, sub)
+	if err != nil {
+		d.logger.Infow("Could not create the subscriber dispatcher", zap.Any("subscription", sub.UID), zap.Error(err))
+		return err
+	}
+
 	handler := &consumerMessageHandler{
 		d.logger,
 		sub,
-		d.dispatcher,
+		messageDispatcher,
 		kafkaSubscription,
 		groupID,
 		d.reporter,
@@ -299,7 +359,7 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 	}
 	d.logger.Debugw("Starting consumer group", zap.Any("channelRef", channelRef),
 		zap.Any("subscription", sub.UID), zap.String("topic", topicName), zap.String("consumer group", groupID))
-	consumerGroup, err := d.kafkaConsumerFactory.StartConsumerGroup(ctx, groupID, []string{topicName}, handler, channelRef)
+	consumerGroup, err := kafkaConsumerFactory.StartConsumerGroup(ctx, groupID, []string{topicName}, handler, channelRef)
 
 	if err != nil {
 		// we can not create a consumer - logging that, with reason
@@ -323,6 +383,45 @@ func (d *KafkaDispatcher) subscribe(ctx context.Context, channelRef types.Namesp
 	return nil
 }
 
+// messageDispatcher returns the dispatcher presenting the credentials of the
+// subscription to its subscriber, or the shared one when there are none.
+func (d *KafkaDispatcher) messageDispatcher(ctx context.Context, namespace string, sub Subscription) (*eventingchannels.MessageDispatcherImpl, error) {
+	if sub.Auth == nil || sub.Subscriber == nil {
+		return d.dispatcher, nil
+	}
+
+	creds, err := sinkauth.FromSpec(ctx, d.kubeClient, namespace, sub.Auth)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := kncloudevents.NewHTTPMessageSenderWithTarget(sub.Subscriber.String())
+	if err != nil {
+		return nil, err
+	}
+	if sender.Client, err = sinkauth.NewClient(sender.Client, sender.Target, creds); err != nil {
+		return nil, err
+	}
+	return eventingchannels.NewMessageDispatcherFromSender(d.logger.Desugar(), sender), nil
+}
+
+// consumerFactory returns the consumer group factory of the given isolation level. The channels
+// without isolation level use the one of the dispatcher config.
+// consumerFactory must be called under updateLock.
+func (d *KafkaDispatcher) consumerFactory(isolationLevel sourcesv1beta1.IsolationLevel) (consumer.KafkaConsumerGroupFactory, error) {
+	if isolationLevel == "" {
+		return d.kafkaConsumerFactory, nil
+	}
+	if factory, ok := d.kafkaConsumerFactories[isolationLevel]; ok {
+		return factory, nil
+	}
+	factory, err := d.newKafkaConsumerFactory(isolationLevel)
+	if err != nil {
+		return nil, err
+	}
+	d.kafkaConsumerFactories[isolationLevel] = factory
+	return factory, nil
+}
+
 // unsubscribe reads kafkaConsumers which gets updated in UpdateConfig in a separate go-routine.
 // unsubscribe must be called under updateLock.
 func (d *KafkaDispatcher) unsubscribe(channelRef types.NamespacedName, sub Subscription) error {
@@ -19,6 +19,8 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"sync"
 	"testing"
@@ -26,20 +28,27 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/config"
 
 	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cetest "github.com/cloudevents/sdk-go/v2/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
 
 	eventingchannels "knative.dev/eventing/pkg/channel"
 	"knative.dev/eventing/pkg/channel/fanout"
 	klogtesting "knative.dev/pkg/logging/testing"
 	_ "knative.dev/pkg/system/testing"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/consolidated/utils"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
 )
@@ -61,6 +70,19 @@ func (c mockKafkaConsumerFactory) StartConsumerGroup(ctx context.Context, groupI
 
 var _ consumer.KafkaConsumerGroupFactory = (*mockKafkaConsumerFactory)(nil)
 
+// isolationLevelConsumerFactory records the consumer groups started with each isolation level
+type isolationLevelConsumerFactory struct {
+	level   sourcesv1beta1.IsolationLevel
+	started map[sourcesv1beta1.IsolationLevel][]string
+}
+
+func (c isolationLevelConsumerFactory) StartConsumerGroup(ctx context.Context, groupID string, topics []string, handler consumer.KafkaConsumerHandler, ref types.NamespacedName, options ...consumer.SaramaConsumerHandlerOption) (sarama.ConsumerGroup, error) {
+	c.started[c.level] = append(c.started[c.level], groupID)
+	return mockConsumerGroup{}, nil
+}
+
+var _ consumer.KafkaConsumerGroupFactory = (*isolationLevelConsumerFactory)(nil)
+
 type mockConsumerGroup struct{}
 
 func (m mockConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
@@ -458,6 +480,146 @@ func TestKafkaDispatcher_CleanupChannel(t *testing.T) {
 	require.NotContains(t, d.subsConsumerGroups, "subscription-2")
 }
 
+func TestKafkaDispatcher_IsolationLevel(t *testing.T) {
+	subscriber, _ := url.Parse("http://test/subscriber")
+
+	started := make(map[sourcesv1beta1.IsolationLevel][]string)
+	var created []sourcesv1beta1.IsolationLevel
+	d := &KafkaDispatcher{
+		kafkaConsumerFactory:   isolationLevelConsumerFactory{started: started},
+		kafkaConsumerFactories: make(map[sourcesv1beta1.IsolationLevel]consumer.KafkaConsumerGroupFactory),
+		newKafkaConsumerFactory: func(level sourcesv1beta1.IsolationLevel) (consumer.KafkaConsumerGroupFactory, error) {
+			created = append(created, level)
+			return isolationLevelConsumerFactory{level: level, started: started}, nil
+		},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		logger:               zaptest.NewLogger(t).Sugar(),
+	}
+
+	ctx := context.TODO()
+
+	channelConfig := func(name string, level sourcesv1beta1.IsolationLevel) *ChannelConfig {
+		return &ChannelConfig{
+			Namespace:      "default",
+			Name:           name,
+			IsolationLevel: level,
+			Subscriptions: []Subscription{{
+				UID: types.UID(name + "-subscription"),
+				Subscription: fanout.Subscription{
+					Subscriber: subscriber,
+				},
+			}},
+		}
+	}
+
+	// The read committed factory is created once and shared by the channels
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig("channel-1", sourcesv1beta1.IsolationLevelReadCommitted)))
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig("channel-2", sourcesv1beta1.IsolationLevelReadCommitted)))
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig("channel-3", "")))
+	assert.Equal(t, []sourcesv1beta1.IsolationLevel{sourcesv1beta1.IsolationLevelReadCommitted}, created)
+	assert.Equal(t, map[sourcesv1beta1.IsolationLevel][]string{
+		sourcesv1beta1.IsolationLevelReadCommitted: {"kafka.default.channel-1.channel-1-subscription", "kafka.default.channel-2.channel-2-subscription"},
+		"": {"kafka.default.channel-3.channel-3-subscription"},
+	}, started)
+
+	// Reconciling the same isolation level doesn't restart the consumer groups
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig("channel-1", sourcesv1beta1.IsolationLevelReadCommitted)))
+	assert.Len(t, started[sourcesv1beta1.IsolationLevelReadCommitted], 2)
+
+	// Changing the isolation level restarts the consumer groups of the channel
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig("channel-1", "")))
+	assert.Equal(t, []string{"kafka.default.channel-3.channel-3-subscription", "kafka.default.channel-1.channel-1-subscription"}, started[""])
+	assert.Equal(t, sourcesv1beta1.IsolationLevel(""), d.channelSubscriptions[types.NamespacedName{Namespace: "default", Name: "channel-1"}].isolationLevel)
+	assert.Contains(t, d.subsConsumerGroups, types.UID("channel-1-subscription"))
+}
+
+func TestKafkaDispatcher_SubscriberAuth(t *testing.T) {
+	authorization := make(chan string, 2)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+	}))
+	defer sink.Close()
+	subscriber, _ := url.Parse(sink.URL)
+
+	started := make(map[sourcesv1beta1.IsolationLevel][]string)
+	d := &KafkaDispatcher{
+		dispatcher:           eventingchannels.NewMessageDispatcher(zaptest.NewLogger(t)),
+		kafkaConsumerFactory: isolationLevelConsumerFactory{started: started},
+		channelSubscriptions: make(map[types.NamespacedName]*KafkaSubscription),
+		subsConsumerGroups:   make(map[types.UID]sarama.ConsumerGroup),
+		subscriptions:        make(map[types.UID]Subscription),
+		topicFunc:            utils.TopicName,
+		kubeClient: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "subscriber-credentials"},
+			Data:       map[string][]byte{"token": []byte("token-1"), "rotated": []byte("token-2")},
+		}),
+		logger: zaptest.NewLogger(t).Sugar(),
+	}
+
+	ctx := context.TODO()
+
+	subscription := func(key string) Subscription {
+		sub := Subscription{
+			UID:          "subscription",
+			Subscription: fanout.Subscription{Subscriber: subscriber},
+		}
+		if key != "" {
+			sub.Auth = &sourcesv1beta1.SinkAuthSpec{
+				BearerToken: &bindingsv1beta1.SecretValueFromSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "subscriber-credentials"},
+						Key:                  key,
+					},
+				},
+			}
+		}
+		return sub
+	}
+	channelConfig := func(sub Subscription) *ChannelConfig {
+		return &ChannelConfig{Namespace: "default", Name: "channel", Subscriptions: []Subscription{sub}}
+	}
+	dispatch := func(sub Subscription) string {
+		messageDispatcher, err := d.messageDispatcher(ctx, "default", sub)
+		require.NoError(t, err)
+		event := cetest.FullEvent()
+		_, err = messageDispatcher.DispatchMessage(ctx, binding.ToMessage(&event), nil, subscriber, nil, nil)
+		require.NoError(t, err)
+		return <-authorization
+	}
+
+	// The subscribers without credentials share the dispatcher
+	messageDispatcher, err := d.messageDispatcher(ctx, "default", subscription(""))
+	require.NoError(t, err)
+	assert.Same(t, d.dispatcher, messageDispatcher)
+	assert.Empty(t, dispatch(subscription("")))
+	assert.Equal(t, "Bearer token-1", dispatch(subscription("token")))
+
+	// Changing the credentials restarts the consumer group
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig(subscription("token"))))
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig(subscription("token"))))
+	assert.Len(t, started[""], 1)
+	require.NoError(t, d.ReconcileConsumers(ctx, channelConfig(subscription("rotated"))))
+	assert.Len(t, started[""], 2)
+	assert.Equal(t, "rotated", d.subscriptions["subscription"].Auth.BearerToken.SecretKeyRef.Key)
+	assert.Len(t, d.channelSubscriptions[types.NamespacedName{Namespace: "default", Name: "channel"}].subs, 1)
+
+	// Unknown secrets fail to subscribe
+	sub := subscription("token")
+	sub.Auth.BearerToken = nil
+	sub.Auth.TLS = &sourcesv1beta1.SinkTLSSpec{
+		CACert: bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "ca.crt",
+			},
+		},
+	}
+	assert.Error(t, d.ReconcileConsumers(ctx, channelConfig(sub)))
+}
+
 func TestSubscribeError(t *testing.T) {
 	cf := &mockKafkaConsumerFactory{createErr: true}
 	d := &KafkaDispatcher{
@@ -479,7 +641,7 @@ func TestSubscribeError(t *testing.T) {
 		UID:          "test-sub",
 		Subscription: fanout.Subscription{},
 	}
-	err := d.subscribe(ctx, channelRef, subRef)
+	err := d.subscribe(ctx, channelRef, subRef, "")
 	if err == nil {
 		t.Errorf("Expected error want %s, got %s", "error creating consumer", err)
 	}
@@ -23,11 +23,15 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
 type KafkaSubscription struct {
 	logger *zap.SugaredLogger
 	subs   sets.String
+	// isolationLevel is the isolation level of the consumer groups of the subscriptions
+	isolationLevel sourcesv1beta1.IsolationLevel
 	// readySubscriptionsLock must be used to synchronize access to channelReadySubscriptions
 	readySubscriptionsLock    sync.RWMutex
 	channelReadySubscriptions map[string]sets.Int32
@@ -21,11 +21,16 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing/pkg/channel/fanout"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 )
 
 type Subscription struct {
 	UID types.UID
 	fanout.Subscription
+
+	// Auth holds the credentials presented to the subscriber (if any).
+	Auth *sourcesv1beta1.SinkAuthSpec
 }
 
 func (sub Subscription) String() string {
@@ -26,6 +26,7 @@ import (
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/kncloudevents"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	configmapinformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
@@ -102,9 +103,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 
 	kafkaChannelInformer := kafkachannel.Get(ctx)
 	args := &dispatcher.KafkaDispatcherArgs{
-		Brokers:   kafkaConfig.Brokers,
-		Config:    kafkaConfig.EventingKafka,
-		TopicFunc: utils.TopicName,
+		Brokers:    kafkaConfig.Brokers,
+		Config:     kafkaConfig.EventingKafka,
+		TopicFunc:  utils.TopicName,
+		KubeClient: kubeclient.Get(ctx),
 	}
 
 	r := &Reconciler{
@@ -200,9 +202,10 @@ func (r *Reconciler) CleanupChannel(kc *v1beta1.KafkaChannel) pkgreconciler.Even
 // newConfigFromKafkaChannel creates a new Config from the list of kafka channels.
 func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatcher.ChannelConfig {
 	channelConfig := dispatcher.ChannelConfig{
-		Namespace: c.Namespace,
-		Name:      c.Name,
-		HostName:  c.Status.Address.URL.Host,
+		Namespace:      c.Namespace,
+		Name:           c.Name,
+		HostName:       c.Status.Address.URL.Host,
+		IsolationLevel: c.IsolationLevel(),
 	}
 	if c.Spec.SubscribableSpec.Subscribers != nil {
 		newSubs := make([]dispatcher.Subscription, 0, len(c.Spec.SubscribableSpec.Subscribers))
@@ -212,6 +215,7 @@ func (r *Reconciler) newConfigFromKafkaChannel(c *v1beta1.KafkaChannel) *dispatc
 			newSubs = append(newSubs, dispatcher.Subscription{
 				Subscription: *innerSub,
 				UID:          source.UID,
+				Auth:         c.Spec.SubscriberAuthFor(source.SubscriberURI),
 			})
 		}
 		channelConfig.Subscriptions = newSubs
@@ -33,7 +33,8 @@ const (
 	KafkaSecretNameEnvVarKey      = "KAFKA_SECRET_NAME"
 
 	// Kafka Configuration
-	KafkaTopicEnvVarKey = "KAFKA_TOPIC"
+	KafkaTopicEnvVarKey          = "KAFKA_TOPIC"
+	KafkaIsolationLevelEnvVarKey = "KAFKA_ISOLATION_LEVEL"
 
 	// Dispatcher Configuration
 	ChannelKeyEnvVarKey  = "CHANNEL_KEY"
@@ -630,6 +630,14 @@ func (r *Reconciler) dispatcherDeploymentEnvVars(channel *kafkav1beta1.KafkaChan
 		},
 	}
 
+	// If The KafkaChannel Overrides The Isolation Level Then Append It As Env Var
+	if isolationLevel := channel.IsolationLevel(); isolationLevel != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  commonenv.KafkaIsolationLevelEnvVarKey,
+			Value: string(isolationLevel),
+		})
+	}
+
 	// If The Kafka Secret Name Is Specified Then Append Relevant Env Vars
 	if len(r.config.Kafka.AuthSecretName) <= 0 {
 
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkachannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	kafkav1beta1 "knative.dev/eventing-kafka/pkg/apis/messaging/v1beta1"
+	commonenv "knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
+	controllertesting "knative.dev/eventing-kafka/pkg/channel/distributed/controller/testing"
+)
+
+// Test The Dispatcher Env Vars Of KafkaChannels With And Without Isolation Level Annotation
+func TestDispatcherDeploymentEnvVarsIsolationLevel(t *testing.T) {
+
+	r := &Reconciler{
+		environment: controllertesting.NewEnvironment(),
+		config:      controllertesting.NewConfig(),
+	}
+
+	// Without Annotation The Dispatcher Uses The ConfigMap Isolation Level
+	envVars, err := r.dispatcherDeploymentEnvVars(controllertesting.NewKafkaChannel())
+	assert.Nil(t, err)
+	assert.Nil(t, findEnvVar(envVars, commonenv.KafkaIsolationLevelEnvVarKey))
+
+	// The Annotation Overrides The ConfigMap Isolation Level
+	channel := controllertesting.NewKafkaChannel(func(kafkachannel *kafkav1beta1.KafkaChannel) {
+		kafkachannel.Annotations = map[string]string{kafkav1beta1.IsolationLevelAnnotationKey: "read_committed"}
+	})
+	envVars, err = r.dispatcherDeploymentEnvVars(channel)
+	assert.Nil(t, err)
+	envVar := findEnvVar(envVars, commonenv.KafkaIsolationLevelEnvVarKey)
+	if assert.NotNil(t, envVar) {
+		assert.Equal(t, "read_committed", envVar.Value)
+	}
+}
+
+// Utility Function For Finding An EnvVar By Name
+func findEnvVar(envVars []corev1.EnvVar, name string) *corev1.EnvVar {
+	for i := range envVars {
+		if envVars[i].Name == name {
+			return &envVars[i]
+		}
+	}
+	return nil
+}
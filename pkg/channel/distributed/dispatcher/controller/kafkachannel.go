@@ -236,7 +236,7 @@ func (r Reconciler) reconcile(ctx context.Context, channel *kafkav1beta1.KafkaCh
 		Namespace: channel.GetNamespace(),
 		Name:      channel.GetName(),
 	}
-	subscriptions := r.dispatcher.UpdateSubscriptions(ctx, channelRef, subscribers)
+	subscriptions := r.dispatcher.UpdateSubscriptions(ctx, channelRef, subscribers, channel.Spec.SubscriberAuthFor)
 
 	// Update The KafkaChannel Subscribable Status Based On ConsumerGroup Creation Status
 	channel.Status.SubscribableStatus = r.createSubscribableStatus(channel.Spec.Subscribers, subscriptions)
@@ -365,7 +365,7 @@ func (m *MockDispatcher) Shutdown() {
 	m.Called()
 }
 
-func (m *MockDispatcher) UpdateSubscriptions(ctx context.Context, ref types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) consumer.SubscriberStatusMap {
+func (m *MockDispatcher) UpdateSubscriptions(ctx context.Context, ref types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec, subscriberAuth dispatcher.SubscriberAuthFunc) consumer.SubscriberStatusMap {
 	args := m.Called(ctx, ref, subscriberSpecs)
 	return args.Get(0).(consumer.SubscriberStatusMap)
 }
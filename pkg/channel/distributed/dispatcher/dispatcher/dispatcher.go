@@ -18,6 +18,7 @@ package dispatcher
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -25,10 +26,15 @@ import (
 	gometrics "github.com/rcrowley/go-metrics"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/apis"
 
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	commonkafkautil "knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	dispatcherconstants "knative.dev/eventing-kafka/pkg/channel/distributed/dispatcher/constants"
 	"knative.dev/eventing-kafka/pkg/common/client"
@@ -36,6 +42,7 @@ import (
 	commonconsumer "knative.dev/eventing-kafka/pkg/common/consumer"
 	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
 	"knative.dev/eventing-kafka/pkg/common/metrics"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 )
 
 // DispatcherConfig Defines A Dispatcher Config Struct To Hold Configuration
@@ -48,24 +55,29 @@ type DispatcherConfig struct {
 	StatsReporter   metrics.StatsReporter
 	MetricsRegistry gometrics.Registry
 	SaramaConfig    *sarama.Config
+	KubeClient      kubernetes.Interface // Requests The Service Account Tokens Presented To The Subscribers
 }
 
 // SubscriberWrapper Defines A Knative Eventing SubscriberSpec Wrapper Enhanced With Sarama ConsumerGroup ID
 type SubscriberWrapper struct {
 	eventingduck.SubscriberSpec
 	GroupId string
+	Auth    *sourcesv1beta1.SinkAuthSpec
 }
 
 // NewSubscriberWrapper Is The SubscriberWrapper Constructor
-func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string) *SubscriberWrapper {
-	return &SubscriberWrapper{subscriberSpec, groupId}
+func NewSubscriberWrapper(subscriberSpec eventingduck.SubscriberSpec, groupId string, auth *sourcesv1beta1.SinkAuthSpec) *SubscriberWrapper {
+	return &SubscriberWrapper{subscriberSpec, groupId, auth}
 }
 
+// SubscriberAuthFunc Returns The Credentials Presented To The Specified Subscriber URI (If Any)
+type SubscriberAuthFunc func(subscriberURI *apis.URL) *sourcesv1beta1.SinkAuthSpec
+
 // Dispatcher Interface
 type Dispatcher interface {
 	SecretChanged(ctx context.Context, secret *corev1.Secret)
 	Shutdown()
-	UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap
+	UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec, subscriberAuth SubscriberAuthFunc) commonconsumer.SubscriberStatusMap
 }
 
 // DispatcherImpl Is A Struct With Configuration & ConsumerGroup State
@@ -123,7 +135,7 @@ func (d *DispatcherImpl) Shutdown() {
 }
 
 // UpdateSubscriptions manages the Dispatcher's Subscriptions to align with new state
-func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec) commonconsumer.SubscriberStatusMap {
+func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef types.NamespacedName, subscriberSpecs []eventingduck.SubscriberSpec, subscriberAuth SubscriberAuthFunc) commonconsumer.SubscriberStatusMap {
 
 	if d.SaramaConfig == nil {
 		d.Logger.Error("Dispatcher has no config!")
@@ -143,6 +155,18 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 		// Format The GroupId For The Specified Subscriber
 		groupId := commonkafkautil.GroupId(string(subscriberSpec.UID))
 
+		// Lookup The Credentials Presented To The Subscriber (If Any)
+		var auth *sourcesv1beta1.SinkAuthSpec
+		if subscriberAuth != nil {
+			auth = subscriberAuth(subscriberSpec.SubscriberURI)
+		}
+
+		// Close The ConsumerGroup If The Credentials Presented To The Subscriber Changed (Recreated Below)
+		if subscriber, ok := d.subscribers[subscriberSpec.UID]; ok && !equality.Semantic.DeepEqual(subscriber.Auth, auth) {
+			d.Logger.Info("Subscriber Credentials Changed - Restarting ConsumerGroup", zap.String("GroupId", groupId))
+			d.closeConsumerGroup(subscriber)
+		}
+
 		// If The Subscriber Wrapper For The SubscriberSpec Does Not Exist Then Create One
 		if _, ok := d.subscribers[subscriberSpec.UID]; !ok {
 
@@ -151,7 +175,10 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 
 			// Create/Start A New ConsumerGroup With Custom Handler
 			handler := NewHandler(logger, groupId, &subscriberSpec)
-			err := d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{d.Topic}, handler, channelRef)
+			err := d.authenticate(ctx, channelRef.Namespace, handler, auth)
+			if err == nil {
+				err = d.consumerMgr.StartConsumerGroup(ctx, groupId, []string{d.Topic}, handler, channelRef)
+			}
 			if err != nil {
 
 				// Log & Return Failure
@@ -161,7 +188,7 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 			} else {
 
 				// Create A New SubscriberWrapper With The ConsumerGroup
-				subscriber := NewSubscriberWrapper(subscriberSpec, groupId)
+				subscriber := NewSubscriberWrapper(subscriberSpec, groupId, auth)
 
 				// Asynchronously Process ConsumerGroup's Error Channel
 				go func() {
@@ -203,6 +230,35 @@ func (d *DispatcherImpl) UpdateSubscriptions(ctx context.Context, channelRef typ
 	return subscriptions
 }
 
+// authenticate replaces the MessageDispatcher of the handler with one presenting the specified credentials
+// to the subscriber (the reply and dead letter sinks are sent to without credentials)
+func (d *DispatcherImpl) authenticate(ctx context.Context, namespace string, handler *Handler, auth *sourcesv1beta1.SinkAuthSpec) error {
+
+	// Nothing To Do Without Credentials Or Subscriber
+	if auth == nil || handler.destinationURL == nil {
+		return nil
+	}
+
+	// The Dispatchers May Not Read The Secrets Of The KafkaChannel Namespaces - Only Service Account Tokens Are Supported
+	if auth.BearerToken != nil || auth.TLS != nil {
+		return errors.New("subscriber credentials read from secrets are not supported by the distributed KafkaChannel")
+	}
+
+	creds, err := sinkauth.FromSpec(ctx, d.KubeClient, namespace, auth)
+	if err != nil {
+		return err
+	}
+	sender, err := kncloudevents.NewHTTPMessageSenderWithTarget(handler.destinationURL.String())
+	if err != nil {
+		return err
+	}
+	if sender.Client, err = sinkauth.NewClient(sender.Client, sender.Target, creds); err != nil {
+		return err
+	}
+	handler.MessageDispatcher = channel.NewMessageDispatcherFromSender(handler.Logger, sender)
+	return nil
+}
+
 // closeConsumerGroup closes the ConsumerGroup associated with a single Subscriber
 func (d *DispatcherImpl) closeConsumerGroup(subscriber *SubscriberWrapper) {
 
@@ -19,19 +19,32 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cetest "github.com/cloudevents/sdk-go/v2/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/kafka/util"
 	commonclient "knative.dev/eventing-kafka/pkg/common/client"
 	clienttesting "knative.dev/eventing-kafka/pkg/common/client/testing"
@@ -61,13 +74,140 @@ func TestNewSubscriberWrapper(t *testing.T) {
 	subscriber := eventingduck.SubscriberSpec{UID: uid123}
 	groupId := "TestGroupId"
 
+	auth := &sourcesv1beta1.SinkAuthSpec{ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "gateway"}}
+
 	// Perform The Test
-	subscriberWrapper := NewSubscriberWrapper(subscriber, groupId)
+	subscriberWrapper := NewSubscriberWrapper(subscriber, groupId, auth)
 
 	// Verify Results
 	assert.NotNil(t, subscriberWrapper)
 	assert.Equal(t, subscriber.UID, subscriberWrapper.UID)
 	assert.Equal(t, groupId, subscriberWrapper.GroupId)
+	assert.Equal(t, auth, subscriberWrapper.Auth)
+}
+
+// Test The UpdateSubscriptions() Functionality With Subscriber Credentials
+func TestUpdateSubscriptionsAuth(t *testing.T) {
+
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Test Data
+	config, err := commonclient.NewConfigBuilder().WithDefaults().FromYaml(clienttesting.DefaultSaramaConfigYaml).Build(ctx)
+	assert.Nil(t, err)
+
+	authorization := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+	}))
+	defer sink.Close()
+	subscriberURI, err := apis.ParseURL(sink.URL)
+	assert.Nil(t, err)
+	subscriberSpecs := []eventingduck.SubscriberSpec{{UID: uid123, SubscriberURI: subscriberURI}}
+
+	// The Service Account Allows Tokens For The "gateway" & "rotated" Audiences, Issued As "token-<audience>"
+	kubeClient := fake.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test-namespace",
+			Name:        "default",
+			Annotations: map[string]string{sourcesv1beta1.ServiceAccountTokenAudiencesAnnotation: "gateway,rotated"},
+		},
+	})
+	kubeClient.PrependReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		tr := action.(clientgotesting.CreateAction).GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		tr.Status = authenticationv1.TokenRequestStatus{
+			Token:               "token-" + tr.Spec.Audiences[0],
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		}
+		return true, tr, nil
+	})
+	subscriberAuth := func(auth *sourcesv1beta1.SinkAuthSpec) SubscriberAuthFunc {
+		return func(uri *apis.URL) *sourcesv1beta1.SinkAuthSpec {
+			if uri.String() != subscriberURI.String() {
+				return nil
+			}
+			return auth
+		}
+	}
+	serviceAccountToken := func(audience string) *sourcesv1beta1.SinkAuthSpec {
+		return &sourcesv1beta1.SinkAuthSpec{ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: audience}}
+	}
+
+	// Record The Handlers Of The Started ConsumerGroups
+	var handlers []*Handler
+	errorSource := make(chan error)
+	defer close(errorSource)
+	mockManager := consumertesting.NewMockConsumerGroupManager()
+	mockManager.On("StartConsumerGroup", mock.Anything, "kafka."+id123, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { handlers = append(handlers, args.Get(3).(*Handler)) }).
+		Return(nil)
+	mockManager.On("Errors", "kafka."+id123).Return((<-chan error)(errorSource))
+	mockManager.On("IsManaged", "kafka."+id123).Return(true)
+	mockManager.On("CloseConsumerGroup", "kafka."+id123).Return(nil)
+	mockManager.On("IsStopped", "kafka."+id123).Return(false)
+
+	dispatcher := &DispatcherImpl{
+		DispatcherConfig: DispatcherConfig{
+			Logger:       logger.Desugar(),
+			Brokers:      []string{configtesting.DefaultKafkaBroker},
+			SaramaConfig: config,
+			KubeClient:   kubeClient,
+		},
+		subscribers: make(map[types.UID]*SubscriberWrapper),
+		consumerMgr: mockManager,
+	}
+	channelRef := types.NamespacedName{Namespace: "test-namespace", Name: "test-channel"}
+
+	// Verify The Credentials Presented To The Subscriber
+	dispatch := func(handler *Handler) string {
+		event := cetest.FullEvent()
+		_, err := handler.MessageDispatcher.DispatchMessage(ctx, binding.ToMessage(&event), nil, subscriberURI.URL(), nil, nil)
+		assert.Nil(t, err)
+		return <-authorization
+	}
+
+	// Start The ConsumerGroup With The Subscriber Credentials
+	result := dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, subscriberAuth(serviceAccountToken("gateway")))
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Len(t, handlers, 1)
+	assert.Equal(t, "Bearer token-gateway", dispatch(handlers[0]))
+
+	// Unchanged Credentials Don't Restart The ConsumerGroup
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, subscriberAuth(serviceAccountToken("gateway")))
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Len(t, handlers, 1)
+
+	// Changed Credentials Restart The ConsumerGroup
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, subscriberAuth(serviceAccountToken("rotated")))
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Len(t, handlers, 2)
+	assert.Equal(t, "Bearer token-rotated", dispatch(handlers[1]))
+	assert.Equal(t, serviceAccountToken("rotated"), dispatcher.subscribers[uid123].Auth)
+
+	// Audiences Not Allowed By The Service Account Fail The Subscription
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, subscriberAuth(serviceAccountToken("attacker")))
+	assert.Equal(t, 1, result.FailedCount())
+	assert.Len(t, handlers, 2)
+	assert.Empty(t, dispatcher.subscribers)
+
+	// Credentials Read From Secrets Fail The Subscription
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, subscriberAuth(&sourcesv1beta1.SinkAuthSpec{
+		BearerToken: &bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "subscriber-credentials"},
+				Key:                  "token",
+			},
+		},
+	}))
+	assert.Equal(t, 1, result.FailedCount())
+	assert.Len(t, handlers, 2)
+	assert.Empty(t, dispatcher.subscribers)
+
+	// No Credentials
+	result = dispatcher.UpdateSubscriptions(ctx, channelRef, subscriberSpecs, nil)
+	assert.Equal(t, 0, result.FailedCount())
+	assert.Len(t, handlers, 3)
+	assert.Empty(t, dispatch(handlers[2]))
 }
 
 // Test The NewDispatcher() Functionality
@@ -111,9 +251,9 @@ func TestShutdown(t *testing.T) {
 		},
 		consumerMgr: mockManager,
 		subscribers: map[types.UID]*SubscriberWrapper{
-			subscriber1.UID: NewSubscriberWrapper(subscriber1, groupId1),
-			subscriber2.UID: NewSubscriberWrapper(subscriber2, groupId2),
-			subscriber3.UID: NewSubscriberWrapper(subscriber3, groupId3),
+			subscriber1.UID: NewSubscriberWrapper(subscriber1, groupId1, nil),
+			subscriber2.UID: NewSubscriberWrapper(subscriber2, groupId2, nil),
+			subscriber3.UID: NewSubscriberWrapper(subscriber3, groupId3, nil),
 		},
 	}
 
@@ -380,7 +520,7 @@ func TestUpdateSubscriptions(t *testing.T) {
 			}
 
 			// Perform The Test
-			result := dispatcher.UpdateSubscriptions(ctx, types.NamespacedName{}, testCase.args.subscriberSpecs)
+			result := dispatcher.UpdateSubscriptions(ctx, types.NamespacedName{}, testCase.args.subscriberSpecs, nil)
 
 			close(errorSource)
 
@@ -546,7 +686,7 @@ func TestSecretChanged(t *testing.T) {
 
 // Utility Function For Creating A SubscriberWrapper With Specified UID & Mock ConsumerGroup
 func createSubscriberWrapper(uid types.UID) *SubscriberWrapper {
-	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, fmt.Sprintf("kafka.%s", string(uid)))
+	return NewSubscriberWrapper(eventingduck.SubscriberSpec{UID: uid}, fmt.Sprintf("kafka.%s", string(uid)), nil)
 }
 
 // Utility Function For Creating A Dispatcher With Specified Configuration
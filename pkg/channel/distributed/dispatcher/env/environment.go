@@ -23,6 +23,7 @@ import (
 	"knative.dev/pkg/system"
 
 	"go.uber.org/zap"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/channel/distributed/common/env"
 	"knative.dev/pkg/controller"
 )
@@ -45,10 +46,11 @@ type Environment struct {
 	HealthPort int // Required
 
 	// Kafka Configuration
-	KafkaTopic   string        // Required
-	ChannelKey   string        // Required
-	ServiceName  string        // Required
-	ResyncPeriod time.Duration // Optional
+	KafkaTopic     string                        // Required
+	ChannelKey     string                        // Required
+	ServiceName    string                        // Required
+	ResyncPeriod   time.Duration                 // Optional
+	IsolationLevel sourcesv1beta1.IsolationLevel // Optional
 
 	// Kafka Authorization
 	KafkaSecretName      string // Required
@@ -141,6 +143,9 @@ func GetEnvironment(logger *zap.Logger) (*Environment, error) {
 	}
 	environment.ResyncPeriod = time.Duration(resyncMinutes) * time.Minute
 
+	// Get The Optional Isolation Level Config Value (Defaults To The One Of The ConfigMap)
+	environment.IsolationLevel = sourcesv1beta1.IsolationLevel(env.GetOptionalConfigValue(logger, env.KafkaIsolationLevelEnvVarKey, ""))
+
 	// Log The Dispatcher Configuration Loaded From Environment Variables
 	logger.Info("Environment Variables", zap.Any("Environment", environment))
 
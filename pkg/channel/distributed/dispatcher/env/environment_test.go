@@ -62,6 +62,7 @@ type TestCase struct {
 	kafkaSecretNamespace string
 	podName              string
 	containerName        string
+	isolationLevel       string
 	expectedError        error
 	expectedResyncPeriod string
 }
@@ -152,6 +153,10 @@ func TestGetEnvironment(t *testing.T) {
 	testCase.expectedResyncPeriod = "600" // 10 hours - default value
 	testCases = append(testCases, testCase)
 
+	testCase = getValidTestCase("Valid Config - IsolationLevel")
+	testCase.isolationLevel = "read_committed"
+	testCases = append(testCases, testCase)
+
 	// Loop Over All The TestCases
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -170,6 +175,7 @@ func TestGetEnvironment(t *testing.T) {
 			assertSetenv(t, commonenv.KafkaSecretNamespaceEnvVarKey, testCase.kafkaSecretNamespace)
 			assertSetenv(t, commonenv.PodNameEnvVarKey, testCase.podName)
 			assertSetenv(t, commonenv.ContainerNameEnvVarKey, testCase.containerName)
+			assertSetenvNonempty(t, commonenv.KafkaIsolationLevelEnvVarKey, testCase.isolationLevel)
 
 			// Perform The Test
 			environment, err := GetEnvironment(logger)
@@ -190,6 +196,7 @@ func TestGetEnvironment(t *testing.T) {
 				assert.Equal(t, testCase.podName, environment.PodName)
 				assert.Equal(t, testCase.containerName, environment.ContainerName)
 				assert.Equal(t, testCase.expectedResyncPeriod, strconv.Itoa(int(environment.ResyncPeriod/time.Minute)))
+				assert.Equal(t, testCase.isolationLevel, string(environment.IsolationLevel))
 
 			} else {
 				assert.Equal(t, testCase.expectedError, err)
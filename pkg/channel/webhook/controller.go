@@ -39,12 +39,17 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
 
-// IncludeResetOffset adds the ResetOffset GVK entry to the Types map so that the WebHook will
-// support both CRDs for Defaulting and Validation Admission (but not Conversion).  This needs
-// to be called prior to calling the "NewXXXAdmissionController()" functions to have any effect.
+// IncludeResetOffset adds the ResetOffset (and companion ConsumerGroupSnapshot / ConsumerGroupPause) GVK entries to the
+// Types map so that the WebHook will support those CRDs for Defaulting and Validation Admission (but
+// not Conversion).  This needs to be called prior to calling the "NewXXXAdmissionController()"
+// functions to have any effect.
 func IncludeResetOffset() {
 	gvkKey := kafkav1alpha1.SchemeGroupVersion.WithKind("ResetOffset")
 	types[gvkKey] = &kafkav1alpha1.ResetOffset{}
+	snapshotGvkKey := kafkav1alpha1.SchemeGroupVersion.WithKind("ConsumerGroupSnapshot")
+	types[snapshotGvkKey] = &kafkav1alpha1.ConsumerGroupSnapshot{}
+	pauseGvkKey := kafkav1alpha1.SchemeGroupVersion.WithKind("ConsumerGroupPause")
+	types[pauseGvkKey] = &kafkav1alpha1.ConsumerGroupPause{}
 }
 
 func NewDefaultingAdmissionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
@@ -37,7 +37,7 @@ func TestDefaultCallbacksMap(t *testing.T) {
 
 func TestIncludeResetOffset(t *testing.T) {
 	IncludeResetOffset()
-	assert.Len(t, types, 2)
+	assert.Len(t, types, 4)
 
 	kcTypeEntry := types[messagingv1beta1.SchemeGroupVersion.WithKind("KafkaChannel")]
 	assert.NotNil(t, kcTypeEntry)
@@ -46,4 +46,12 @@ func TestIncludeResetOffset(t *testing.T) {
 	roTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("ResetOffset")]
 	assert.NotNil(t, roTypeEntry)
 	assert.IsType(t, &kafkav1alpha1.ResetOffset{}, roTypeEntry)
+
+	cgsTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("ConsumerGroupSnapshot")]
+	assert.NotNil(t, cgsTypeEntry)
+	assert.IsType(t, &kafkav1alpha1.ConsumerGroupSnapshot{}, cgsTypeEntry)
+
+	cgpTypeEntry := types[kafkav1alpha1.SchemeGroupVersion.WithKind("ConsumerGroupPause")]
+	assert.NotNil(t, cgpTypeEntry)
+	assert.IsType(t, &kafkav1alpha1.ConsumerGroupPause{}, cgpTypeEntry)
 }
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// FakeConsumerGroupPauses implements ConsumerGroupPauseInterface
+type FakeConsumerGroupPauses struct {
+	Fake *FakeKafkaV1alpha1
+	ns   string
+}
+
+var consumergrouppausesResource = schema.GroupVersionResource{Group: "kafka.eventing.knative.dev", Version: "v1alpha1", Resource: "consumergrouppauses"}
+
+var consumergrouppausesKind = schema.GroupVersionKind{Group: "kafka.eventing.knative.dev", Version: "v1alpha1", Kind: "ConsumerGroupPause"}
+
+// Get takes name of the consumerGroupPause, and returns the corresponding consumerGroupPause object, and an error if there is any.
+func (c *FakeConsumerGroupPauses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ConsumerGroupPause, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(consumergrouppausesResource, c.ns, name), &v1alpha1.ConsumerGroupPause{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), err
+}
+
+// List takes label and field selectors, and returns the list of ConsumerGroupPauses that match those selectors.
+func (c *FakeConsumerGroupPauses) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ConsumerGroupPauseList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(consumergrouppausesResource, consumergrouppausesKind, c.ns, opts), &v1alpha1.ConsumerGroupPauseList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ConsumerGroupPauseList{ListMeta: obj.(*v1alpha1.ConsumerGroupPauseList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ConsumerGroupPauseList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested consumerGroupPauses.
+func (c *FakeConsumerGroupPauses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(consumergrouppausesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a consumerGroupPause and creates it.  Returns the server's representation of the consumerGroupPause, and an error, if there is any.
+func (c *FakeConsumerGroupPauses) Create(ctx context.Context, consumerGroupPause *v1alpha1.ConsumerGroupPause, opts v1.CreateOptions) (result *v1alpha1.ConsumerGroupPause, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(consumergrouppausesResource, c.ns, consumerGroupPause), &v1alpha1.ConsumerGroupPause{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), err
+}
+
+// Update takes the representation of a consumerGroupPause and updates it. Returns the server's representation of the consumerGroupPause, and an error, if there is any.
+func (c *FakeConsumerGroupPauses) Update(ctx context.Context, consumerGroupPause *v1alpha1.ConsumerGroupPause, opts v1.UpdateOptions) (result *v1alpha1.ConsumerGroupPause, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(consumergrouppausesResource, c.ns, consumerGroupPause), &v1alpha1.ConsumerGroupPause{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeConsumerGroupPauses) UpdateStatus(ctx context.Context, consumerGroupPause *v1alpha1.ConsumerGroupPause, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupPause, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(consumergrouppausesResource, "status", c.ns, consumerGroupPause), &v1alpha1.ConsumerGroupPause{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), err
+}
+
+// Delete takes name of the consumerGroupPause and deletes it. Returns an error if one occurs.
+func (c *FakeConsumerGroupPauses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(consumergrouppausesResource, c.ns, name), &v1alpha1.ConsumerGroupPause{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeConsumerGroupPauses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(consumergrouppausesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ConsumerGroupPauseList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched consumerGroupPause.
+func (c *FakeConsumerGroupPauses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ConsumerGroupPause, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(consumergrouppausesResource, c.ns, name, pt, data, subresources...), &v1alpha1.ConsumerGroupPause{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), err
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// FakeConsumerGroupSnapshots implements ConsumerGroupSnapshotInterface
+type FakeConsumerGroupSnapshots struct {
+	Fake *FakeKafkaV1alpha1
+	ns   string
+}
+
+var consumergroupsnapshotsResource = schema.GroupVersionResource{Group: "kafka.eventing.knative.dev", Version: "v1alpha1", Resource: "consumergroupsnapshots"}
+
+var consumergroupsnapshotsKind = schema.GroupVersionKind{Group: "kafka.eventing.knative.dev", Version: "v1alpha1", Kind: "ConsumerGroupSnapshot"}
+
+// Get takes name of the consumerGroupSnapshot, and returns the corresponding consumerGroupSnapshot object, and an error if there is any.
+func (c *FakeConsumerGroupSnapshots) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ConsumerGroupSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(consumergroupsnapshotsResource, c.ns, name), &v1alpha1.ConsumerGroupSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), err
+}
+
+// List takes label and field selectors, and returns the list of ConsumerGroupSnapshots that match those selectors.
+func (c *FakeConsumerGroupSnapshots) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ConsumerGroupSnapshotList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(consumergroupsnapshotsResource, consumergroupsnapshotsKind, c.ns, opts), &v1alpha1.ConsumerGroupSnapshotList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ConsumerGroupSnapshotList{ListMeta: obj.(*v1alpha1.ConsumerGroupSnapshotList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ConsumerGroupSnapshotList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested consumerGroupSnapshots.
+func (c *FakeConsumerGroupSnapshots) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(consumergroupsnapshotsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a consumerGroupSnapshot and creates it.  Returns the server's representation of the consumerGroupSnapshot, and an error, if there is any.
+func (c *FakeConsumerGroupSnapshots) Create(ctx context.Context, consumerGroupSnapshot *v1alpha1.ConsumerGroupSnapshot, opts v1.CreateOptions) (result *v1alpha1.ConsumerGroupSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(consumergroupsnapshotsResource, c.ns, consumerGroupSnapshot), &v1alpha1.ConsumerGroupSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), err
+}
+
+// Update takes the representation of a consumerGroupSnapshot and updates it. Returns the server's representation of the consumerGroupSnapshot, and an error, if there is any.
+func (c *FakeConsumerGroupSnapshots) Update(ctx context.Context, consumerGroupSnapshot *v1alpha1.ConsumerGroupSnapshot, opts v1.UpdateOptions) (result *v1alpha1.ConsumerGroupSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(consumergroupsnapshotsResource, c.ns, consumerGroupSnapshot), &v1alpha1.ConsumerGroupSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeConsumerGroupSnapshots) UpdateStatus(ctx context.Context, consumerGroupSnapshot *v1alpha1.ConsumerGroupSnapshot, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(consumergroupsnapshotsResource, "status", c.ns, consumerGroupSnapshot), &v1alpha1.ConsumerGroupSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), err
+}
+
+// Delete takes name of the consumerGroupSnapshot and deletes it. Returns an error if one occurs.
+func (c *FakeConsumerGroupSnapshots) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(consumergroupsnapshotsResource, c.ns, name), &v1alpha1.ConsumerGroupSnapshot{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeConsumerGroupSnapshots) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(consumergroupsnapshotsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ConsumerGroupSnapshotList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched consumerGroupSnapshot.
+func (c *FakeConsumerGroupSnapshots) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ConsumerGroupSnapshot, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(consumergroupsnapshotsResource, c.ns, name, pt, data, subresources...), &v1alpha1.ConsumerGroupSnapshot{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), err
+}
@@ -28,6 +28,14 @@ type FakeKafkaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKafkaV1alpha1) ConsumerGroupPauses(namespace string) v1alpha1.ConsumerGroupPauseInterface {
+	return &FakeConsumerGroupPauses{c, namespace}
+}
+
+func (c *FakeKafkaV1alpha1) ConsumerGroupSnapshots(namespace string) v1alpha1.ConsumerGroupSnapshotInterface {
+	return &FakeConsumerGroupSnapshots{c, namespace}
+}
+
 func (c *FakeKafkaV1alpha1) ResetOffsets(namespace string) v1alpha1.ResetOffsetInterface {
 	return &FakeResetOffsets{c, namespace}
 }
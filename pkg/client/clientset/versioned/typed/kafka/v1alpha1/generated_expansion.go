@@ -18,4 +18,8 @@ limitations under the License.
 
 package v1alpha1
 
+type ConsumerGroupPauseExpansion interface{}
+
+type ConsumerGroupSnapshotExpansion interface{}
+
 type ResetOffsetExpansion interface{}
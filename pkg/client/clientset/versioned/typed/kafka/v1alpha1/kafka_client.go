@@ -26,6 +26,8 @@ import (
 
 type KafkaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ConsumerGroupPausesGetter
+	ConsumerGroupSnapshotsGetter
 	ResetOffsetsGetter
 }
 
@@ -34,6 +36,14 @@ type KafkaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KafkaV1alpha1Client) ConsumerGroupPauses(namespace string) ConsumerGroupPauseInterface {
+	return newConsumerGroupPauses(c, namespace)
+}
+
+func (c *KafkaV1alpha1Client) ConsumerGroupSnapshots(namespace string) ConsumerGroupSnapshotInterface {
+	return newConsumerGroupSnapshots(c, namespace)
+}
+
 func (c *KafkaV1alpha1Client) ResetOffsets(namespace string) ResetOffsetInterface {
 	return newResetOffsets(c, namespace)
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	versioned "knative.dev/eventing-kafka/pkg/client/clientset/versioned"
+	internalinterfaces "knative.dev/eventing-kafka/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "knative.dev/eventing-kafka/pkg/client/listers/kafka/v1alpha1"
+)
+
+// ConsumerGroupSnapshotInformer provides access to a shared informer and lister for
+// ConsumerGroupSnapshots.
+type ConsumerGroupSnapshotInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ConsumerGroupSnapshotLister
+}
+
+type consumerGroupSnapshotInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewConsumerGroupSnapshotInformer constructs a new informer for ConsumerGroupSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewConsumerGroupSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredConsumerGroupSnapshotInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredConsumerGroupSnapshotInformer constructs a new informer for ConsumerGroupSnapshot type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredConsumerGroupSnapshotInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KafkaV1alpha1().ConsumerGroupSnapshots(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KafkaV1alpha1().ConsumerGroupSnapshots(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&kafkav1alpha1.ConsumerGroupSnapshot{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *consumerGroupSnapshotInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredConsumerGroupSnapshotInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *consumerGroupSnapshotInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kafkav1alpha1.ConsumerGroupSnapshot{}, f.defaultInformer)
+}
+
+func (f *consumerGroupSnapshotInformer) Lister() v1alpha1.ConsumerGroupSnapshotLister {
+	return v1alpha1.NewConsumerGroupSnapshotLister(f.Informer().GetIndexer())
+}
@@ -24,6 +24,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ConsumerGroupPauses returns a ConsumerGroupPauseInformer.
+	ConsumerGroupPauses() ConsumerGroupPauseInformer
+	// ConsumerGroupSnapshots returns a ConsumerGroupSnapshotInformer.
+	ConsumerGroupSnapshots() ConsumerGroupSnapshotInformer
 	// ResetOffsets returns a ResetOffsetInformer.
 	ResetOffsets() ResetOffsetInformer
 }
@@ -39,6 +43,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ConsumerGroupPauses returns a ConsumerGroupPauseInformer.
+func (v *version) ConsumerGroupPauses() ConsumerGroupPauseInformer {
+	return &consumerGroupPauseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ConsumerGroupSnapshots returns a ConsumerGroupSnapshotInformer.
+func (v *version) ConsumerGroupSnapshots() ConsumerGroupSnapshotInformer {
+	return &consumerGroupSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ResetOffsets returns a ResetOffsetInformer.
 func (v *version) ResetOffsets() ResetOffsetInformer {
 	return &resetOffsetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -264,6 +264,268 @@ func (w *wrapKafkaV1alpha1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapKafkaV1alpha1) ConsumerGroupPauses(namespace string) typedkafkav1alpha1.ConsumerGroupPauseInterface {
+	return &wrapKafkaV1alpha1ConsumerGroupPauseImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kafka.eventing.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "consumergrouppauses",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKafkaV1alpha1ConsumerGroupPauseImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkafkav1alpha1.ConsumerGroupPauseInterface = (*wrapKafkaV1alpha1ConsumerGroupPauseImpl)(nil)
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Create(ctx context.Context, in *v1alpha1.ConsumerGroupPause, opts v1.CreateOptions) (*v1alpha1.ConsumerGroupPause, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupPause",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPause{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ConsumerGroupPause, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPause{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ConsumerGroupPauseList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPauseList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ConsumerGroupPause, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPause{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Update(ctx context.Context, in *v1alpha1.ConsumerGroupPause, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupPause, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupPause",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPause{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) UpdateStatus(ctx context.Context, in *v1alpha1.ConsumerGroupPause, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupPause, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupPause",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupPause{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupPauseImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapKafkaV1alpha1) ConsumerGroupSnapshots(namespace string) typedkafkav1alpha1.ConsumerGroupSnapshotInterface {
+	return &wrapKafkaV1alpha1ConsumerGroupSnapshotImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kafka.eventing.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "consumergroupsnapshots",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKafkaV1alpha1ConsumerGroupSnapshotImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkafkav1alpha1.ConsumerGroupSnapshotInterface = (*wrapKafkaV1alpha1ConsumerGroupSnapshotImpl)(nil)
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Create(ctx context.Context, in *v1alpha1.ConsumerGroupSnapshot, opts v1.CreateOptions) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupSnapshot",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshot{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshot{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ConsumerGroupSnapshotList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshotList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ConsumerGroupSnapshot, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshot{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Update(ctx context.Context, in *v1alpha1.ConsumerGroupSnapshot, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupSnapshot",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshot{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) UpdateStatus(ctx context.Context, in *v1alpha1.ConsumerGroupSnapshot, opts v1.UpdateOptions) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kafka.eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "ConsumerGroupSnapshot",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ConsumerGroupSnapshot{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKafkaV1alpha1ConsumerGroupSnapshotImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKafkaV1alpha1) ResetOffsets(namespace string) typedkafkav1alpha1.ResetOffsetInterface {
 	return &wrapKafkaV1alpha1ResetOffsetImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	v1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// ConsumerGroupPauseLister helps list ConsumerGroupPauses.
+// All objects returned here must be treated as read-only.
+type ConsumerGroupPauseLister interface {
+	// List lists all ConsumerGroupPauses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupPause, err error)
+	// ConsumerGroupPauses returns an object that can list and get ConsumerGroupPauses.
+	ConsumerGroupPauses(namespace string) ConsumerGroupPauseNamespaceLister
+	ConsumerGroupPauseListerExpansion
+}
+
+// consumerGroupPauseLister implements the ConsumerGroupPauseLister interface.
+type consumerGroupPauseLister struct {
+	indexer cache.Indexer
+}
+
+// NewConsumerGroupPauseLister returns a new ConsumerGroupPauseLister.
+func NewConsumerGroupPauseLister(indexer cache.Indexer) ConsumerGroupPauseLister {
+	return &consumerGroupPauseLister{indexer: indexer}
+}
+
+// List lists all ConsumerGroupPauses in the indexer.
+func (s *consumerGroupPauseLister) List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupPause, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ConsumerGroupPause))
+	})
+	return ret, err
+}
+
+// ConsumerGroupPauses returns an object that can list and get ConsumerGroupPauses.
+func (s *consumerGroupPauseLister) ConsumerGroupPauses(namespace string) ConsumerGroupPauseNamespaceLister {
+	return consumerGroupPauseNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ConsumerGroupPauseNamespaceLister helps list and get ConsumerGroupPauses.
+// All objects returned here must be treated as read-only.
+type ConsumerGroupPauseNamespaceLister interface {
+	// List lists all ConsumerGroupPauses in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupPause, err error)
+	// Get retrieves the ConsumerGroupPause from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ConsumerGroupPause, error)
+	ConsumerGroupPauseNamespaceListerExpansion
+}
+
+// consumerGroupPauseNamespaceLister implements the ConsumerGroupPauseNamespaceLister
+// interface.
+type consumerGroupPauseNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ConsumerGroupPauses in the indexer for a given namespace.
+func (s consumerGroupPauseNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupPause, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ConsumerGroupPause))
+	})
+	return ret, err
+}
+
+// Get retrieves the ConsumerGroupPause from the indexer for a given namespace and name.
+func (s consumerGroupPauseNamespaceLister) Get(name string) (*v1alpha1.ConsumerGroupPause, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("consumergrouppause"), name)
+	}
+	return obj.(*v1alpha1.ConsumerGroupPause), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	v1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// ConsumerGroupSnapshotLister helps list ConsumerGroupSnapshots.
+// All objects returned here must be treated as read-only.
+type ConsumerGroupSnapshotLister interface {
+	// List lists all ConsumerGroupSnapshots in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupSnapshot, err error)
+	// ConsumerGroupSnapshots returns an object that can list and get ConsumerGroupSnapshots.
+	ConsumerGroupSnapshots(namespace string) ConsumerGroupSnapshotNamespaceLister
+	ConsumerGroupSnapshotListerExpansion
+}
+
+// consumerGroupSnapshotLister implements the ConsumerGroupSnapshotLister interface.
+type consumerGroupSnapshotLister struct {
+	indexer cache.Indexer
+}
+
+// NewConsumerGroupSnapshotLister returns a new ConsumerGroupSnapshotLister.
+func NewConsumerGroupSnapshotLister(indexer cache.Indexer) ConsumerGroupSnapshotLister {
+	return &consumerGroupSnapshotLister{indexer: indexer}
+}
+
+// List lists all ConsumerGroupSnapshots in the indexer.
+func (s *consumerGroupSnapshotLister) List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupSnapshot, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ConsumerGroupSnapshot))
+	})
+	return ret, err
+}
+
+// ConsumerGroupSnapshots returns an object that can list and get ConsumerGroupSnapshots.
+func (s *consumerGroupSnapshotLister) ConsumerGroupSnapshots(namespace string) ConsumerGroupSnapshotNamespaceLister {
+	return consumerGroupSnapshotNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ConsumerGroupSnapshotNamespaceLister helps list and get ConsumerGroupSnapshots.
+// All objects returned here must be treated as read-only.
+type ConsumerGroupSnapshotNamespaceLister interface {
+	// List lists all ConsumerGroupSnapshots in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupSnapshot, err error)
+	// Get retrieves the ConsumerGroupSnapshot from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ConsumerGroupSnapshot, error)
+	ConsumerGroupSnapshotNamespaceListerExpansion
+}
+
+// consumerGroupSnapshotNamespaceLister implements the ConsumerGroupSnapshotNamespaceLister
+// interface.
+type consumerGroupSnapshotNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ConsumerGroupSnapshots in the indexer for a given namespace.
+func (s consumerGroupSnapshotNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ConsumerGroupSnapshot, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ConsumerGroupSnapshot))
+	})
+	return ret, err
+}
+
+// Get retrieves the ConsumerGroupSnapshot from the indexer for a given namespace and name.
+func (s consumerGroupSnapshotNamespaceLister) Get(name string) (*v1alpha1.ConsumerGroupSnapshot, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("consumergroupsnapshot"), name)
+	}
+	return obj.(*v1alpha1.ConsumerGroupSnapshot), nil
+}
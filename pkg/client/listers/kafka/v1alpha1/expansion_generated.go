@@ -18,6 +18,22 @@ limitations under the License.
 
 package v1alpha1
 
+// ConsumerGroupPauseListerExpansion allows custom methods to be added to
+// ConsumerGroupPauseLister.
+type ConsumerGroupPauseListerExpansion interface{}
+
+// ConsumerGroupPauseNamespaceListerExpansion allows custom methods to be added to
+// ConsumerGroupPauseNamespaceLister.
+type ConsumerGroupPauseNamespaceListerExpansion interface{}
+
+// ConsumerGroupSnapshotListerExpansion allows custom methods to be added to
+// ConsumerGroupSnapshotLister.
+type ConsumerGroupSnapshotListerExpansion interface{}
+
+// ConsumerGroupSnapshotNamespaceListerExpansion allows custom methods to be added to
+// ConsumerGroupSnapshotNamespaceLister.
+type ConsumerGroupSnapshotNamespaceListerExpansion interface{}
+
 // ResetOffsetListerExpansion allows custom methods to be added to
 // ResetOffsetLister.
 type ResetOffsetListerExpansion interface{}
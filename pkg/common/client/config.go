@@ -123,6 +123,16 @@ type ConfigBuilder interface {
 	// if no offset was previously committed
 	WithInitialOffset(offset v1beta1.Offset) ConfigBuilder
 
+	// WithIsolationLevel sets the isolation level of the consumers,
+	// regardless what's set in the existing config (if provided)
+	// or in the YAML-string
+	WithIsolationLevel(level v1beta1.IsolationLevel) ConfigBuilder
+
+	// WithConsumerConfig makes the builder apply the consumer
+	// settings which are specified, on top of the existing config
+	// (if provided) and the YAML-string
+	WithConsumerConfig(consumerConfig *v1beta1.ConsumerConfigSpec) ConfigBuilder
+
 	// Build builds the Sarama config with the given context.
 	// Context is used for getting the config at the moment.
 	Build(ctx context.Context) (*sarama.Config, error)
@@ -133,13 +143,15 @@ func NewConfigBuilder() ConfigBuilder {
 }
 
 type configBuilder struct {
-	existing      *sarama.Config
-	defaults      bool
-	version       *sarama.KafkaVersion
-	clientId      string
-	yaml          string
-	auth          *KafkaAuthConfig
-	initialOffset v1beta1.Offset
+	existing       *sarama.Config
+	defaults       bool
+	version        *sarama.KafkaVersion
+	clientId       string
+	yaml           string
+	auth           *KafkaAuthConfig
+	initialOffset  v1beta1.Offset
+	isolationLevel v1beta1.IsolationLevel
+	consumerConfig *v1beta1.ConsumerConfigSpec
 }
 
 func (b *configBuilder) WithExisting(existing *sarama.Config) ConfigBuilder {
@@ -177,6 +189,16 @@ func (b *configBuilder) WithInitialOffset(offset v1beta1.Offset) ConfigBuilder {
 	return b
 }
 
+func (b *configBuilder) WithIsolationLevel(level v1beta1.IsolationLevel) ConfigBuilder {
+	b.isolationLevel = level
+	return b
+}
+
+func (b *configBuilder) WithConsumerConfig(consumerConfig *v1beta1.ConsumerConfigSpec) ConfigBuilder {
+	b.consumerConfig = consumerConfig
+	return b
+}
+
 // Build builds the Sarama config.
 func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 	var config *sarama.Config
@@ -281,6 +303,30 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 			config.Consumer.Offsets.Initial = sarama.OffsetOldest
 		case v1beta1.OffsetLatest:
 			config.Consumer.Offsets.Initial = sarama.OffsetNewest
+		default:
+			// The offsets of a timestamp are initialized by the controller, and the
+			// partitions created afterwards only contain more recent messages.
+			config.Consumer.Offsets.Initial = sarama.OffsetOldest
+		}
+	}
+
+	switch b.isolationLevel {
+	case "":
+	case v1beta1.IsolationLevelReadUncommitted:
+		config.Consumer.IsolationLevel = sarama.ReadUncommitted
+	case v1beta1.IsolationLevelReadCommitted:
+		// The transactional markers are only returned by brokers >= 0.11
+		if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, fmt.Errorf("isolation level %s requires Kafka version 0.11.0.0 or later, got %s", b.isolationLevel, config.Version)
+		}
+		config.Consumer.IsolationLevel = sarama.ReadCommitted
+	default:
+		return nil, fmt.Errorf("invalid isolation level %q", b.isolationLevel)
+	}
+
+	if b.consumerConfig != nil {
+		if err := applyConsumerConfig(config, b.consumerConfig); err != nil {
+			return nil, err
 		}
 	}
 
@@ -294,6 +340,56 @@ func (b *configBuilder) Build(ctx context.Context) (*sarama.Config, error) {
 	return config, nil
 }
 
+// applyConsumerConfig overrides the consumer settings of the Sarama config
+// with the ones specified in the given ConsumerConfigSpec.
+func applyConsumerConfig(config *sarama.Config, consumerConfig *v1beta1.ConsumerConfigSpec) error {
+	if fetch := consumerConfig.Fetch; fetch != nil {
+		if fetch.Min > 0 {
+			config.Consumer.Fetch.Min = fetch.Min
+		}
+		if fetch.Default > 0 {
+			config.Consumer.Fetch.Default = fetch.Default
+		}
+		if fetch.Max > 0 {
+			config.Consumer.Fetch.Max = fetch.Max
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value *string
+		field *time.Duration
+	}{
+		{"sessionTimeout", consumerConfig.SessionTimeout, &config.Consumer.Group.Session.Timeout},
+		{"heartbeatInterval", consumerConfig.HeartbeatInterval, &config.Consumer.Group.Heartbeat.Interval},
+		{"rebalanceTimeout", consumerConfig.RebalanceTimeout, &config.Consumer.Group.Rebalance.Timeout},
+		{"maxProcessingTime", consumerConfig.MaxProcessingTime, &config.Consumer.MaxProcessingTime},
+	} {
+		if d.value == nil {
+			continue
+		}
+		duration, err := v1beta1.ParseISO8601Duration(*d.value)
+		if err != nil {
+			return fmt.Errorf("invalid consumer %s %q: %w", d.name, *d.value, err)
+		}
+		*d.field = duration
+	}
+
+	switch consumerConfig.RebalanceStrategy {
+	case "":
+	case v1beta1.RebalanceStrategyRange:
+		config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRange
+	case v1beta1.RebalanceStrategyRoundRobin:
+		config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	case v1beta1.RebalanceStrategySticky:
+		config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategySticky
+	default:
+		return fmt.Errorf("invalid rebalance strategy %q", consumerConfig.RebalanceStrategy)
+	}
+
+	return nil
+}
+
 // ConfigEqual is a convenience function to determine if two given sarama.Config structs are identical aside
 // from unserializable fields (e.g. function pointers).  To ignore parts of the sarama.Config struct, pass
 // them in as the "ignore" parameter.
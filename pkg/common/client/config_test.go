@@ -38,6 +38,7 @@ import (
 	commontesting "knative.dev/eventing-kafka/pkg/common/testing"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
 )
 
 const (
@@ -319,6 +320,189 @@ func TestBuildSaramaConfig(t *testing.T) {
 	assert.Equal(t, sarama.V2_0_0_0, config.Version)
 }
 
+func TestConfigBuilderInitialOffset(t *testing.T) {
+	ctx := context.TODO()
+	for offset, want := range map[v1beta1.Offset]int64{
+		v1beta1.OffsetEarliest: sarama.OffsetOldest,
+		v1beta1.OffsetLatest:   sarama.OffsetNewest,
+		"2021-06-01T00:00:00Z": sarama.OffsetOldest,
+		"-1h":                  sarama.OffsetOldest,
+	} {
+		config, err := NewConfigBuilder().
+			WithDefaults().
+			WithInitialOffset(offset).
+			Build(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, want, config.Consumer.Offsets.Initial, "initial offset %s", offset)
+	}
+}
+
+func TestConfigBuilderIsolationLevel(t *testing.T) {
+	ctx := context.TODO()
+	for level, want := range map[v1beta1.IsolationLevel]sarama.IsolationLevel{
+		"":                                    sarama.ReadUncommitted,
+		v1beta1.IsolationLevelReadUncommitted: sarama.ReadUncommitted,
+		v1beta1.IsolationLevelReadCommitted:   sarama.ReadCommitted,
+	} {
+		config, err := NewConfigBuilder().
+			WithDefaults().
+			WithIsolationLevel(level).
+			Build(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, want, config.Consumer.IsolationLevel, "isolation level %s", level)
+	}
+
+	// The isolation level overrides the one of the YAML-string
+	config, err := NewConfigBuilder().
+		WithDefaults().
+		FromYaml("Consumer:\n  IsolationLevel: 1\n").
+		WithIsolationLevel(v1beta1.IsolationLevelReadUncommitted).
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sarama.ReadUncommitted, config.Consumer.IsolationLevel)
+
+	_, err = NewConfigBuilder().
+		WithDefaults().
+		WithIsolationLevel("serializable").
+		Build(ctx)
+	assert.NotNil(t, err)
+
+	_, err = NewConfigBuilder().
+		WithDefaults().
+		WithVersion(&sarama.V0_10_2_0).
+		WithIsolationLevel(v1beta1.IsolationLevelReadCommitted).
+		Build(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestConfigBuilderConsumerConfig(t *testing.T) {
+	ctx := context.TODO()
+
+	// The consumer config overrides the specified settings of the YAML-string only
+	config, err := NewConfigBuilder().
+		WithDefaults().
+		FromYaml("Consumer:\n  Fetch:\n    Min: 16\n    Default: 2048\n  Group:\n    Session:\n      Timeout: 20000000000\n").
+		WithConsumerConfig(&v1beta1.ConsumerConfigSpec{
+			Fetch:             &v1beta1.FetchSpec{Default: 4096, Max: 8192},
+			HeartbeatInterval: ptr.String("PT2S"),
+			RebalanceTimeout:  ptr.String("PT1M"),
+			MaxProcessingTime: ptr.String("PT0.5S"),
+			RebalanceStrategy: v1beta1.RebalanceStrategyRoundRobin,
+		}).
+		Build(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(16), config.Consumer.Fetch.Min)
+	assert.Equal(t, int32(4096), config.Consumer.Fetch.Default)
+	assert.Equal(t, int32(8192), config.Consumer.Fetch.Max)
+	assert.Equal(t, 20*time.Second, config.Consumer.Group.Session.Timeout)
+	assert.Equal(t, 2*time.Second, config.Consumer.Group.Heartbeat.Interval)
+	assert.Equal(t, time.Minute, config.Consumer.Group.Rebalance.Timeout)
+	assert.Equal(t, 500*time.Millisecond, config.Consumer.MaxProcessingTime)
+	assert.Equal(t, sarama.BalanceStrategyRoundRobin, config.Consumer.Group.Rebalance.Strategy)
+
+	for strategy, want := range map[v1beta1.RebalanceStrategy]sarama.BalanceStrategy{
+		"":                                  sarama.BalanceStrategyRange,
+		v1beta1.RebalanceStrategyRange:      sarama.BalanceStrategyRange,
+		v1beta1.RebalanceStrategyRoundRobin: sarama.BalanceStrategyRoundRobin,
+		v1beta1.RebalanceStrategySticky:     sarama.BalanceStrategySticky,
+	} {
+		config, err := NewConfigBuilder().
+			WithDefaults().
+			WithConsumerConfig(&v1beta1.ConsumerConfigSpec{RebalanceStrategy: strategy}).
+			Build(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, want, config.Consumer.Group.Rebalance.Strategy, "rebalance strategy %s", strategy)
+	}
+
+	_, err = NewConfigBuilder().
+		WithDefaults().
+		WithConsumerConfig(&v1beta1.ConsumerConfigSpec{SessionTimeout: ptr.String("10s")}).
+		Build(ctx)
+	assert.NotNil(t, err)
+
+	_, err = NewConfigBuilder().
+		WithDefaults().
+		WithConsumerConfig(&v1beta1.ConsumerConfigSpec{RebalanceStrategy: "cooperative"}).
+		Build(ctx)
+	assert.NotNil(t, err)
+}
+
+// TestReadCommittedSkipsAbortedTransactions consumes a partition, containing the records of a committed and
+// of an aborted transaction, from a mock broker with both isolation levels.
+func TestReadCommittedSkipsAbortedTransactions(t *testing.T) {
+	const topic = "transactions"
+	for level, want := range map[v1beta1.IsolationLevel][]string{
+		v1beta1.IsolationLevelReadUncommitted: {"committed", "aborted", "non-transactional"},
+		v1beta1.IsolationLevelReadCommitted:   {"committed", "non-transactional"},
+	} {
+		t.Run(string(level), func(t *testing.T) {
+			broker := sarama.NewMockBroker(t, 1)
+			defer broker.Close()
+
+			// Producer 1 commits its transaction (offsets 0-1), producer 2 aborts its own (offsets 2-3)
+			fetchResponse := &sarama.FetchResponse{Version: 4}
+			fetchResponse.AddRecordBatch(topic, 0, nil, sarama.StringEncoder("committed"), 0, 1, true)
+			fetchResponse.AddControlRecord(topic, 0, 1, 1, sarama.ControlRecordCommit)
+			fetchResponse.AddRecordBatch(topic, 0, nil, sarama.StringEncoder("aborted"), 2, 2, true)
+			fetchResponse.AddControlRecord(topic, 0, 3, 2, sarama.ControlRecordAbort)
+			fetchResponse.AddRecordBatch(topic, 0, nil, sarama.StringEncoder("non-transactional"), 4, 3, false)
+			fetchResponse.SetLastStableOffset(topic, 0, 5)
+			block := fetchResponse.GetBlock(topic, 0)
+			block.HighWaterMarkOffset = 5
+			block.AbortedTransactions = []*sarama.AbortedTransaction{{ProducerID: 2, FirstOffset: 2}}
+
+			broker.SetHandlerByMap(map[string]sarama.MockResponse{
+				"MetadataRequest": sarama.NewMockMetadataResponse(t).
+					SetBroker(broker.Addr(), broker.BrokerID()).
+					SetLeader(topic, 0, broker.BrokerID()),
+				"OffsetRequest": sarama.NewMockOffsetResponse(t).
+					SetVersion(1).
+					SetOffset(topic, 0, sarama.OffsetOldest, 0).
+					SetOffset(topic, 0, sarama.OffsetNewest, 5),
+				"FetchRequest": sarama.NewMockWrapper(fetchResponse),
+			})
+
+			config, err := NewConfigBuilder().
+				WithDefaults().
+				WithIsolationLevel(level).
+				Build(context.TODO())
+			assert.Nil(t, err)
+
+			consumer, err := sarama.NewConsumer([]string{broker.Addr()}, config)
+			assert.Nil(t, err)
+			defer consumer.Close()
+
+			partitionConsumer, err := consumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
+			assert.Nil(t, err)
+			defer partitionConsumer.Close()
+
+			var got []string
+			for len(got) < len(want) {
+				select {
+				case msg := <-partitionConsumer.Messages():
+					got = append(got, string(msg.Value))
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out waiting for messages, got %v", got)
+				}
+			}
+			assert.Equal(t, want, got)
+
+			// The messages after the last expected one are never delivered
+			select {
+			case msg := <-partitionConsumer.Messages():
+				t.Errorf("unexpected message at offset %d: %s", msg.Offset, msg.Value)
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			for _, rr := range broker.History() {
+				if req, ok := rr.Request.(*sarama.FetchRequest); ok {
+					assert.Equal(t, config.Consumer.IsolationLevel, req.Isolation)
+				}
+			}
+		})
+	}
+}
+
 func extractSaramaConfig(t *testing.T, saramaConfigField string) string {
 	saramaShell := &struct {
 		EnableLogging bool   `json:"enableLogging"`
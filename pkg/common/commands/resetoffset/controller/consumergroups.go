@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "knative.dev/control-protocol/pkg"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// consumerGroupStep identifies the step at which the reconciliation of a single ConsumerGroup failed.
+type consumerGroupStep int
+
+const (
+	consumerGroupStepNone consumerGroupStep = iota // No Failure
+	consumerGroupStepStop
+	consumerGroupStepOffsets
+	consumerGroupStepStart
+	consumerGroupStepUndoRefused // Permanent, Not Retried
+)
+
+// reconcileConsumerGroups performs the ResetOffset reconciliation for Refs which map to multiple
+// ConsumerGroups (e.g. a KafkaChannel).  Each ConsumerGroup is stopped, repositioned and restarted
+// independently and in parallel, with the individual results tracked in ResetOffset.Status.Groups
+// so that partial failures are visible and only the failed ConsumerGroups are re-processed on
+// subsequent reconciliations.
+func (r *Reconciler) reconcileConsumerGroups(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, multiRefMapper refmappers.ResetOffsetMultiRefMapper) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Map The ResetOffset's Ref To Multiple Kafka Topic Names / ConsumerGroup IDs
+	refInfos, err := multiRefMapper.MapRefs(resetOffset)
+	if err != nil {
+		logger.Error("Failed to map ResetOffset.Spec.Ref to Kafka Topic names and ConsumerGroup IDs", zap.Error(err))
+		resetOffset.Status.MarkRefMappedFailed("FailedToMapRef", "Failed to map 'ref' to Kafka Topics and Groups: %v", err)
+		return fmt.Errorf("failed to map 'ref' to Kafka Topics and Groups: %v", err)
+	}
+	logger.Info("Successfully mapped ResetOffset.Spec.Ref to multiple ConsumerGroups", zap.Int("Count", len(refInfos)))
+	resetOffset.Status.MarkRefMappedTrue()
+
+	// Reconcile The DataPlane "Services" From The ConnectionPool Once For Each Distinct Key
+	dataPlaneServices := make(map[string]map[string]ctrl.Service)
+	for _, refInfo := range refInfos {
+		if _, ok := dataPlaneServices[refInfo.ConnectionPoolKey]; ok {
+			continue
+		}
+		services, err := r.reconcileDataPlaneServices(ctx, resetOffset, refInfo)
+		if err != nil {
+			logger.Error("Failed to reconcile DataPlane services from ConnectionPool", zap.Error(err))
+			resetOffset.Status.MarkAcquireDataPlaneServicesFailed("FailedToAcquireDataPlaneServices", "Failed to reconciler DataPlane Services from ConnectionPool: %v", err)
+			return fmt.Errorf("failed to reconcile DataPlane Services from ConnectionPool: %v", err)
+		}
+		dataPlaneServices[refInfo.ConnectionPoolKey] = services
+	}
+	logger.Info("Successfully reconciled DataPlane Services from ConnectionPool")
+	resetOffset.Status.MarkAcquireDataPlaneServicesTrue()
+
+	// Initialize The ConsumerGroupResults, Retaining Any Results From Prior Reconciliations
+	results := make([]kafkav1alpha1.ConsumerGroupResult, len(refInfos))
+	for index, refInfo := range refInfos {
+		if priorResult := resetOffset.Status.GetGroupResult(refInfo.GroupId); priorResult != nil {
+			results[index] = *priorResult
+		} else {
+			results[index] = kafkav1alpha1.ConsumerGroupResult{Topic: refInfo.TopicName, Group: refInfo.GroupId}
+		}
+	}
+
+	// Reconcile All The Unfinished ConsumerGroups In Parallel (Refused Undos Only Until Restarted)
+	steps := make([]consumerGroupStep, len(refInfos))
+	waitGroup := &sync.WaitGroup{}
+	for index, refInfo := range refInfos {
+		if results[index].Succeeded || (results[index].UndoRefused && !results[index].Stopped) {
+			continue
+		}
+		waitGroup.Add(1)
+		go func(index int, refInfo *refmappers.RefInfo) {
+			defer waitGroup.Done()
+			steps[index] = r.reconcileConsumerGroup(ctx, resetOffset, dataPlaneServices[refInfo.ConnectionPoolKey], refInfo, &results[index])
+		}(index, refInfo)
+	}
+	waitGroup.Wait()
+	resetOffset.Status.SetGroups(results)
+
+	// Count The ConsumerGroup Failures Of Each Step, Separately From The Refused Undos
+	failures := make(map[consumerGroupStep]int)
+	failureCount := 0
+	for _, step := range steps {
+		if step != consumerGroupStepNone && step != consumerGroupStepUndoRefused {
+			failures[step]++
+			failureCount++
+		}
+	}
+	refusedCount := 0
+	for _, result := range results {
+		if result.UndoRefused {
+			refusedCount++
+		}
+	}
+	total := len(refInfos)
+
+	// Aggregate The ConsumerGroup Results Into The ResetOffset Status Conditions
+	if failures[consumerGroupStepStop] > 0 {
+		resetOffset.Status.MarkConsumerGroupsStoppedFailed("FailedToStopConsumerGroups", "Failed to stop %d of %d ConsumerGroups (see status.groups)", failures[consumerGroupStepStop], total)
+	} else {
+		resetOffset.Status.MarkConsumerGroupsStoppedTrue()
+	}
+	if failures[consumerGroupStepOffsets] > 0 {
+		resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToUpdateOffsets", "Failed to update Offsets of %d of %d ConsumerGroups (see status.groups)", failures[consumerGroupStepOffsets], total)
+	} else if refusedCount > 0 {
+		resetOffset.Status.MarkOffsetsUpdatedFailed("UndoRefused", "Refused to undo ResetOffset for %d of %d ConsumerGroups (see status.groups)", refusedCount, total)
+	} else if failures[consumerGroupStepStop] == 0 {
+		resetOffset.Status.MarkOffsetsUpdatedTrue()
+	}
+	if failures[consumerGroupStepStart] > 0 {
+		resetOffset.Status.MarkConsumerGroupsStartedFailed("FailedToStartConsumerGroups", "Failed to restart %d of %d ConsumerGroups (see status.groups)", failures[consumerGroupStepStart], total)
+	} else if failureCount == 0 {
+		resetOffset.Status.MarkConsumerGroupsStartedTrue()
+	}
+
+	// Return An Error If Any ConsumerGroups Failed So That They Will Be Retried
+	if failureCount > 0 {
+		logger.Error("Failed to reset Offsets of one or more ConsumerGroups", zap.Int("Failed", failureCount), zap.Int("Total", total))
+		return fmt.Errorf("failed to reset Offsets of %d of %d ConsumerGroups", failureCount, total)
+	}
+
+	// Return A Permanent Error If Any Undo Was Refused, Since Retrying Would Be Refused Again
+	if refusedCount > 0 {
+		logger.Error("Refused to undo ResetOffset for one or more ConsumerGroups", zap.Int("Refused", refusedCount), zap.Int("Total", total))
+		return controller.NewPermanentError(fmt.Errorf("refused to undo ResetOffset for %d of %d ConsumerGroups", refusedCount, total))
+	}
+
+	// Return Reconciled Success Event
+	logger.Info("Successfully reset Offsets of all ConsumerGroups", zap.Int("Total", total))
+	return reconciler.NewEvent(corev1.EventTypeNormal, ResetOffsetReconciled.String(), "Reconciled successfully")
+}
+
+// reconcileConsumerGroup performs the stop / reposition / start sequence for a single ConsumerGroup and
+// records the outcome in the specified ConsumerGroupResult.  The Offsets will only be repositioned if
+// the ConsumerGroupResult indicates they have not already been updated.  A refused undo is recorded in
+// the ConsumerGroupResult and the ConsumerGroup is restarted if it was stopped, but it is never
+// repositioned.  The step which failed, if any, is returned.
+func (r *Reconciler) reconcileConsumerGroup(ctx context.Context,
+	resetOffset *kafkav1alpha1.ResetOffset,
+	services map[string]ctrl.Service,
+	refInfo *refmappers.RefInfo,
+	result *kafkav1alpha1.ConsumerGroupResult) consumerGroupStep {
+
+	// Get The Logger From Context & Enhance With ConsumerGroup
+	logger := logging.FromContext(ctx).Desugar().With(zap.String("Topic", refInfo.TopicName), zap.String("Group", refInfo.GroupId))
+
+	// Clear Any Prior Failure Message (Unless The Undo Was Refused, Which Is Permanent)
+	if !result.UndoRefused {
+		result.Message = ""
+	}
+
+	// Only Stop ConsumerGroup & Update Offsets Once (And Never After The Undo Was Refused)
+	if !result.OffsetsUpdated && !result.UndoRefused {
+
+		// Resolve The Offset Position (Time Or ConsumerGroupSnapshot) Before Stopping The ConsumerGroup
+		offsetsUpdater, err := r.newOffsetsUpdater(ctx, resetOffset, refInfo)
+		if isUndoRefused(err) {
+			refuseConsumerGroupUndo(logger, result, err)
+			if !result.Stopped {
+				return consumerGroupStepUndoRefused
+			}
+		} else if err != nil {
+			logger.Error("Failed to resolve Offset position from ResetOffset Spec", zap.Error(err))
+			result.Message = fmt.Sprintf("Failed to resolve Offset position: %v", err)
+			return consumerGroupStepOffsets
+		}
+
+		if !result.UndoRefused {
+
+			// Stop The ConsumerGroup In Associated Dispatchers
+			err = r.stopConsumerGroups(ctx, resetOffset, services, refInfo)
+			if err != nil {
+				logger.Error("Failed to stop ConsumerGroup", zap.Error(err))
+				result.Message = fmt.Sprintf("Failed to stop ConsumerGroup: %v", err)
+				return consumerGroupStepStop
+			}
+			result.Stopped = true
+
+			// Update The Sarama Offsets (Single Atomic Operation For All Offsets Of The ConsumerGroup)
+			offsetMappings, err := offsetsUpdater(ctx)
+			if isUndoRefused(err) {
+				refuseConsumerGroupUndo(logger, result, err)
+			} else if err != nil {
+				logger.Error("Failed to update Offsets of ConsumerGroup Partitions", zap.Error(err))
+				result.Message = fmt.Sprintf("Failed to update Offsets of ConsumerGroup Partitions: %v", err)
+				return consumerGroupStepOffsets
+			} else {
+				result.Partitions = offsetMappings
+				result.OffsetsUpdated = true
+			}
+		}
+	}
+
+	// Start The ConsumerGroup In Associated Dispatchers
+	err := r.startConsumerGroups(ctx, resetOffset, services, refInfo)
+	if err != nil {
+		logger.Error("Failed to restart ConsumerGroup", zap.Error(err))
+		if !result.UndoRefused {
+			result.Message = fmt.Sprintf("Failed to restart ConsumerGroup: %v", err)
+		}
+		return consumerGroupStepStart
+	}
+	result.Stopped = false
+
+	// Return The Refused Undo Now That The ConsumerGroup Has Been Restarted
+	if result.UndoRefused {
+		logger.Info("Successfully restarted ConsumerGroup after refusing undo")
+		return consumerGroupStepUndoRefused
+	}
+
+	// Return Success
+	logger.Info("Successfully reset Offsets of ConsumerGroup")
+	result.Succeeded = true
+	return consumerGroupStepNone
+}
+
+// refuseConsumerGroupUndo records in the specified ConsumerGroupResult that the ConsumerGroup has progressed
+// too far to undo the referenced ResetOffset.
+func refuseConsumerGroupUndo(logger *zap.Logger, result *kafkav1alpha1.ConsumerGroupResult, err error) {
+	logger.Error("Refused to undo ResetOffset", zap.Error(err))
+	result.Message = fmt.Sprintf("Refused to undo ResetOffset: %v", err)
+	result.UndoRefused = true
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+func TestReconciler_ReconcileConsumerGroups(t *testing.T) {
+
+	// Test Data
+	reconcilerUID := types.UID(uuid.NewString())
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	topicName := controllertesting.TopicName
+	groupId1 := "TestGroupId1"
+	groupId2 := "TestGroupId2"
+	partition := int32(0)
+	oldOffset := int64(100)
+	newOffset := oldOffset - 50
+	offsetTime := sarama.OffsetOldest
+	metadata := formatOffsetMetaData(offsetTime)
+	offsetMappings := []kafkav1alpha1.OffsetMapping{{Partition: partition, OldOffset: oldOffset, NewOffset: newOffset}}
+	podIp := "1.2.3.4"
+	pods := []*corev1.Pod{{Status: corev1.PodStatus{PodIP: podIp}}}
+	podIpPorts := []string{fmt.Sprintf("%s:%d", podIp, controlprotocol.ServerPort)}
+	testErr := fmt.Errorf("test-error")
+
+	// Create A Context With Test Logger
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Create The RefInfos For The Two ConsumerGroups (Same Topic / DataPlane As Subscriptions To A Single KafkaChannel)
+	refInfo1 := refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = groupId1 })
+	refInfo2 := refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = groupId2 })
+
+	// Define The Test Cases
+	tests := []struct {
+		name            string
+		priorGroups     []kafkav1alpha1.ConsumerGroupResult
+		mapRefsErr      error
+		stopGroup2Err   error
+		wantErr         bool
+		wantPermanent   bool
+		wantRefMapped   corev1.ConditionStatus
+		wantStopped     corev1.ConditionStatus
+		wantUpdated     corev1.ConditionStatus
+		wantStarted     corev1.ConditionStatus
+		wantGroups      []kafkav1alpha1.ConsumerGroupResult
+		wantGroup1Calls bool
+		noGroup2Stop    bool
+		noGroup2Start   bool
+		wantGroup2Start bool
+	}{
+		{
+			name:          "Success",
+			wantRefMapped: corev1.ConditionTrue,
+			wantStopped:   corev1.ConditionTrue,
+			wantUpdated:   corev1.ConditionTrue,
+			wantStarted:   corev1.ConditionTrue,
+			wantGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+			},
+			wantGroup1Calls: true,
+		},
+		{
+			name:          "MapRefs Error",
+			mapRefsErr:    testErr,
+			wantErr:       true,
+			wantRefMapped: corev1.ConditionFalse,
+			wantStopped:   corev1.ConditionUnknown,
+			wantUpdated:   corev1.ConditionUnknown,
+			wantStarted:   corev1.ConditionUnknown,
+		},
+		{
+			name:          "Partial Failure",
+			stopGroup2Err: testErr,
+			wantErr:       true,
+			wantRefMapped: corev1.ConditionTrue,
+			wantStopped:   corev1.ConditionFalse,
+			wantUpdated:   corev1.ConditionUnknown,
+			wantStarted:   corev1.ConditionUnknown,
+			wantGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, Message: "Failed to stop ConsumerGroup: failed to send ConsumerGroup AsyncCommand '%d': test-error"},
+			},
+			wantGroup1Calls: true,
+		},
+		{
+			name: "Retry Only Unfinished ConsumerGroups",
+			priorGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, Message: "previous failure"},
+			},
+			wantRefMapped: corev1.ConditionTrue,
+			wantStopped:   corev1.ConditionTrue,
+			wantUpdated:   corev1.ConditionTrue,
+			wantStarted:   corev1.ConditionTrue,
+			wantGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+			},
+			wantGroup1Calls: false,
+		},
+		{
+			name: "Restart Stopped ConsumerGroup After Refused Undo",
+			priorGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, UndoRefused: true, Stopped: true, Message: "Refused to undo ResetOffset: progressed"},
+			},
+			wantErr:       true,
+			wantPermanent: true,
+			wantRefMapped: corev1.ConditionTrue,
+			wantStopped:   corev1.ConditionTrue,
+			wantUpdated:   corev1.ConditionFalse,
+			wantStarted:   corev1.ConditionTrue,
+			wantGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, UndoRefused: true, Message: "Refused to undo ResetOffset: progressed"},
+			},
+			wantGroup1Calls: false,
+			noGroup2Stop:    true,
+			wantGroup2Start: true,
+		},
+		{
+			name: "Skip Restarted ConsumerGroup After Refused Undo",
+			priorGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, UndoRefused: true, Message: "Refused to undo ResetOffset: progressed"},
+			},
+			wantErr:       true,
+			wantPermanent: true,
+			wantRefMapped: corev1.ConditionTrue,
+			wantStopped:   corev1.ConditionTrue,
+			wantUpdated:   corev1.ConditionFalse,
+			wantStarted:   corev1.ConditionTrue,
+			wantGroups: []kafkav1alpha1.ConsumerGroupResult{
+				{Topic: topicName, Group: groupId1, Partitions: offsetMappings, OffsetsUpdated: true, Succeeded: true},
+				{Topic: topicName, Group: groupId2, UndoRefused: true, Message: "Refused to undo ResetOffset: progressed"},
+			},
+			wantGroup1Calls: false,
+			noGroup2Stop:    true,
+			noGroup2Start:   true,
+		},
+	}
+
+	// Restore Sarama Client / OffsetManager Stubs After Test Completion
+	defer restoreSaramaNewClientFn()
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create The ResetOffset To Test
+			resetOffset := controllertesting.NewResetOffset()
+			resetOffset.Status.SetGroups(test.priorGroups)
+			resetOffset.Status.InitializeConditions()
+			resetOffsetNamespacedName := controllertesting.NewResetOffsetNamespacedName()
+
+			// Create A Mock PodLister
+			mockPodNamespaceLister := &controllertesting.MockPodNamespaceLister{}
+			mockPodNamespaceLister.On("List", labels.Set(refInfo1.DataPlaneLabels).AsSelector()).Return(pods, nil)
+			mockPodLister := &controllertesting.MockPodLister{}
+			mockPodLister.On("Pods", refInfo1.DataPlaneNamespace).Return(mockPodNamespaceLister)
+
+			// Create The Test ConsumerGroupAsyncCommands For Each ConsumerGroup
+			lockToken := GenerateLockToken(reconcilerUID, resetOffset.UID)
+			newCommand := func(refInfo *refmappers.RefInfo, opCode ctrl.OpCode, lock *commands.CommandLock) *commands.ConsumerGroupAsyncCommand {
+				commandId, err := GenerateCommandId(resetOffset, podIp, refInfo.GroupId, opCode)
+				assert.Nil(t, err)
+				return &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: lock}
+			}
+			stopLock := commands.NewCommandLock(lockToken, asyncCommandLockTimeout, true, false)
+			startLock := commands.NewCommandLock(lockToken, 0, false, true)
+			stopCommand1 := newCommand(refInfo1, commands.StopConsumerGroupOpCode, stopLock)
+			startCommand1 := newCommand(refInfo1, commands.StartConsumerGroupOpCode, startLock)
+			stopCommand2 := newCommand(refInfo2, commands.StopConsumerGroupOpCode, stopLock)
+			startCommand2 := newCommand(refInfo2, commands.StartConsumerGroupOpCode, startLock)
+
+			// Create The Mock Service To Test Against
+			mockDataPlaneService := &controlprotocoltesting.MockService{}
+			mockDataPlaneService.On("SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopCommand1).Return(nil)
+			mockDataPlaneService.On("SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand1).Return(nil)
+			mockDataPlaneService.On("SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopCommand2).Return(test.stopGroup2Err)
+			mockDataPlaneService.On("SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand2).Return(nil)
+			mockDataPlaneService.On("MessageHandler", mock.Anything).Return()
+			services := map[string]ctrl.Service{podIp: mockDataPlaneService}
+
+			// Create A Mock Control-Protocol ConnectionPool (Both ConsumerGroups Share The Same Key)
+			mockConnectionPool := &controlprotocoltesting.MockConnectionPool{}
+			mockConnectionPool.On("ReconcileConnections",
+				mock.Anything,
+				refInfo1.ConnectionPoolKey,
+				podIpPorts,
+				mock.AnythingOfType("func(string, control.Service)"),
+				mock.AnythingOfType("func(string)")).
+				Return(services, nil).Once()
+
+			// Create A Mock Control-Protocol AsyncCommandNotificationStore
+			successResult := &ctrlmessage.AsyncCommandResult{}
+			mockAsyncCommandNotificationStore := &controlprotocoltesting.MockAsyncCommandNotificationStore{}
+			for _, command := range []*commands.ConsumerGroupAsyncCommand{stopCommand1, startCommand1, stopCommand2, startCommand2} {
+				mockAsyncCommandNotificationStore.On("GetCommandResult", resetOffsetNamespacedName, podIp, command).Return(successResult)
+			}
+
+			// Create A Mock ResetOffset MultiRefMapper
+			mockMultiRefMapper := &refmapperstesting.MockResetOffsetMultiRefMapper{}
+			mockMultiRefMapper.On("MapRefs", resetOffset).Return([]*refmappers.RefInfo{refInfo1, refInfo2}, test.mapRefsErr)
+
+			// Mock & Stub "success" Sarama Client / OffsetManagers For Each ConsumerGroup
+			mockClient := newSuccessSaramaClient(topicName, partition, offsetTime, newOffset)
+			stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, mockClient, nil)
+			offsetManagers := map[string]sarama.OffsetManager{
+				groupId1: newSuccessSaramaOffsetManager(topicName, partition, oldOffset, newOffset, metadata),
+				groupId2: newSuccessSaramaOffsetManager(topicName, partition, oldOffset, newOffset, metadata),
+			}
+			SaramaNewOffsetManagerFromClientFn = func(groupId string, client sarama.Client) (sarama.OffsetManager, error) {
+				assert.Equal(t, mockClient, client)
+				return offsetManagers[groupId], nil
+			}
+
+			// Create The Reconciler To Test
+			r := &Reconciler{
+				uid:                           reconcilerUID,
+				kafkaBrokers:                  kafkaBrokers,
+				saramaConfig:                  saramaConfig,
+				podLister:                     mockPodLister,
+				refMapper:                     mockMultiRefMapper,
+				connectionPool:                mockConnectionPool,
+				asyncCommandNotificationStore: mockAsyncCommandNotificationStore,
+				asyncCommandRouter:            newAsyncCommandRouter(),
+			}
+
+			// Perform The Test
+			err := r.reconcileConsumerGroups(ctx, resetOffset, mockMultiRefMapper)
+
+			// Verify The Results
+			var event *reconciler.ReconcilerEvent
+			assert.Equal(t, test.wantErr, !(reconciler.EventAs(err, &event) && event.EventType == corev1.EventTypeNormal))
+			assert.Equal(t, test.wantPermanent, controller.IsPermanentError(err))
+			status := resetOffset.Status
+			assert.Equal(t, test.wantRefMapped, status.GetCondition(kafkav1alpha1.ResetOffsetConditionRefMapped).Status)
+			assert.Equal(t, test.wantStopped, status.GetCondition(kafkav1alpha1.ResetOffsetConditionConsumerGroupsStopped).Status)
+			assert.Equal(t, test.wantUpdated, status.GetCondition(kafkav1alpha1.ResetOffsetConditionOffsetsUpdated).Status)
+			assert.Equal(t, test.wantStarted, status.GetCondition(kafkav1alpha1.ResetOffsetConditionConsumerGroupsStarted).Status)
+			assert.Equal(t, test.wantErr, !status.IsSucceeded())
+			if test.stopGroup2Err != nil {
+				test.wantGroups[1].Message = fmt.Sprintf(test.wantGroups[1].Message, stopCommand2.CommandId)
+			}
+			assert.Equal(t, test.wantGroups, status.GetGroups())
+			assert.Empty(t, status.GetTopic())
+			assert.Empty(t, status.GetGroup())
+			if !test.wantGroup1Calls {
+				mockDataPlaneService.AssertNotCalled(t, "SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopCommand1)
+				mockDataPlaneService.AssertNotCalled(t, "SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand1)
+			}
+			if test.noGroup2Stop {
+				mockDataPlaneService.AssertNotCalled(t, "SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopCommand2)
+			}
+			if test.noGroup2Start {
+				mockDataPlaneService.AssertNotCalled(t, "SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand2)
+			}
+			if test.wantGroup2Start {
+				mockDataPlaneService.AssertCalled(t, "SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand2)
+			}
+		})
+	}
+}
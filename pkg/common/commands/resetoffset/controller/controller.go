@@ -30,6 +30,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/consumergroupsnapshot"
 	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset"
 	resetoffsetreconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/resetoffset"
 	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
@@ -50,6 +51,7 @@ func NewControllerFactory(
 		// Get The Needed Informers
 		podInformer := pod.Get(ctx)
 		resetoffsetInformer := resetoffset.Get(ctx)
+		snapshotInformer := consumergroupsnapshot.Get(ctx)
 
 		// Create The RefMapper Via The Supplied Factory Using Initialized Context
 		refMapper := refMapperFactory.Create(ctx)
@@ -65,9 +67,11 @@ func NewControllerFactory(
 			uid:                           types.UID(uuid.NewString()),
 			podLister:                     podInformer.Lister(),
 			resetoffsetLister:             resetoffsetInformer.Lister(),
+			snapshotLister:                snapshotInformer.Lister(),
 			refMapper:                     refMapper,
 			connectionPool:                connectionPool,
 			asyncCommandNotificationStore: asyncCommandNotificationStore,
+			asyncCommandRouter:            sharedAsyncCommandRouter,
 		}
 
 		// Setup Reconciler To Watch The Kafka ConfigMap For Changes
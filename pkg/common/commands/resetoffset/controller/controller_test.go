@@ -30,7 +30,8 @@ import (
 	logtesting "knative.dev/pkg/logging/testing"
 
 	fakekafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client/fake"
-	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset/fake" // Force Fake Informer Injection
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/consumergroupsnapshot/fake" // Force Fake Informer Injection
+	_ "knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/resetoffset/fake"           // Force Fake Informer Injection
 	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
 	configtesting "knative.dev/eventing-kafka/pkg/common/config/testing"
 	"knative.dev/eventing-kafka/pkg/common/configmaploader"
@@ -25,6 +25,7 @@ import (
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -54,7 +55,8 @@ var (
 )
 
 // reconcileDataPlaneServices updates the Reconciler ConnectionPool Services associated with the specified RefInfo.
-func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, refInfo *refmappers.RefInfo) (map[string]ctrl.Service, error) {
+// The specified resource (ResetOffset, ConsumerGroupPause) is used to key the AsyncCommandResults in the store.
+func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resource metav1.Object, refInfo *refmappers.RefInfo) (map[string]ctrl.Service, error) {
 
 	// Get The Logger From Context
 	logger := logging.FromContext(ctx).Desugar().With(zap.Any("RefInfo", refInfo))
@@ -76,17 +78,17 @@ func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resetOffset
 	logger.Debug("Detected DataPlane Services", zap.Any("Pod IPs", podIPs))
 
 	// Define Service Callback Functions To Manage The Reconciler AsyncCommandNotificationStore
-	resetOffsetNamespacedName := types.NamespacedName{
-		Namespace: resetOffset.GetNamespace(),
-		Name:      resetOffset.GetName(),
+	resourceNamespacedName := types.NamespacedName{
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
 	}
 	newServiceCallbackFn := func(newHost string, service ctrl.Service) {
 		logger.Debug("New Control-Protocol Service Callback", zap.String("Host", newHost))
-		service.MessageHandler(r.asyncCommandNotificationStore.MessageHandler(resetOffsetNamespacedName, newHost))
 	}
 	oldServiceCallbackFn := func(oldHost string) {
 		logger.Debug("Old Control-Protocol Service Callback", zap.String("Host", oldHost))
-		r.asyncCommandNotificationStore.CleanPodNotification(resetOffsetNamespacedName, oldHost)
+		r.asyncCommandNotificationStore.CleanPodNotification(resourceNamespacedName, oldHost)
+		r.asyncCommandRouter.unregister(oldHost)
 	}
 
 	// Reconcile The Services/Connections For Specified Key / Pods
@@ -96,6 +98,13 @@ func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resetOffset
 		return nil, err
 	}
 
+	// Register The Shared AsyncCommandRouter On Any New Services.  The Services Are Shared By Multiple Reconcilers
+	// (ResetOffset, ConsumerGroupPause) And Only Support A Single MessageHandler, So The Router Dispatches The
+	// AsyncCommandResults To The AsyncCommandNotificationStore Of The Reconciler Which Sent The AsyncCommand.
+	for host, service := range services {
+		r.asyncCommandRouter.register(host, service)
+	}
+
 	// Return Success
 	return services, nil
 }
@@ -103,22 +112,24 @@ func (r *Reconciler) reconcileDataPlaneServices(ctx context.Context, resetOffset
 // startConsumerGroups sends Start messages to the specified DataPlane services for a Topic / ConsumerGroup and
 // waits for the async responses.  A multi-error is returned if any ConsumerGroup was not started successfully.
 func (r *Reconciler) startConsumerGroups(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, services map[string]ctrl.Service, refInfo *refmappers.RefInfo) error {
-	return r.sendConsumerGroupAsyncCommands(ctx, resetOffset, services, refInfo, commands.StartConsumerGroupOpCode)
+	return r.sendConsumerGroupAsyncCommands(ctx, resetOffset, services, refInfo, commands.StartConsumerGroupOpCode, 0)
 }
 
 // stopConsumerGroups sends Stop messages to the specified DataPlane services for a Topic / ConsumerGroup and
 // waits for the async responses.  A multi-error is returned if any ConsumerGroup was not stopped successfully.
 func (r *Reconciler) stopConsumerGroups(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, services map[string]ctrl.Service, refInfo *refmappers.RefInfo) error {
-	return r.sendConsumerGroupAsyncCommands(ctx, resetOffset, services, refInfo, commands.StopConsumerGroupOpCode)
+	return r.sendConsumerGroupAsyncCommands(ctx, resetOffset, services, refInfo, commands.StopConsumerGroupOpCode, asyncCommandLockTimeout)
 }
 
 // sendConsumerGroupAsyncCommands sends ConsumerGroupAsyncCommands to the specified control-protocol Services in
 // parallel and blocks waiting for all the AsyncCommandResults.  Any errors are returned in a single multi-error.
+// The lockTimeout is only applicable to Stop commands, which lock the ConsumerGroups for that duration.
 func (r *Reconciler) sendConsumerGroupAsyncCommands(ctx context.Context,
-	resetOffset *kafkav1alpha1.ResetOffset,
+	resource metav1.Object,
 	services map[string]ctrl.Service,
 	refInfo *refmappers.RefInfo,
-	opCode ctrl.OpCode) error {
+	opCode ctrl.OpCode,
+	lockTimeout time.Duration) error {
 
 	// Send To All The ConsumerGroups In Parallel
 	waitGroup := &sync.WaitGroup{}
@@ -127,7 +138,7 @@ func (r *Reconciler) sendConsumerGroupAsyncCommands(ctx context.Context,
 	for podIP, service := range services {
 		go func(podIP string, service ctrl.Service) {
 			defer waitGroup.Done()
-			err := r.sendConsumerGroupAsyncCommand(ctx, resetOffset, podIP, service, refInfo, opCode)
+			err := r.sendConsumerGroupAsyncCommand(ctx, resource, podIP, service, refInfo, opCode, lockTimeout)
 			if err != nil {
 				errChan <- err
 			}
@@ -151,30 +162,31 @@ func (r *Reconciler) sendConsumerGroupAsyncCommands(ctx context.Context,
 // sendConsumerGroupAsyncCommand sends a ConsumerGroupAsyncCommand with the specified opCode to the
 // specified pods and blocks waiting for AsyncCommandResult response which is then returned.
 func (r *Reconciler) sendConsumerGroupAsyncCommand(ctx context.Context,
-	resetOffset *kafkav1alpha1.ResetOffset,
+	resource metav1.Object,
 	podIP string,
 	service ctrl.Service,
 	refInfo *refmappers.RefInfo,
-	opCode ctrl.OpCode) error {
+	opCode ctrl.OpCode,
+	lockTimeout time.Duration) error {
 
 	// Get The Logger From Context
 	logger := logging.FromContext(ctx).Desugar().With(zap.String("PodIP", podIP), zap.Int("OpCode", int(opCode)))
 
-	// Generate A CommandID For The ResetOffset
-	commandId, err := GenerateCommandId(resetOffset, podIP, opCode)
+	// Generate A CommandID For The Resource
+	commandId, err := GenerateCommandId(resource, podIP, refInfo.GroupId, opCode)
 	if err != nil {
-		logger.Error("Failed to generate Command ID for ResetOffset", zap.Error(err))
-		return fmt.Errorf("failed to generate Command ID for ResetOffset: %v", err)
+		logger.Error("Failed to generate Command ID", zap.Error(err))
+		return fmt.Errorf("failed to generate Command ID: %v", err)
 	}
 
-	// Generate A Lock Token For The Current Reconciler & ResetOffset
-	lockToken := GenerateLockToken(r.uid, resetOffset.UID)
+	// Generate A Lock Token For The Current Reconciler & Resource
+	lockToken := GenerateLockToken(r.uid, resource.GetUID())
 
 	// Create The OpCode Specific CommandLock (Lock Before Stop, Unlock After Start)
 	var commandLock *commands.CommandLock
 	switch opCode {
 	case commands.StopConsumerGroupOpCode:
-		commandLock = commands.NewCommandLock(lockToken, asyncCommandLockTimeout, true, false)
+		commandLock = commands.NewCommandLock(lockToken, lockTimeout, true, false)
 	case commands.StartConsumerGroupOpCode:
 		commandLock = commands.NewCommandLock(lockToken, 0, false, true)
 	default:
@@ -185,6 +197,14 @@ func (r *Reconciler) sendConsumerGroupAsyncCommand(ctx context.Context,
 	// Create The ConsumerGroupAsyncCommand With CommandLock
 	consumerGroupAsyncCommand := commands.NewConsumerGroupAsyncCommand(commandId, refInfo.TopicName, refInfo.GroupId, commandLock)
 
+	// Route The AsyncCommandResult To The Reconciler AsyncCommandNotificationStore Until It Has Been Received
+	removeRoute := r.asyncCommandRouter.addRoute(consumerGroupAsyncCommand, asyncCommandRoute{
+		store:   r.asyncCommandNotificationStore,
+		srcName: types.NamespacedName{Namespace: resource.GetNamespace(), Name: resource.GetName()},
+		pod:     podIP,
+	})
+	defer removeRoute()
+
 	// Send The ConsumerGroupAsyncCommand & Wait For Acknowledgement
 	err = service.SendAndWaitForAck(opCode, consumerGroupAsyncCommand)
 	if err != nil {
@@ -193,24 +213,24 @@ func (r *Reconciler) sendConsumerGroupAsyncCommand(ctx context.Context,
 	}
 
 	// Wait For The AsyncCommand Result & Return Results
-	return r.waitForAsyncCommandResult(resetOffset, podIP, consumerGroupAsyncCommand)
+	return r.waitForAsyncCommandResult(resource, podIP, consumerGroupAsyncCommand)
 }
 
 // waitForAsyncCommandResult polls the Reconciler AsyncCommandNotificationStore waiting for the
 // AsyncCommandResult corresponding to the specified AsyncCommand.  The ConsumerGroupAsyncCommands
 // are inherently asynchronous so that they can be used in other scenarios (Pause/Resume), but the
 // ResetOffset implementation treats them as Synchronous to facilitate single-pass reconciliation.
-func (r *Reconciler) waitForAsyncCommandResult(resetOffset *kafkav1alpha1.ResetOffset, podIP string, asyncCommand ctrlmessage.AsyncCommand) error {
+func (r *Reconciler) waitForAsyncCommandResult(resource metav1.Object, podIP string, asyncCommand ctrlmessage.AsyncCommand) error {
 
-	// Create A NamespacedName For The ResetOffset
-	resetOffsetNamespacedName := types.NamespacedName{
-		Namespace: resetOffset.GetNamespace(),
-		Name:      resetOffset.GetName(),
+	// Create A NamespacedName For The Resource
+	resourceNamespacedName := types.NamespacedName{
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
 	}
 
 	// Poll The AsyncCommandNotificationStore For AsyncCommandResult
 	err := wait.Poll(asyncCommandResultPollDuration, asyncCommandResultTimeoutDuration, func() (done bool, err error) {
-		asyncCommandResult := r.asyncCommandNotificationStore.GetCommandResult(resetOffsetNamespacedName, podIP, asyncCommand)
+		asyncCommandResult := r.asyncCommandNotificationStore.GetCommandResult(resourceNamespacedName, podIP, asyncCommand)
 		if asyncCommandResult != nil {
 			if asyncCommandResult.IsFailed() {
 				return true, fmt.Errorf("AsyncCommand ID '%x' resulted in error: %s", asyncCommand.SerializedId(), asyncCommandResult.Error)
@@ -54,6 +54,8 @@ func TestReconciler_ReconcileDataPlaneServices(t *testing.T) {
 	}
 	mockDataPlaneService1 := &controlprotocoltesting.MockService{}
 	mockDataPlaneService2 := &controlprotocoltesting.MockService{}
+	mockDataPlaneService1.On("MessageHandler", mock.Anything).Return()
+	mockDataPlaneService2.On("MessageHandler", mock.Anything).Return()
 	testErr := fmt.Errorf("test-error")
 
 	// Create A Context With Test Logger
@@ -126,6 +128,7 @@ func TestReconciler_ReconcileDataPlaneServices(t *testing.T) {
 			reconciler := &Reconciler{
 				podLister:                     mockPodLister,
 				asyncCommandNotificationStore: mockAsyncCommandNotificationStore,
+				asyncCommandRouter:            newAsyncCommandRouter(),
 				connectionPool:                mockConnectionPool,
 			}
 
@@ -142,6 +145,9 @@ func TestReconciler_ReconcileDataPlaneServices(t *testing.T) {
 	}
 }
 
+// noopMessageHandler is a control-protocol MessageHandler returned by the mock AsyncCommandNotificationStore.
+var noopMessageHandler = ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {})
+
 func TestReconciler_StartConsumerGroups(t *testing.T) {
 	performStartStopConsumerGroupAsyncCommandsTest(t, commands.StartConsumerGroupOpCode)
 }
@@ -195,9 +201,9 @@ func performStartStopConsumerGroupAsyncCommandsTest(t *testing.T, opCode ctrl.Op
 			refInfo := refmapperstesting.NewRefInfo()
 
 			// Determine The Expected CommandIDs
-			commandId1, err := GenerateCommandId(resetOffset, podIp1, opCode)
+			commandId1, err := GenerateCommandId(resetOffset, podIp1, refInfo.GroupId, opCode)
 			assert.Nil(t, err)
-			commandId2, err := GenerateCommandId(resetOffset, podIp2, opCode)
+			commandId2, err := GenerateCommandId(resetOffset, podIp2, refInfo.GroupId, opCode)
 			assert.Nil(t, err)
 
 			// Determine The Expected CommandLock Based On OpCode
@@ -234,6 +240,7 @@ func performStartStopConsumerGroupAsyncCommandsTest(t *testing.T, opCode ctrl.Op
 			reconciler := &Reconciler{
 				uid:                           reconcilerUID,
 				asyncCommandNotificationStore: mockAsyncCommandNotificationStore,
+				asyncCommandRouter:            newAsyncCommandRouter(),
 			}
 
 			// Perform The Test Based On OpCode
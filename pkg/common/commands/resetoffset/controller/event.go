@@ -19,11 +19,15 @@ package controller
 // CoreV1EventType "Enum" Type
 type CoreV1EventType int
 
-// CoreV1 EventType "Enum" Values For ResetOffset
+// CoreV1 EventType "Enum" Values For ResetOffset, ConsumerGroupSnapshot & ConsumerGroupPause
 const (
 	ResetOffsetReconciled CoreV1EventType = iota
 	ResetOffsetFinalized
 	ResetOffsetSkipped
+	ConsumerGroupSnapshotReconciled
+	ConsumerGroupSnapshotSkipped
+	ConsumerGroupPauseReconciled
+	ConsumerGroupPauseFinalized
 )
 
 // CoreV1 EventType String Value
@@ -40,6 +44,14 @@ func (et CoreV1EventType) String() string {
 		eventTypeString = "ResetOffsetFinalized"
 	case ResetOffsetSkipped:
 		eventTypeString = "ResetOffsetSkipped"
+	case ConsumerGroupSnapshotReconciled:
+		eventTypeString = "ConsumerGroupSnapshotReconciled"
+	case ConsumerGroupSnapshotSkipped:
+		eventTypeString = "ConsumerGroupSnapshotSkipped"
+	case ConsumerGroupPauseReconciled:
+		eventTypeString = "ConsumerGroupPauseReconciled"
+	case ConsumerGroupPauseFinalized:
+		eventTypeString = "ConsumerGroupPauseFinalized"
 	}
 
 	// Return The EventType String Value
@@ -33,6 +33,10 @@ func TestEventTypes(t *testing.T) {
 		{name: "ResetOffsetReconciled", eventType: ResetOffsetReconciled, expect: "ResetOffsetReconciled"},
 		{name: "ResetOffsetFinalized", eventType: ResetOffsetFinalized, expect: "ResetOffsetFinalized"},
 		{name: "ResetOffsetSkipped", eventType: ResetOffsetSkipped, expect: "ResetOffsetSkipped"},
+		{name: "ConsumerGroupSnapshotReconciled", eventType: ConsumerGroupSnapshotReconciled, expect: "ConsumerGroupSnapshotReconciled"},
+		{name: "ConsumerGroupSnapshotSkipped", eventType: ConsumerGroupSnapshotSkipped, expect: "ConsumerGroupSnapshotSkipped"},
+		{name: "ConsumerGroupPauseReconciled", eventType: ConsumerGroupPauseReconciled, expect: "ConsumerGroupPauseReconciled"},
+		{name: "ConsumerGroupPauseFinalized", eventType: ConsumerGroupPauseFinalized, expect: "ConsumerGroupPauseFinalized"},
 	}
 
 	for _, test := range tests {
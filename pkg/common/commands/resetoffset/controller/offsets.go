@@ -47,6 +47,11 @@ type SaramaNewOffsetManagerFromClientFnType func(group string, client sarama.Cli
 // function used when reconciling offsets which facilitates stubbing in unit tests.
 var SaramaNewOffsetManagerFromClientFn SaramaNewOffsetManagerFromClientFnType = sarama.NewOffsetManagerFromClient
 
+// offsetResolverFn defines the signature of the functions which determine the new Offset of a single
+// Topic Partition.  The boolean return value indicates whether the Partition should be repositioned
+// at all, allowing for Partitions to be left at their current Offset.
+type offsetResolverFn func(saramaClient sarama.Client, topic string, partition int32) (int64, bool, error)
+
 // reconcileOffsets updates the Offsets of all Partitions for the specified
 // Topic / ConsumerGroup to the Offset value corresponding to the specified
 // offsetTime (millis since epoch) and return OffsetMappings of the old/new
@@ -60,6 +65,93 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 		zap.String("Group", refInfo.GroupId),
 		zap.Int64("Time", offsetTime))
 
+	// Resolve Each Partition's New Offset From The Specified Time
+	offsetResolver := func(saramaClient sarama.Client, topic string, partition int32) (int64, bool, error) {
+		newOffset, err := saramaClient.GetOffset(topic, partition, offsetTime)
+		if err != nil {
+			logger.Error("Failed to get Partition Offset for Time", zap.Int32("Partition", partition), zap.Int64("Time", offsetTime), zap.Error(err))
+			return 0, false, err
+		}
+		return newOffset, true, nil
+	}
+
+	// Update & Commit The Offsets Of All Partitions
+	return r.commitOffsets(logger, refInfo, offsetResolver, formatOffsetMetaData(offsetTime))
+}
+
+// reconcileSnapshotOffsets updates the Offsets of all Partitions for the specified
+// Topic / ConsumerGroup to the Offset values captured in the specified ConsumerGroupOffsets
+// of the named ConsumerGroupSnapshot and return OffsetMappings of the old/new state.
+// Partitions which are not present in the snapshot, or which had no committed Offset
+// at the time of the snapshot, are left unchanged.  An error will be returned and the
+// Offsets will not be committed if any problems occur.
+func (r *Reconciler) reconcileSnapshotOffsets(ctx context.Context, refInfo *refmappers.RefInfo, snapshotName string, groupOffsets *kafkav1alpha1.ConsumerGroupOffsets) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Get The Logger From The Context & Enhance The With Parameters
+	logger := logging.FromContext(ctx).Desugar().With(
+		zap.String("Topic", refInfo.TopicName),
+		zap.String("Group", refInfo.GroupId),
+		zap.String("Snapshot", snapshotName))
+
+	// Update & Commit The Offsets Of All Partitions To The Snapshot's Values
+	return r.restoreOffsets(logger, refInfo, groupOffsets.Partitions, formatSnapshotOffsetMetaData(snapshotName))
+}
+
+// reconcileUndoOffsets updates the Offsets of all Partitions for the specified Topic /
+// ConsumerGroup back to the OldOffset values recorded in the specified OffsetMappings of
+// the named ResetOffset and return OffsetMappings of the old/new state.  Partitions which
+// are not present in the OffsetMappings, or which had no committed Offset prior to the
+// ResetOffset, are left unchanged.  An error will be returned and the Offsets will not be
+// committed if any problems occur.
+func (r *Reconciler) reconcileUndoOffsets(ctx context.Context, refInfo *refmappers.RefInfo, resetOffsetName string, offsetMappings []kafkav1alpha1.OffsetMapping) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Get The Logger From The Context & Enhance The With Parameters
+	logger := logging.FromContext(ctx).Desugar().With(
+		zap.String("Topic", refInfo.TopicName),
+		zap.String("Group", refInfo.GroupId),
+		zap.String("Undo", resetOffsetName))
+
+	// Convert The Recorded OffsetMappings Into The Old PartitionOffsets
+	partitionOffsets := make([]kafkav1alpha1.PartitionOffset, len(offsetMappings))
+	for index, offsetMapping := range offsetMappings {
+		partitionOffsets[index] = kafkav1alpha1.PartitionOffset{Partition: offsetMapping.Partition, Offset: offsetMapping.OldOffset}
+	}
+
+	// Update & Commit The Offsets Of All Partitions To The Old Values
+	return r.restoreOffsets(logger, refInfo, partitionOffsets, formatUndoOffsetMetaData(resetOffsetName))
+}
+
+// restoreOffsets updates the Offsets of all Partitions for the specified Topic / ConsumerGroup
+// to the explicit values in the specified PartitionOffsets.  Partitions which are not present,
+// or which have a negative (uncommitted) Offset, are left unchanged.
+func (r *Reconciler) restoreOffsets(logger *zap.Logger, refInfo *refmappers.RefInfo, partitionOffsets []kafkav1alpha1.PartitionOffset, offsetMetaData string) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Index The Explicit Partition Offsets
+	explicitOffsets := make(map[int32]int64, len(partitionOffsets))
+	for _, partitionOffset := range partitionOffsets {
+		explicitOffsets[partitionOffset.Partition] = partitionOffset.Offset
+	}
+
+	// Resolve Each Partition's New Offset From The Explicit Values
+	offsetResolver := func(_ sarama.Client, _ string, partition int32) (int64, bool, error) {
+		explicitOffset, ok := explicitOffsets[partition]
+		if !ok || explicitOffset < 0 {
+			logger.Info("No committed Offset to restore - leaving Partition unchanged", zap.Int32("Partition", partition))
+			return 0, false, nil
+		}
+		return explicitOffset, true, nil
+	}
+
+	// Update & Commit The Offsets Of All Partitions
+	return r.commitOffsets(logger, refInfo, offsetResolver, offsetMetaData)
+}
+
+// commitOffsets updates the Offsets of all Partitions for the specified Topic / ConsumerGroup
+// to the values determined by the specified offsetResolver and return OffsetMappings of the
+// old/new state.  An error will be returned and the Offsets will not be committed if any
+// problems occur.
+func (r *Reconciler) commitOffsets(logger *zap.Logger, refInfo *refmappers.RefInfo, offsetResolver offsetResolverFn, offsetMetaData string) ([]kafkav1alpha1.OffsetMapping, error) {
+
 	// Initialize A New Sarama Client
 	//
 	// ResetOffset is an infrequently used feature so there is no need for
@@ -97,8 +189,8 @@ func (r *Reconciler) reconcileOffsets(ctx context.Context, refInfo *refmappers.R
 		return nil, err
 	}
 
-	// Update All Topic Partitions To The Specified Offset Time
-	offsetMappings, err := updateOffsets(logger, saramaClient, offsetManager, partitionOffsetManagers, refInfo.TopicName, partitions, offsetTime)
+	// Update All Topic Partitions To The Resolved Offsets
+	offsetMappings, err := updateOffsets(logger, saramaClient, offsetManager, partitionOffsetManagers, refInfo.TopicName, partitions, offsetResolver, offsetMetaData)
 	if err != nil {
 		logger.Error("Failed to update Offsets for Topic Partitions", zap.Error(err))
 		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
@@ -128,7 +220,8 @@ func updateOffsets(logger *zap.Logger,
 	partitionOffsetManagers PartitionOffsetManagers,
 	topicName string,
 	partitions []int32,
-	offsetTime int64) ([]kafkav1alpha1.OffsetMapping, error) {
+	offsetResolver offsetResolverFn,
+	offsetMetaData string) ([]kafkav1alpha1.OffsetMapping, error) {
 
 	// The OffsetMappings To Be Returned For ResetOffset Status
 	offsetMappings := make([]kafkav1alpha1.OffsetMapping, len(partitions))
@@ -146,8 +239,8 @@ func updateOffsets(logger *zap.Logger,
 			return nil, fmt.Errorf("missing PartitionOffsetManager - unable to update Offset")
 		}
 
-		// Update The Individual Offset To Specified Time
-		offsetMapping, updateErr := updateOffset(logger, saramaClient, partitionOffsetManager, topicName, partition, offsetTime)
+		// Update The Individual Offset To The Resolved Value
+		offsetMapping, updateErr := updateOffset(saramaClient, partitionOffsetManager, topicName, partition, offsetResolver, offsetMetaData)
 		if updateErr != nil {
 			logger.Error("Failed to update Offset - skipping Commit", zap.Error(updateErr))
 			return nil, updateErr
@@ -166,25 +259,28 @@ func updateOffsets(logger *zap.Logger,
 // updateOffset calculates and performs an update of a single Partition's Offset
 // and returns an OffsetMapping representing the old/new state.  No Offset changes
 // are committed to allow for atomic commit/fail decision for all Offsets.
-func updateOffset(logger *zap.Logger,
-	saramaClient sarama.Client,
+func updateOffset(saramaClient sarama.Client,
 	partitionOffsetManager sarama.PartitionOffsetManager,
 	topic string,
 	partition int32,
-	offsetTime int64) (*kafkav1alpha1.OffsetMapping, error) {
+	offsetResolver offsetResolverFn,
+	offsetMetaData string) (*kafkav1alpha1.OffsetMapping, error) {
 
-	// Get The New Offset Of Partition For Specified Time
-	newOffset, err := saramaClient.GetOffset(topic, partition, offsetTime)
+	// Resolve The New Offset Of Partition
+	newOffset, reposition, err := offsetResolver(saramaClient, topic, partition)
 	if err != nil {
-		logger.Error("Failed to get Partition Offset for Time", zap.Int64("Time", offsetTime), zap.Error(err))
 		return nil, err
 	}
 
 	// Get The Current Offset Of Partition (Accuracy Depends On ConsumerGroup Having Been Stopped)
 	currentOffset, _ := partitionOffsetManager.NextOffset()
 
+	// Leave The Partition At The Current Offset If So Resolved
+	if !reposition {
+		newOffset = currentOffset
+	}
+
 	// Update The Partition's Offset Forward/Back As Needed
-	offsetMetaData := formatOffsetMetaData(offsetTime)
 	if newOffset > currentOffset {
 		partitionOffsetManager.MarkOffset(newOffset, offsetMetaData) // No Errors Returned - On PartitionOffsetManager.Errors() Channel Instead
 	} else if newOffset < currentOffset {
@@ -202,11 +298,80 @@ func updateOffset(logger *zap.Logger,
 	return offsetMapping, nil
 }
 
+// captureOffsets reads the committed Offsets of all Partitions for the specified
+// Topic / ConsumerGroup without modifying them, and returns them as PartitionOffsets.
+// Partitions without a committed Offset are reported with a negative Offset.
+func captureOffsets(ctx context.Context, kafkaBrokers []string, saramaConfig *sarama.Config, refInfo *refmappers.RefInfo) ([]kafkav1alpha1.PartitionOffset, error) {
+
+	// Get The Logger From The Context & Enhance The With Parameters
+	logger := logging.FromContext(ctx).Desugar().With(
+		zap.String("Topic", refInfo.TopicName),
+		zap.String("Group", refInfo.GroupId))
+
+	// Initialize A New Sarama Client (No Reuse - See commitOffsets() For Rationale)
+	saramaClient, err := SaramaNewClientFn(kafkaBrokers, saramaConfig)
+	defer safeCloseSaramaClient(logger, saramaClient)
+	if saramaClient == nil || err != nil {
+		logger.Error("Failed to create a new Sarama Client", zap.Error(err))
+		return nil, err
+	}
+
+	// Get The Partitions Of The Specified Kafka Topic
+	partitions, err := saramaClient.Partitions(refInfo.TopicName)
+	if err != nil {
+		logger.Error("Failed to determine Partitions for Topic", zap.Error(err))
+		return nil, err
+	}
+	logger.Debug("Found Topic Partitions", zap.Any("Partitions", partitions))
+
+	// Create An OffsetManager For The Specified ConsumerGroup
+	offsetManager, err := SaramaNewOffsetManagerFromClientFn(refInfo.GroupId, saramaClient)
+	if offsetManager == nil || err != nil {
+		logger.Error("Failed to create OffsetManager for ConsumerGroup", zap.Error(err))
+		return nil, err
+	}
+
+	// Create The Required PartitionOffsetManagers For The Specified Topic / Partitions
+	partitionOffsetManagers, err := createPartitionOffsetManagers(offsetManager, refInfo.TopicName, partitions)
+	if err != nil {
+		logger.Error("Failed to create PartitionOffsetManagers for Topic Partitions", zap.Error(err))
+		_ = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
+		return nil, err
+	}
+
+	// Read The Committed Offset Of Each Partition (Nothing Is Marked So Nothing Will Be Committed)
+	partitionOffsets := make([]kafkav1alpha1.PartitionOffset, len(partitions))
+	for index, partition := range partitions {
+		offset, _ := partitionOffsetManagers[partition].NextOffset()
+		partitionOffsets[index] = kafkav1alpha1.PartitionOffset{Partition: partition, Offset: offset}
+	}
+
+	// Close The Sarama Managers And Get Any Accumulated Errors
+	err = closeManagersAndDrainErrors(logger, offsetManager, partitionOffsetManagers)
+	if err != nil {
+		logger.Error("PartitionOffsetManager Errors encountered", zap.Error(err))
+		return nil, err
+	}
+
+	// Return Success
+	return partitionOffsets, nil
+}
+
 // formatOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified time.
 func formatOffsetMetaData(time int64) string {
 	return fmt.Sprintf("resetoffset.%d", time)
 }
 
+// formatSnapshotOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified ConsumerGroupSnapshot name.
+func formatSnapshotOffsetMetaData(snapshotName string) string {
+	return fmt.Sprintf("resetoffset.snapshot.%s", snapshotName)
+}
+
+// formatUndoOffsetMetaData returns a "metadata" string, suitable for use with MarkOffset/ResetOffset, for the specified undone ResetOffset name.
+func formatUndoOffsetMetaData(resetOffsetName string) string {
+	return fmt.Sprintf("resetoffset.undo.%s", resetOffsetName)
+}
+
 // safeCloseSaramaClient will attempt to close the specified Sarama Client
 func safeCloseSaramaClient(logger *zap.Logger, client sarama.Client) {
 	if client != nil && !client.Closed() {
@@ -346,6 +346,313 @@ func TestReconciler_ReconcileOffsets(t *testing.T) {
 	}
 }
 
+// Test The Reconciler's reconcileSnapshotOffsets() Functionality
+func TestReconciler_ReconcileSnapshotOffsets(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	topicName := controllertesting.TopicName
+	groupId := controllertesting.GroupId
+	snapshotName := "test-snapshot"
+	metadata := formatSnapshotOffsetMetaData(snapshotName)
+
+	partition1 := int32(0)
+	oldOffset1 := int64(100)
+	snapshotOffset1 := int64(50)
+
+	partition2 := int32(1)
+	oldOffset2 := int64(200)
+	snapshotOffset2 := int64(250)
+
+	partition3 := int32(2)
+	oldOffset3 := int64(300)
+
+	partition4 := int32(3)
+	oldOffset4 := int64(400)
+
+	// Create The Snapshot's ConsumerGroupOffsets (Partition3 Not Committed & Partition4 Missing)
+	groupOffsets := &kafkav1alpha1.ConsumerGroupOffsets{
+		Topic: topicName,
+		Group: groupId,
+		Partitions: []kafkav1alpha1.PartitionOffset{
+			{Partition: partition1, Offset: snapshotOffset1},
+			{Partition: partition2, Offset: snapshotOffset2},
+			{Partition: partition3, Offset: sarama.OffsetNewest},
+		},
+	}
+
+	// Create The Mock Sarama Client / OffsetManager / PartitionOffsetManagers
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2, partition3, partition4}, nil),
+		controllertesting.WithClientMockClosed(false),
+		controllertesting.WithClientMockClose(nil))
+	offsetManager := controllertesting.NewMockOffsetManager(
+		controllertesting.WithOffsetManagerMockCommit(),
+		controllertesting.WithOffsetManagerMockClose(nil))
+	partitionOffsetManagers := map[int32]*controllertesting.MockPartitionOffsetManager{
+		partition1: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset1, ""),
+			controllertesting.WithPartitionOffsetManagerMockResetOffset(snapshotOffset1, metadata),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+		partition2: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset2, ""),
+			controllertesting.WithPartitionOffsetManagerMockMarkOffset(snapshotOffset2, metadata),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+		partition3: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset3, ""),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+		partition4: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(oldOffset4, ""),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+	}
+	for partition, partitionOffsetManager := range partitionOffsetManagers {
+		controllertesting.WithOffsetManagerMockManagePartition(topicName, partition, partitionOffsetManager, nil)(offsetManager)
+	}
+
+	// Stub The Sarama Functions To Return The Mocks
+	stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+	defer restoreSaramaNewClientFn()
+	stubSaramaNewOffsetManagerFromClientFn(t, groupId, client, offsetManager, nil)
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Create A Context With Test Logger
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+	// Create A Reconciler To Test
+	reconciler := &Reconciler{
+		kafkaBrokers: kafkaBrokers,
+		saramaConfig: saramaConfig,
+	}
+
+	// Perform The Test
+	offsetMappings, err := reconciler.reconcileSnapshotOffsets(ctx, &refmappers.RefInfo{TopicName: topicName, GroupId: groupId}, snapshotName, groupOffsets)
+
+	// Verify The Results (Uncommitted & Missing Partitions Unchanged)
+	assert.Nil(t, err)
+	assert.Equal(t, []kafkav1alpha1.OffsetMapping{
+		{Partition: partition1, OldOffset: oldOffset1, NewOffset: snapshotOffset1},
+		{Partition: partition2, OldOffset: oldOffset2, NewOffset: snapshotOffset2},
+		{Partition: partition3, OldOffset: oldOffset3, NewOffset: oldOffset3},
+		{Partition: partition4, OldOffset: oldOffset4, NewOffset: oldOffset4},
+	}, offsetMappings)
+	client.AssertExpectations(t)
+	offsetManager.AssertExpectations(t)
+	for _, partitionOffsetManager := range partitionOffsetManagers {
+		partitionOffsetManager.AssertExpectations(t)
+	}
+}
+
+// Test The Reconciler's reconcileUndoOffsets() Functionality
+func TestReconciler_ReconcileUndoOffsets(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	topicName := controllertesting.TopicName
+	groupId := controllertesting.GroupId
+	resetOffsetName := controllertesting.UndoResetOffsetName
+	metadata := formatUndoOffsetMetaData(resetOffsetName)
+
+	partition1 := int32(0)
+	currentOffset1 := int64(105)
+	undoOffset1 := int64(500)
+
+	partition2 := int32(1)
+	currentOffset2 := int64(200)
+
+	// Create The Recorded OffsetMappings (Partition2 Not Committed Prior To ResetOffset)
+	offsetMappings := []kafkav1alpha1.OffsetMapping{
+		{Partition: partition1, OldOffset: undoOffset1, NewOffset: 100},
+		{Partition: partition2, OldOffset: sarama.OffsetNewest, NewOffset: 200},
+	}
+
+	// Create The Mock Sarama Client / OffsetManager / PartitionOffsetManagers
+	client := controllertesting.NewMockClient(
+		controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+		controllertesting.WithClientMockClosed(false),
+		controllertesting.WithClientMockClose(nil))
+	offsetManager := controllertesting.NewMockOffsetManager(
+		controllertesting.WithOffsetManagerMockCommit(),
+		controllertesting.WithOffsetManagerMockClose(nil))
+	partitionOffsetManagers := map[int32]*controllertesting.MockPartitionOffsetManager{
+		partition1: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(currentOffset1, ""),
+			controllertesting.WithPartitionOffsetManagerMockMarkOffset(undoOffset1, metadata),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+		partition2: controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(currentOffset2, ""),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+	}
+	for partition, partitionOffsetManager := range partitionOffsetManagers {
+		controllertesting.WithOffsetManagerMockManagePartition(topicName, partition, partitionOffsetManager, nil)(offsetManager)
+	}
+
+	// Stub The Sarama Functions To Return The Mocks
+	stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+	defer restoreSaramaNewClientFn()
+	stubSaramaNewOffsetManagerFromClientFn(t, groupId, client, offsetManager, nil)
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Create A Context With Test Logger
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+	// Create A Reconciler To Test
+	reconciler := &Reconciler{
+		kafkaBrokers: kafkaBrokers,
+		saramaConfig: saramaConfig,
+	}
+
+	// Perform The Test
+	resultOffsetMappings, err := reconciler.reconcileUndoOffsets(ctx, &refmappers.RefInfo{TopicName: topicName, GroupId: groupId}, resetOffsetName, offsetMappings)
+
+	// Verify The Results (Previously Uncommitted Partition Unchanged)
+	assert.Nil(t, err)
+	assert.Equal(t, []kafkav1alpha1.OffsetMapping{
+		{Partition: partition1, OldOffset: currentOffset1, NewOffset: undoOffset1},
+		{Partition: partition2, OldOffset: currentOffset2, NewOffset: currentOffset2},
+	}, resultOffsetMappings)
+	client.AssertExpectations(t)
+	offsetManager.AssertExpectations(t)
+	for _, partitionOffsetManager := range partitionOffsetManagers {
+		partitionOffsetManager.AssertExpectations(t)
+	}
+}
+
+// Test The captureOffsets() Functionality
+func TestCaptureOffsets(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	topicName := controllertesting.TopicName
+	groupId := controllertesting.GroupId
+	testErr := fmt.Errorf("test-error")
+
+	partition1 := int32(0)
+	offset1 := int64(100)
+
+	partition2 := int32(1)
+	offset2 := sarama.OffsetNewest
+
+	// Define The Test Cases
+	tests := []struct {
+		name                     string
+		client                   *controllertesting.MockClient
+		offsetManager            *controllertesting.MockOffsetManager
+		partitionOffsetManagers  map[int32]*controllertesting.MockPartitionOffsetManager
+		expectedPartitionOffsets []kafkav1alpha1.PartitionOffset
+		expectedErr              error
+	}{
+		{
+			name: "Success",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1, partition2}, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(offset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+				partition2: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(offset2, ""),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedPartitionOffsets: []kafkav1alpha1.PartitionOffset{
+				{Partition: partition1, Offset: offset1},
+				{Partition: partition2, Offset: offset2},
+			},
+		},
+		{
+			name:        "SaramaNewClientFn() Error",
+			expectedErr: testErr,
+		},
+		{
+			name: "Client.Partitions() Error",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, nil, testErr),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil)),
+			expectedErr: testErr,
+		},
+		{
+			name: "PartitionsOffsetManager.Errors()",
+			client: controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition1}, nil),
+				controllertesting.WithClientMockClosed(true)),
+			offsetManager: controllertesting.NewMockOffsetManager(
+				controllertesting.WithOffsetManagerMockClose(nil)),
+			partitionOffsetManagers: map[int32]*controllertesting.MockPartitionOffsetManager{
+				partition1: controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(offset1, ""),
+					controllertesting.WithPartitionOffsetManagerMockErrors(&sarama.ConsumerError{
+						Topic:     topicName,
+						Partition: partition1,
+						Err:       testErr,
+					}),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose()),
+			},
+			expectedErr: testErr,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Stub The Sarama NewClient() Implementation To Return Mock Sarama Client
+			if test.client == nil {
+				stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, nil, testErr)
+			} else {
+				stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, test.client, nil)
+			}
+			defer restoreSaramaNewClientFn()
+
+			// Stub The Sarama NewOffsetManagerFromClient() Implementation To Return Mock Sarama OffsetManager
+			if test.offsetManager == nil {
+				stubSaramaNewOffsetManagerFromClientFn(t, groupId, test.client, nil, testErr)
+			} else {
+				stubSaramaNewOffsetManagerFromClientFn(t, groupId, test.client, test.offsetManager, nil)
+			}
+			defer restoreSaramaNewOffsetManagerFromClientFn()
+
+			// Configure The Test OffsetManager With Partitions
+			for partition, partitionOffsetManager := range test.partitionOffsetManagers {
+				controllertesting.WithOffsetManagerMockManagePartition(topicName, partition, partitionOffsetManager, nil)(test.offsetManager)
+			}
+
+			// Perform The Test
+			partitionOffsets, err := captureOffsets(ctx, kafkaBrokers, saramaConfig, &refmappers.RefInfo{TopicName: topicName, GroupId: groupId})
+
+			// Verify The Results
+			assert.Equal(t, test.expectedErr, err)
+			assert.Equal(t, test.expectedPartitionOffsets, partitionOffsets)
+			if test.client != nil {
+				test.client.AssertExpectations(t)
+			}
+			if test.offsetManager != nil {
+				test.offsetManager.AssertExpectations(t)
+			}
+			for _, partitionOffsetManager := range test.partitionOffsetManagers {
+				partitionOffsetManager.AssertExpectations(t)
+			}
+		})
+	}
+}
+
 //
 // Stubbing Utilities
 //
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/client/injection/informers/kafka/v1alpha1/consumergrouppause"
+	pausereconciler "knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/consumergrouppause"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// NewPauseControllerFactory returns a ControllerConstructor function capable of creating a "typed" ConsumerGroupPause Controller.
+// The ConnectionPool should be the same instance provided to the ResetOffset Controller as the DataPlane (Dispatcher)
+// Replicas only accept a single control-protocol connection.
+func NewPauseControllerFactory(
+	refMapperFactory refmappers.ResetOffsetRefMapperFactory,
+	connectionPool ctrlreconciler.ControlPlaneConnectionPool) injection.ControllerConstructor {
+
+	// Return The New ConsumerGroupPause ControllerConstructor Function
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+
+		// Get A Logger
+		logger := logging.FromContext(ctx)
+
+		// Get The Needed Informers
+		podInformer := pod.Get(ctx)
+		pauseInformer := consumergrouppause.Get(ctx)
+
+		// Create A Control-Protocol AsyncCommandNotificationStore - No-Op Enqueue Function As Results Are Polled
+		enqueueFunc := func(key types.NamespacedName) {
+			logger.Debug("Control-Protocol Enqueue Function", zap.String("Key", key.String()))
+		}
+		asyncCommandNotificationStore := ctrlreconciler.NewAsyncCommandNotificationStore(enqueueFunc)
+
+		// Create A ConsumerGroupPause Reconciler With RefMapper From The Supplied Factory
+		reconciler := &PauseReconciler{
+			dataPlane: &Reconciler{
+				uid:                           pauseReconcilerUID,
+				podLister:                     podInformer.Lister(),
+				connectionPool:                connectionPool,
+				asyncCommandNotificationStore: asyncCommandNotificationStore,
+				asyncCommandRouter:            sharedAsyncCommandRouter,
+			},
+			refMapper: refMapperFactory.Create(ctx),
+			now:       time.Now,
+		}
+
+		// Create A New ConsumerGroupPause Controller Impl With The Reconciler
+		controllerImpl := pausereconciler.NewImpl(ctx, reconciler)
+
+		// Configure The Informers' EventHandlers
+		logger.Info("Setting Up EventHandlers")
+		pauseInformer.Informer().AddEventHandler(controller.HandleAll(controllerImpl.Enqueue))
+
+		// Return The ConsumerGroupPause Controller
+		return controllerImpl
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/consumergrouppause"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+)
+
+const (
+	// pauseEnforcementInterval is the maximum duration between reconciliations of a paused ConsumerGroupPause.
+	// The Stop commands are re-sent on each reconciliation in order to renew the DataPlane (Dispatcher) locks
+	// and to stop the ConsumerGroups in any new or restarted DataPlane Replicas.
+	pauseEnforcementInterval = 1 * time.Minute
+
+	// pauseLockTimeout is the timeout of the lock kept in the DataPlane (Dispatcher) while a ConsumerGroup is
+	// paused.  It is long enough to be renewed by the next enforcement, but short enough that the lock does
+	// not linger once the Controller stops enforcing the pause (the ConsumerGroup remains stopped though).
+	pauseLockTimeout = 2 * pauseEnforcementInterval
+
+	// pauseReconcilerUID is used in place of a random Reconciler UID when generating the lock tokens, so that
+	// a restarted Controller is able to renew and release the locks it acquired before the restart.
+	pauseReconcilerUID = types.UID("consumergrouppause")
+)
+
+var (
+	_ consumergrouppause.Interface = (*PauseReconciler)(nil) // Verify PauseReconciler Implements Interface
+	_ consumergrouppause.Finalizer = (*PauseReconciler)(nil) // Verify PauseReconciler Implements Finalizer
+)
+
+// PauseReconciler Implements controller.Reconciler for ConsumerGroupPause Resources
+type PauseReconciler struct {
+	dataPlane *Reconciler // Provides The DataPlane (Control-Protocol) ConsumerGroup Stop / Start Commands
+	refMapper refmappers.ResetOffsetRefMapper
+	now       func() time.Time
+}
+
+// ReconcileKind implements the Reconciler Interface and is responsible for stopping and restarting the
+// ConsumerGroups in accordance with the PauseWindows.  The ConsumerGroupPause is re-queued for the end
+// of the active PauseWindows (or the enforcement interval) while paused, and for the start of the next
+// PauseWindow otherwise.
+func (r *PauseReconciler) ReconcileKind(ctx context.Context, pause *kafkav1alpha1.ConsumerGroupPause) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+	logger.Debug("<==========  START CONSUMER-GROUP-PAUSE RECONCILIATION  ==========>")
+
+	// Reset The ConsumerGroupPause's Status Conditions To Unknown
+	pause.Status.InitializeConditions()
+
+	// Map The ConsumerGroupPause's Ref To Kafka Topic Names / ConsumerGroup IDs
+	refInfos, err := mapRefs(r.refMapper, pause.Namespace, pause.Name, pause.Spec.Ref)
+	if err != nil {
+		logger.Error("Failed to map ConsumerGroupPause.Spec.Ref to Kafka Topic name and ConsumerGroup ID", zap.Error(err))
+		pause.Status.MarkRefMappedFailed("FailedToMapRef", "Failed to map 'ref' to Kafka Topic and Group: %v", err)
+		return fmt.Errorf("failed to map 'ref' to Kafka Topic and Group: %v", err)
+	}
+	logger.Info("Successfully mapped ConsumerGroupPause.Spec.Ref", zap.Int("Count", len(refInfos)))
+	pause.Status.SetGroups(pausedGroups(refInfos))
+	pause.Status.MarkRefMappedTrue()
+
+	// Evaluate The PauseWindows At The Current Time
+	now := r.now()
+	state, err := evaluatePauseWindows(pause.Spec.Windows, now)
+	if err != nil {
+		logger.Error("Failed to evaluate ConsumerGroupPause.Spec.Windows", zap.Error(err))
+		pause.Status.MarkConsumerGroupsSynchronizedFailed("InvalidPauseWindows", "Failed to evaluate pause windows: %v", err)
+		return controller.NewPermanentError(fmt.Errorf("failed to evaluate pause windows: %v", err))
+	}
+
+	// Stop The ConsumerGroups While Paused, And Restart Them Once When The PauseWindows End
+	if state.paused {
+		err = r.syncConsumerGroups(ctx, pause, refInfos, commands.StopConsumerGroupOpCode, pauseLockTimeout)
+		if err != nil {
+			logger.Error("Failed to stop one or more ConsumerGroups", zap.Error(err))
+			pause.Status.MarkConsumerGroupsSynchronizedFailed("FailedToStopConsumerGroups", "Failed to stop one or more ConsumerGroups: %v", err)
+			return fmt.Errorf("failed to stop one or more ConsumerGroups: %v", err)
+		}
+		logger.Info("Successfully stopped all ConsumerGroups", zap.Time("WindowEnd", state.windowEnd))
+	} else if pause.Status.IsPaused() {
+		err = r.syncConsumerGroups(ctx, pause, refInfos, commands.StartConsumerGroupOpCode, 0)
+		if err != nil {
+			logger.Error("Failed to restart one or more ConsumerGroups", zap.Error(err))
+			pause.Status.MarkConsumerGroupsSynchronizedFailed("FailedToStartConsumerGroups", "Failed to restart one or more ConsumerGroups: %v", err)
+			return fmt.Errorf("failed to restart one or more ConsumerGroups: %v", err)
+		}
+		logger.Info("Successfully restarted all ConsumerGroups")
+	}
+	pause.Status.MarkConsumerGroupsSynchronizedTrue()
+
+	// Update The Status With The Current PauseWindow State
+	pause.Status.SetPaused(state.paused)
+	pause.Status.SetWindowEnd(optionalTime(state.windowEnd))
+	pause.Status.SetNextWindowStart(optionalTime(state.nextWindowStart))
+
+	// Re-Queue For The Next Transition (Or Enforcement While Paused)
+	if state.paused {
+		requeueAfter := state.windowEnd.Sub(now)
+		if requeueAfter > pauseEnforcementInterval {
+			requeueAfter = pauseEnforcementInterval
+		}
+		return controller.NewRequeueAfter(requeueAfter)
+	} else if !state.nextWindowStart.IsZero() {
+		return controller.NewRequeueAfter(state.nextWindowStart.Sub(now))
+	}
+
+	// Return Reconciled Success Event (No Future PauseWindows)
+	return reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupPauseReconciled.String(), "Reconciled successfully")
+}
+
+// FinalizeKind implements the Finalizer Interface and is responsible for restarting any paused ConsumerGroups.
+func (r *PauseReconciler) FinalizeKind(ctx context.Context, pause *kafkav1alpha1.ConsumerGroupPause) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+	logger.Debug("<==========  START CONSUMER-GROUP-PAUSE FINALIZATION  ==========>")
+
+	// Restart The ConsumerGroups If Currently Paused
+	if pause.Status.IsPaused() {
+		refInfos, err := mapRefs(r.refMapper, pause.Namespace, pause.Name, pause.Spec.Ref)
+		if err != nil {
+			// The Ref No Longer Maps (e.g. Subscription Deleted) So There Is Nothing Left To Restart
+			logger.Warn("Failed to map ConsumerGroupPause.Spec.Ref - Skipping restart of ConsumerGroups", zap.Error(err))
+		} else {
+			err = r.syncConsumerGroups(ctx, pause, refInfos, commands.StartConsumerGroupOpCode, 0)
+			if err != nil {
+				logger.Error("Failed to restart one or more ConsumerGroups", zap.Error(err))
+				return fmt.Errorf("failed to restart one or more ConsumerGroups: %v", err)
+			}
+			logger.Info("Successfully restarted all ConsumerGroups")
+		}
+	}
+
+	// Clean The AsyncCommandNotificationStore
+	r.dataPlane.asyncCommandNotificationStore.CleanPodsNotifications(types.NamespacedName{
+		Namespace: pause.Namespace,
+		Name:      pause.Name,
+	})
+
+	// Return Finalized Success Event
+	return reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupPauseFinalized.String(), "Finalized successfully")
+}
+
+// syncConsumerGroups sends the specified Stop / Start command to all the DataPlane Replicas of every ConsumerGroup
+// in parallel, and blocks waiting for the results.  Any errors are returned in a single multi-error.
+func (r *PauseReconciler) syncConsumerGroups(ctx context.Context,
+	pause *kafkav1alpha1.ConsumerGroupPause,
+	refInfos []*refmappers.RefInfo,
+	opCode ctrl.OpCode,
+	lockTimeout time.Duration) error {
+
+	// Clean Any Prior AsyncCommandResults As The Command IDs Are Re-Used For Each Enforcement
+	r.dataPlane.asyncCommandNotificationStore.CleanPodsNotifications(types.NamespacedName{
+		Namespace: pause.Namespace,
+		Name:      pause.Name,
+	})
+
+	// Reconcile The DataPlane "Services" From The ConnectionPool Once For Each Distinct Key
+	dataPlaneServices := make(map[string]map[string]ctrl.Service)
+	for _, refInfo := range refInfos {
+		if _, ok := dataPlaneServices[refInfo.ConnectionPoolKey]; ok {
+			continue
+		}
+		services, err := r.dataPlane.reconcileDataPlaneServices(ctx, pause, refInfo)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile DataPlane Services from ConnectionPool: %v", err)
+		}
+		dataPlaneServices[refInfo.ConnectionPoolKey] = services
+	}
+
+	// Send The Commands For All The ConsumerGroups In Parallel
+	waitGroup := &sync.WaitGroup{}
+	waitGroup.Add(len(refInfos))
+	errChan := make(chan error, len(refInfos))
+	for _, refInfo := range refInfos {
+		go func(refInfo *refmappers.RefInfo) {
+			defer waitGroup.Done()
+			err := r.dataPlane.sendConsumerGroupAsyncCommands(ctx, pause, dataPlaneServices[refInfo.ConnectionPoolKey], refInfo, opCode, lockTimeout)
+			if err != nil {
+				errChan <- fmt.Errorf("ConsumerGroup '%s': %v", refInfo.GroupId, err)
+			}
+		}(refInfo)
+	}
+	waitGroup.Wait()
+
+	// Close & Drain The Error Channel
+	close(errChan)
+	var multiErr error
+	for err := range errChan {
+		multierr.AppendInto(&multiErr, err)
+	}
+
+	// Return Any Errors
+	return multiErr
+}
+
+// pausedGroups returns the PausedGroups corresponding to the specified RefInfos.
+func pausedGroups(refInfos []*refmappers.RefInfo) []kafkav1alpha1.PausedGroup {
+	groups := make([]kafkav1alpha1.PausedGroup, len(refInfos))
+	for index, refInfo := range refInfos {
+		groups[index] = kafkav1alpha1.PausedGroup{Topic: refInfo.TopicName, Group: refInfo.GroupId}
+	}
+	return groups
+}
+
+// optionalTime returns a metav1.Time for the specified time, or nil if it is the zero time.
+func optionalTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+// Test The PauseReconciler's ReconcileKind() Functionality
+func TestPauseReconciler_ReconcileKind(t *testing.T) {
+
+	// Test Data
+	now := time.Date(2021, 5, 4, 2, 30, 0, 0, time.UTC)
+	at := func(day int, hour int, minute int) *time.Time {
+		t := time.Date(2021, 5, day, hour, minute, 0, 0, time.UTC)
+		return &t
+	}
+	testErr := fmt.Errorf("test-error")
+
+	// Define The Test Cases
+	tests := []struct {
+		name                  string
+		pause                 *kafkav1alpha1.ConsumerGroupPause
+		multiRef              bool
+		mapErr                error
+		sendErr               error
+		expectOpCode          ctrl.OpCode
+		expectErr             bool
+		expectPermanentErr    bool
+		expectRequeueAfter    time.Duration
+		expectEvent           string
+		expectPaused          bool
+		expectWindowEnd       *time.Time
+		expectNextWindowStart *time.Time
+		expectSynchronized    corev1.ConditionStatus
+	}{
+		{
+			name:                  "Window Active",
+			pause:                 controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h")),
+			expectOpCode:          commands.StopConsumerGroupOpCode,
+			expectRequeueAfter:    pauseEnforcementInterval,
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 3, 0),
+			expectNextWindowStart: at(5, 2, 0),
+			expectSynchronized:    corev1.ConditionTrue,
+		},
+		{
+			name:                  "Window Active Multiple ConsumerGroups",
+			pause:                 controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h")),
+			multiRef:              true,
+			expectOpCode:          commands.StopConsumerGroupOpCode,
+			expectRequeueAfter:    pauseEnforcementInterval,
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 3, 0),
+			expectNextWindowStart: at(5, 2, 0),
+			expectSynchronized:    corev1.ConditionTrue,
+		},
+		{
+			name:                  "Window Ending Before Enforcement Interval",
+			pause:                 controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "30m30s")),
+			expectOpCode:          commands.StopConsumerGroupOpCode,
+			expectRequeueAfter:    30 * time.Second,
+			expectPaused:          true,
+			expectWindowEnd:       func() *time.Time { t := at(4, 2, 30).Add(30 * time.Second); return &t }(),
+			expectNextWindowStart: at(5, 2, 0),
+			expectSynchronized:    corev1.ConditionTrue,
+		},
+		{
+			name:                  "Window Ended",
+			pause:                 controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "15m"), controllertesting.WithPauseStatusPaused),
+			expectOpCode:          commands.StartConsumerGroupOpCode,
+			expectRequeueAfter:    23*time.Hour + 30*time.Minute,
+			expectNextWindowStart: at(5, 2, 0),
+			expectSynchronized:    corev1.ConditionTrue,
+		},
+		{
+			name:                  "Window Inactive",
+			pause:                 controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "15m")),
+			expectRequeueAfter:    23*time.Hour + 30*time.Minute,
+			expectNextWindowStart: at(5, 2, 0),
+			expectSynchronized:    corev1.ConditionTrue,
+		},
+		{
+			name:               "No Future Windows",
+			pause:              controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 0 30 2 *", "1h")),
+			expectEvent:        ConsumerGroupPauseReconciled.String(),
+			expectSynchronized: corev1.ConditionTrue,
+		},
+		{
+			name:               "Map Ref Failure",
+			pause:              controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h")),
+			mapErr:             testErr,
+			expectErr:          true,
+			expectSynchronized: corev1.ConditionUnknown,
+		},
+		{
+			name:               "Invalid Windows",
+			pause:              controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("foo", "1h")),
+			expectErr:          true,
+			expectPermanentErr: true,
+			expectSynchronized: corev1.ConditionFalse,
+		},
+		{
+			name:               "Stop Failure",
+			pause:              controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h")),
+			sendErr:            testErr,
+			expectOpCode:       commands.StopConsumerGroupOpCode,
+			expectErr:          true,
+			expectSynchronized: corev1.ConditionFalse,
+		},
+		{
+			name:               "Start Failure",
+			pause:              controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "15m"), controllertesting.WithPauseStatusPaused),
+			sendErr:            testErr,
+			expectOpCode:       commands.StartConsumerGroupOpCode,
+			expectErr:          true,
+			expectPaused:       true,
+			expectSynchronized: corev1.ConditionFalse,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Create The RefInfos & Mock RefMapper
+			refInfos := newPauseTestRefInfos(test.multiRef)
+			mockMultiRefMapper := newPauseTestRefMapper(refInfos, test.multiRef, test.mapErr)
+
+			// Create The PauseReconciler With Mock DataPlane
+			pauseReconciler, mocks := newPauseTestReconciler(t, test.pause, refInfos, test.expectOpCode, test.sendErr)
+			pauseReconciler.refMapper = mockMultiRefMapper
+			pauseReconciler.now = func() time.Time { return now }
+
+			// Perform The Test
+			event := pauseReconciler.ReconcileKind(ctx, test.pause)
+
+			// Verify The Results
+			if test.expectErr {
+				assert.NotNil(t, event)
+				assert.Equal(t, test.expectPermanentErr, controller.IsPermanentError(event))
+			} else if test.expectRequeueAfter > 0 {
+				isRequeue, requeueAfter := controller.IsRequeueKey(event)
+				assert.True(t, isRequeue)
+				assert.Equal(t, test.expectRequeueAfter, requeueAfter)
+			} else {
+				assert.True(t, reconciler.EventIs(event, reconciler.NewEvent(corev1.EventTypeNormal, test.expectEvent, "")))
+			}
+			assert.Equal(t, test.expectPaused, test.pause.Status.IsPaused())
+			assertOptionalTime(t, test.expectWindowEnd, test.pause.Status.GetWindowEnd())
+			assertOptionalTime(t, test.expectNextWindowStart, test.pause.Status.GetNextWindowStart())
+			assert.Equal(t, test.expectSynchronized, test.pause.Status.GetCondition(kafkav1alpha1.ConsumerGroupPauseConditionConsumerGroupsSynchronized).Status)
+			if test.mapErr == nil {
+				assert.Len(t, test.pause.Status.GetGroups(), len(refInfos))
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+// Test The PauseReconciler's FinalizeKind() Functionality
+func TestPauseReconciler_FinalizeKind(t *testing.T) {
+
+	testErr := fmt.Errorf("test-error")
+
+	// Define The Test Cases
+	tests := []struct {
+		name         string
+		pause        *kafkav1alpha1.ConsumerGroupPause
+		mapErr       error
+		sendErr      error
+		expectOpCode ctrl.OpCode
+		expectErr    bool
+	}{
+		{
+			name:  "Not Paused",
+			pause: controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h")),
+		},
+		{
+			name:         "Paused",
+			pause:        controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h"), controllertesting.WithPauseStatusPaused),
+			expectOpCode: commands.StartConsumerGroupOpCode,
+		},
+		{
+			name:   "Paused Map Ref Failure",
+			pause:  controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h"), controllertesting.WithPauseStatusPaused),
+			mapErr: testErr,
+		},
+		{
+			name:         "Paused Start Failure",
+			pause:        controllertesting.NewConsumerGroupPause(controllertesting.WithPauseWindow("0 2 * * *", "1h"), controllertesting.WithPauseStatusPaused),
+			sendErr:      testErr,
+			expectOpCode: commands.StartConsumerGroupOpCode,
+			expectErr:    true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Create The PauseReconciler With Mock RefMapper & DataPlane
+			refInfos := newPauseTestRefInfos(false)
+			pauseReconciler, mocks := newPauseTestReconciler(t, test.pause, refInfos, test.expectOpCode, test.sendErr)
+			pauseReconciler.refMapper = newPauseTestRefMapper(refInfos, false, test.mapErr)
+			if !test.expectErr {
+				mocks.store.On("CleanPodsNotifications", controllertesting.NewConsumerGroupPauseNamespacedName()).Return()
+			}
+
+			// Perform The Test
+			event := pauseReconciler.FinalizeKind(ctx, test.pause)
+
+			// Verify The Results
+			if test.expectErr {
+				assert.NotNil(t, event)
+				assert.False(t, reconciler.EventIs(event, reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupPauseFinalized.String(), "")))
+			} else {
+				assert.True(t, reconciler.EventIs(event, reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupPauseFinalized.String(), "")))
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+// pauseTestMocks wraps the DataPlane mocks used by the PauseReconciler tests.
+type pauseTestMocks struct {
+	podLister      *controllertesting.MockPodLister
+	connectionPool *controlprotocoltesting.MockConnectionPool
+	store          *controlprotocoltesting.MockAsyncCommandNotificationStore
+	service        *controlprotocoltesting.MockService
+}
+
+func (m *pauseTestMocks) assertExpectations(t *testing.T) {
+	m.podLister.AssertExpectations(t)
+	m.connectionPool.AssertExpectations(t)
+	m.store.AssertExpectations(t)
+	m.service.AssertExpectations(t)
+}
+
+// newPauseTestRefInfos returns one, or two (multiRef), RefInfos sharing the same ConnectionPoolKey.
+func newPauseTestRefInfos(multiRef bool) []*refmappers.RefInfo {
+	refInfos := []*refmappers.RefInfo{refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = "TestGroupId1" })}
+	if multiRef {
+		refInfos = append(refInfos, refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = "TestGroupId2" }))
+	}
+	return refInfos
+}
+
+// newPauseTestRefMapper returns a mock MultiRefMapper which maps to the specified RefInfos.
+func newPauseTestRefMapper(refInfos []*refmappers.RefInfo, multiRef bool, mapErr error) *refmapperstesting.MockResetOffsetMultiRefMapper {
+	mockMultiRefMapper := &refmapperstesting.MockResetOffsetMultiRefMapper{}
+	mockMultiRefMapper.On("IsMultiRef", mock.Anything).Return(multiRef)
+	if multiRef {
+		mockMultiRefMapper.On("MapRefs", mock.Anything).Return(refInfos, mapErr)
+	} else {
+		mockMultiRefMapper.On("MapRef", mock.Anything).Return(refInfos[0], mapErr)
+	}
+	return mockMultiRefMapper
+}
+
+// newPauseTestReconciler returns a PauseReconciler whose DataPlane mocks expect the specified OpCode
+// (if any) to be sent for each of the RefInfos to a single DataPlane Pod.
+func newPauseTestReconciler(t *testing.T,
+	pause *kafkav1alpha1.ConsumerGroupPause,
+	refInfos []*refmappers.RefInfo,
+	opCode ctrl.OpCode,
+	sendErr error) (*PauseReconciler, *pauseTestMocks) {
+
+	podIp := "1.2.3.4"
+	podIpPort := fmt.Sprintf("%s:%d", podIp, controlprotocol.ServerPort)
+	pauseNamespacedName := controllertesting.NewConsumerGroupPauseNamespacedName()
+
+	mocks := &pauseTestMocks{
+		podLister:      &controllertesting.MockPodLister{},
+		connectionPool: &controlprotocoltesting.MockConnectionPool{},
+		store:          &controlprotocoltesting.MockAsyncCommandNotificationStore{},
+		service:        &controlprotocoltesting.MockService{},
+	}
+
+	// Only Expect DataPlane Interaction If A Command Is To Be Sent
+	if opCode != 0 {
+
+		// Mock The PodLister & ConnectionPool
+		mockPodNamespaceLister := &controllertesting.MockPodNamespaceLister{}
+		mockPodNamespaceLister.On("List", labels.Set(refInfos[0].DataPlaneLabels).AsSelector()).Return([]*corev1.Pod{{Status: corev1.PodStatus{PodIP: podIp}}}, nil)
+		mocks.podLister.On("Pods", refInfos[0].DataPlaneNamespace).Return(mockPodNamespaceLister)
+		mocks.connectionPool.On("ReconcileConnections",
+			mock.Anything,
+			refInfos[0].ConnectionPoolKey,
+			[]string{podIpPort},
+			mock.AnythingOfType("func(string, control.Service)"),
+			mock.AnythingOfType("func(string)")).
+			Return(map[string]ctrl.Service{podIp: mocks.service}, nil).Once()
+		mocks.store.On("CleanPodsNotifications", pauseNamespacedName).Return()
+		mocks.service.On("MessageHandler", mock.Anything).Return()
+
+		// Expect The Stop (Locked With The Pause Timeout) Or Start (Unlocked) Command For Each ConsumerGroup
+		lockToken := GenerateLockToken(pauseReconcilerUID, pause.UID)
+		commandLock := commands.NewCommandLock(lockToken, 0, false, true)
+		if opCode == commands.StopConsumerGroupOpCode {
+			commandLock = commands.NewCommandLock(lockToken, pauseLockTimeout, true, false)
+		}
+		for _, refInfo := range refInfos {
+			commandId, err := GenerateCommandId(pause, podIp, refInfo.GroupId, opCode)
+			assert.Nil(t, err)
+			command := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: commandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: commandLock}
+			mocks.service.On("SendAndWaitForAck", opCode, command).Return(sendErr)
+			if sendErr == nil {
+				mocks.store.On("GetCommandResult", pauseNamespacedName, podIp, command).Return(&ctrlmessage.AsyncCommandResult{})
+			}
+		}
+	}
+
+	// Create The PauseReconciler
+	pauseReconciler := &PauseReconciler{
+		dataPlane: &Reconciler{
+			uid:                           pauseReconcilerUID,
+			podLister:                     mocks.podLister,
+			connectionPool:                mocks.connectionPool,
+			asyncCommandNotificationStore: mocks.store,
+			asyncCommandRouter:            newAsyncCommandRouter(),
+		},
+	}
+
+	return pauseReconciler, mocks
+}
+
+// assertOptionalTime verifies the specified metav1.Time is nil, or equal to the expected time.
+func assertOptionalTime(t *testing.T, expected *time.Time, actual *metav1.Time) {
+	if expected == nil {
+		assert.Nil(t, actual)
+	} else if assert.NotNil(t, actual) {
+		assert.True(t, expected.Equal(actual.Time), "expected=%v actual=%v", expected, actual.Time)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// pauseWindowState is the evaluation of a ConsumerGroupPause's PauseWindows at a specific time.
+type pauseWindowState struct {
+	paused          bool      // True If Any PauseWindow Is Active
+	windowEnd       time.Time // The Latest End Of The Active PauseWindows (Zero If Not Paused)
+	nextWindowStart time.Time // The Earliest Start Of A PauseWindow After Now / WindowEnd (Zero If None)
+}
+
+// evaluatePauseWindows determines whether any of the specified PauseWindows is active at the specified time,
+// when the active PauseWindows will end, and when the next PauseWindow will start.  A PauseWindow is active
+// if an occurrence of its Schedule started less than Duration ago.
+func evaluatePauseWindows(pauseWindows []kafkav1alpha1.PauseWindow, now time.Time) (*pauseWindowState, error) {
+
+	// Parse All The PauseWindows Up Front So That Invalid Windows Are Never Partially Applied
+	schedules := make([]scheduledWindow, len(pauseWindows))
+	for index := range pauseWindows {
+		schedule, err := pauseWindows[index].ParseSchedule()
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule '%s': %v", pauseWindows[index].Schedule, err)
+		}
+		duration, err := pauseWindows[index].ParseDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration '%s': %v", pauseWindows[index].Duration, err)
+		}
+		schedules[index] = scheduledWindow{schedule: schedule, duration: duration}
+	}
+
+	// Determine The End Of The Active PauseWindows (Latest Occurrence Start Not After Now, Plus Duration)
+	state := &pauseWindowState{}
+	for _, window := range schedules {
+		start := window.schedule.Next(now.Add(-window.duration))
+		if start.IsZero() || start.After(now) {
+			continue
+		}
+		for next := window.schedule.Next(start); !next.IsZero() && !next.After(now); next = window.schedule.Next(next) {
+			start = next
+		}
+		if end := start.Add(window.duration); end.After(state.windowEnd) {
+			state.paused = true
+			state.windowEnd = end
+		}
+	}
+
+	// Determine The Next PauseWindow Start After Now (Or At / After The End Of The Active PauseWindows)
+	after := now
+	if state.paused {
+		after = state.windowEnd.Add(-time.Nanosecond)
+	}
+	for _, window := range schedules {
+		if start := window.schedule.Next(after); !start.IsZero() && (state.nextWindowStart.IsZero() || start.Before(state.nextWindowStart)) {
+			state.nextWindowStart = start
+		}
+	}
+
+	// Return The PauseWindow State
+	return state, nil
+}
+
+// scheduledWindow is a parsed PauseWindow.
+type scheduledWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
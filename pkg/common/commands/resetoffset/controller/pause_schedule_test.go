@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// Test The evaluatePauseWindows() Functionality
+func TestEvaluatePauseWindows(t *testing.T) {
+
+	// Test Data
+	now := time.Date(2021, 5, 4, 2, 30, 0, 0, time.UTC)
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2021, 5, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name                  string
+		windows               []kafkav1alpha1.PauseWindow
+		expectPaused          bool
+		expectWindowEnd       time.Time
+		expectNextWindowStart time.Time
+		expectErr             bool
+	}{
+		{
+			name:                  "Active Window",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}},
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 3, 0),
+			expectNextWindowStart: at(5, 2, 0),
+		},
+		{
+			name:                  "Inactive Window",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "0 2 * * *", Duration: "15m"}},
+			expectNextWindowStart: at(5, 2, 0),
+		},
+		{
+			name:                  "Window Ending Now",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "0 2 * * *", Duration: "30m"}},
+			expectNextWindowStart: at(5, 2, 0),
+		},
+		{
+			name:                  "Window Starting Now",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "30 2 * * *", Duration: "1h"}},
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 3, 30),
+			expectNextWindowStart: at(5, 2, 30),
+		},
+		{
+			name:                  "Overlapping Occurrences",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "*/10 * * * *", Duration: "25m"}},
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 2, 55),
+			expectNextWindowStart: at(4, 3, 0),
+		},
+		{
+			name: "Multiple Windows",
+			windows: []kafkav1alpha1.PauseWindow{
+				{Schedule: "0 3 * * *", Duration: "1h"},
+				{Schedule: "0 2 * * *", Duration: "1h"},
+				{Schedule: "15 2 * * *", Duration: "30m"},
+			},
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 3, 0),
+			expectNextWindowStart: at(4, 3, 0),
+		},
+		{
+			name:                  "Time Zone",
+			windows:               []kafkav1alpha1.PauseWindow{{Schedule: "CRON_TZ=America/New_York 0 22 * * *", Duration: "5h"}},
+			expectPaused:          true,
+			expectWindowEnd:       at(4, 7, 0),
+			expectNextWindowStart: at(5, 2, 0),
+		},
+		{
+			name:    "Never Scheduled",
+			windows: []kafkav1alpha1.PauseWindow{{Schedule: "0 0 30 2 *", Duration: "1h"}},
+		},
+		{
+			name:      "Invalid Schedule",
+			windows:   []kafkav1alpha1.PauseWindow{{Schedule: "0 2 * * *", Duration: "1h"}, {Schedule: "foo", Duration: "1h"}},
+			expectErr: true,
+		},
+		{
+			name:      "Invalid Duration",
+			windows:   []kafkav1alpha1.PauseWindow{{Schedule: "0 2 * * *", Duration: "0s"}},
+			expectErr: true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := evaluatePauseWindows(test.windows, now)
+			if test.expectErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, state)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.expectPaused, state.paused)
+				assert.True(t, test.expectWindowEnd.Equal(state.windowEnd), "windowEnd=%v", state.windowEnd)
+				assert.True(t, test.expectNextWindowStart.Equal(state.nextWindowStart), "nextWindowStart=%v", state.nextWindowStart)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// undoRefusedError is returned when a ConsumerGroup has progressed too far beyond the Offsets of the
+// ResetOffset to undo.  The ConsumerGroup never progresses backwards, so retrying will not help.
+type undoRefusedError struct {
+	error
+}
+
+// isUndoRefused returns true if the specified error (or one it wraps) is an undoRefusedError.
+func isUndoRefused(err error) bool {
+	var undoRefused undoRefusedError
+	return errors.As(err, &undoRefused)
+}
+
+// offsetsUpdaterFn defines the signature of the functions which reposition the Offsets of a single ConsumerGroup.
+type offsetsUpdaterFn func(ctx context.Context) ([]kafkav1alpha1.OffsetMapping, error)
+
+// newOffsetsUpdater returns an offsetsUpdaterFn which will reposition the Offsets of the specified
+// Topic / ConsumerGroup to the position specified in the ResetOffset (either a Time, the name of a
+// ConsumerGroupSnapshot, or a previous ResetOffset to undo).  Problems with the specified position
+// (e.g. a missing ConsumerGroupSnapshot, or a ConsumerGroup which has progressed too far to undo)
+// are returned here so that they are detected BEFORE the ConsumerGroup is stopped.  The progress of
+// an undo is verified again by the returned offsetsUpdaterFn, because the ConsumerGroup keeps
+// committing Offsets until it has been stopped.
+func (r *Reconciler) newOffsetsUpdater(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, refInfo *refmappers.RefInfo) (offsetsUpdaterFn, error) {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Revert The Offsets Of A Previous ResetOffset If Specified
+	if resetOffset.Spec.IsOffsetUndo() {
+		undoSpec := resetOffset.Spec.Offset.Undo
+		offsetMappings, err := r.getUndoOffsetMappings(resetOffset.Namespace, undoSpec.ResetOffset, refInfo)
+		if err != nil {
+			return nil, err
+		}
+		err = r.verifyUndoProgress(ctx, undoSpec, refInfo, offsetMappings) // Fast-Fail Before Stopping The ConsumerGroup
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Successfully loaded ResetOffset OffsetMappings to undo", zap.String("Undo", undoSpec.ResetOffset))
+		return func(ctx context.Context) ([]kafkav1alpha1.OffsetMapping, error) {
+			err := r.verifyUndoProgress(ctx, undoSpec, refInfo, offsetMappings)
+			if err != nil {
+				return nil, err
+			}
+			return r.reconcileUndoOffsets(ctx, refInfo, undoSpec.ResetOffset, offsetMappings)
+		}, nil
+	}
+
+	// Restore The Offsets From The ConsumerGroupSnapshot If Specified
+	if resetOffset.Spec.IsOffsetSnapshot() {
+		snapshotName := resetOffset.Spec.Offset.Snapshot
+		groupOffsets, err := r.getSnapshotGroupOffsets(resetOffset.Namespace, snapshotName, refInfo)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Successfully loaded ConsumerGroupSnapshot Offsets", zap.String("Snapshot", snapshotName))
+		return func(ctx context.Context) ([]kafkav1alpha1.OffsetMapping, error) {
+			return r.reconcileSnapshotOffsets(ctx, refInfo, snapshotName, groupOffsets)
+		}, nil
+	}
+
+	// Otherwise Parse The Sarama Offset Time From ResetOffset Spec
+	offsetTime, err := resetOffset.Spec.ParseSaramaOffsetTime()
+	if err != nil {
+		return nil, err // Should never happen assuming Validation is in place
+	}
+	logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
+	return func(ctx context.Context) ([]kafkav1alpha1.OffsetMapping, error) {
+		return r.reconcileOffsets(ctx, refInfo, offsetTime)
+	}, nil
+}
+
+// getSnapshotGroupOffsets returns the captured Offsets of the specified Topic / ConsumerGroup from
+// the named ConsumerGroupSnapshot, or an error if the snapshot is not present, has not succeeded, or
+// does not contain the ConsumerGroup.
+func (r *Reconciler) getSnapshotGroupOffsets(namespace string, snapshotName string, refInfo *refmappers.RefInfo) (*kafkav1alpha1.ConsumerGroupOffsets, error) {
+
+	// Get The ConsumerGroupSnapshot From The Lister
+	snapshot, err := r.snapshotLister.ConsumerGroupSnapshots(namespace).Get(snapshotName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConsumerGroupSnapshot '%s': %v", snapshotName, err)
+	}
+
+	// Only Restore From Completed Snapshots
+	if !snapshot.Status.IsSucceeded() {
+		return nil, fmt.Errorf("ConsumerGroupSnapshot '%s' has not succeeded", snapshotName)
+	}
+
+	// Find The Offsets Of The Specified Topic / ConsumerGroup
+	groupOffsets := snapshot.Status.GetGroupOffsets(refInfo.TopicName, refInfo.GroupId)
+	if groupOffsets == nil {
+		return nil, fmt.Errorf("ConsumerGroupSnapshot '%s' does not contain Topic '%s' / ConsumerGroup '%s'", snapshotName, refInfo.TopicName, refInfo.GroupId)
+	}
+
+	// Return The ConsumerGroupOffsets
+	return groupOffsets, nil
+}
+
+// getUndoOffsetMappings returns the OffsetMappings recorded for the specified Topic / ConsumerGroup by
+// the named ResetOffset, or an error if the ResetOffset is not present, has not succeeded, or did not
+// update the ConsumerGroup.
+func (r *Reconciler) getUndoOffsetMappings(namespace string, resetOffsetName string, refInfo *refmappers.RefInfo) ([]kafkav1alpha1.OffsetMapping, error) {
+
+	// Get The ResetOffset To Undo From The Lister
+	undoResetOffset, err := r.resetoffsetLister.ResetOffsets(namespace).Get(resetOffsetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ResetOffset '%s': %v", resetOffsetName, err)
+	}
+
+	// Only Undo Completed ResetOffsets
+	if !undoResetOffset.Status.IsSucceeded() {
+		return nil, fmt.Errorf("ResetOffset '%s' has not succeeded", resetOffsetName)
+	}
+
+	// Find The OffsetMappings Of The Specified Topic / ConsumerGroup
+	offsetMappings := undoResetOffset.Status.GetGroupOffsetMappings(refInfo.TopicName, refInfo.GroupId)
+	if offsetMappings == nil {
+		return nil, fmt.Errorf("ResetOffset '%s' does not contain Topic '%s' / ConsumerGroup '%s'", resetOffsetName, refInfo.TopicName, refInfo.GroupId)
+	}
+
+	// Return The OffsetMappings
+	return offsetMappings, nil
+}
+
+// verifyUndoProgress compares the current committed Offsets of the specified Topic / ConsumerGroup against
+// the NewOffsets recorded in the specified OffsetMappings, and returns an error if any Partition has
+// progressed beyond them by more than the OffsetUndoSpec's MaxProgress.  Partitions without a committed
+// Offset are ignored.  The returned error is an undoRefusedError if the undo must be refused.
+func (r *Reconciler) verifyUndoProgress(ctx context.Context, undoSpec *kafkav1alpha1.OffsetUndoSpec, refInfo *refmappers.RefInfo, offsetMappings []kafkav1alpha1.OffsetMapping) error {
+
+	// Read The Current Committed Offsets Of The ConsumerGroup
+	partitionOffsets, err := captureOffsets(ctx, r.kafkaBrokers, r.saramaConfig, refInfo)
+	if err != nil {
+		return fmt.Errorf("failed to read current Offsets of ConsumerGroup '%s': %v", refInfo.GroupId, err)
+	}
+
+	// Index The Current Committed Offsets
+	currentOffsets := make(map[int32]int64, len(partitionOffsets))
+	for _, partitionOffset := range partitionOffsets {
+		currentOffsets[partitionOffset.Partition] = partitionOffset.Offset
+	}
+
+	// Refuse If Any Partition Has Progressed Too Far Beyond The ResetOffset's New Position
+	maxProgress := undoSpec.GetMaxProgress()
+	for _, offsetMapping := range offsetMappings {
+		currentOffset, ok := currentOffsets[offsetMapping.Partition]
+		if !ok || currentOffset < 0 || offsetMapping.NewOffset < 0 {
+			continue
+		}
+		progress := currentOffset - offsetMapping.NewOffset
+		if progress > maxProgress {
+			return undoRefusedError{fmt.Errorf("ConsumerGroup '%s' Partition %d has progressed %d messages since ResetOffset '%s', exceeding the maximum of %d",
+				refInfo.GroupId, offsetMapping.Partition, progress, undoSpec.ResetOffset, maxProgress)}
+		}
+	}
+
+	// Return Success
+	return nil
+}
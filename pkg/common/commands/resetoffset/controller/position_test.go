@@ -0,0 +1,312 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+// Test The Reconciler's newOffsetsUpdater() Functionality
+func TestReconciler_NewOffsetsUpdater(t *testing.T) {
+
+	// Test Data
+	refInfo := &refmappers.RefInfo{TopicName: controllertesting.TopicName, GroupId: controllertesting.GroupId}
+	groupOffsets := []kafkav1alpha1.ConsumerGroupOffsets{
+		{
+			Topic:      controllertesting.TopicName,
+			Group:      controllertesting.GroupId,
+			Partitions: []kafkav1alpha1.PartitionOffset{{Partition: 0, Offset: 100}},
+		},
+	}
+	otherGroupOffsets := []kafkav1alpha1.ConsumerGroupOffsets{
+		{
+			Topic: controllertesting.TopicName,
+			Group: "other-group",
+		},
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name        string
+		resetOffset *kafkav1alpha1.ResetOffset
+		objects     []runtime.Object
+		expectErr   string
+	}{
+		{
+			name:        "Offset Time",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetTime(kafkav1alpha1.OffsetLatest)),
+		},
+		{
+			name:        "Invalid Offset Time",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetTime("foo")),
+			expectErr:   `parsing time "foo" as "2006-01-02T15:04:05Z07:00": cannot parse "foo" as "2006"`,
+		},
+		{
+			name:        "Snapshot",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName)),
+			objects: []runtime.Object{
+				controllertesting.NewConsumerGroupSnapshot(controllertesting.WithSnapshotStatusSucceeded, controllertesting.WithSnapshotStatusGroups(groupOffsets)),
+			},
+		},
+		{
+			name:        "Snapshot Not Found",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName)),
+			expectErr:   `failed to get ConsumerGroupSnapshot 'snapshot-name': consumergroupsnapshot.kafka.eventing.knative.dev "snapshot-name" not found`,
+		},
+		{
+			name:        "Snapshot Not Succeeded",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName)),
+			objects: []runtime.Object{
+				controllertesting.NewConsumerGroupSnapshot(controllertesting.WithSnapshotStatusGroups(groupOffsets)),
+			},
+			expectErr: "ConsumerGroupSnapshot 'snapshot-name' has not succeeded",
+		},
+		{
+			name:        "Snapshot Missing ConsumerGroup",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName)),
+			objects: []runtime.Object{
+				controllertesting.NewConsumerGroupSnapshot(controllertesting.WithSnapshotStatusSucceeded, controllertesting.WithSnapshotStatusGroups(otherGroupOffsets)),
+			},
+			expectErr: "ConsumerGroupSnapshot 'snapshot-name' does not contain Topic 'TestTopicName' / ConsumerGroup 'TestGroupId'",
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Create A Reconciler To Test With The Snapshot Lister
+			listers := controllertesting.NewListers(test.objects)
+			reconciler := &Reconciler{snapshotLister: listers.GetConsumerGroupSnapshotLister()}
+
+			// Perform The Test
+			offsetsUpdater, err := reconciler.newOffsetsUpdater(ctx, test.resetOffset, refInfo)
+
+			// Verify The Results
+			if test.expectErr == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, offsetsUpdater)
+			} else {
+				assert.NotNil(t, err)
+				assert.Equal(t, test.expectErr, err.Error())
+				assert.Nil(t, offsetsUpdater)
+			}
+		})
+	}
+}
+
+// Test The Reconciler's newOffsetsUpdater() Functionality When Undoing A Previous ResetOffset
+func TestReconciler_NewOffsetsUpdater_Undo(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	refInfo := &refmappers.RefInfo{TopicName: controllertesting.TopicName, GroupId: controllertesting.GroupId}
+	partition := int32(0)
+	offsetMappings := []kafkav1alpha1.OffsetMapping{{Partition: partition, OldOffset: 500, NewOffset: 100}}
+	undoResetOffset := func(options ...controllertesting.ResetOffsetOption) *kafkav1alpha1.ResetOffset {
+		options = append([]controllertesting.ResetOffsetOption{controllertesting.WithName(controllertesting.UndoResetOffsetName)}, options...)
+		return controllertesting.NewResetOffset(options...)
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name          string
+		maxProgress   int64
+		objects       []runtime.Object
+		currentOffset *int64
+		expectErr     string
+		expectRefused bool
+	}{
+		{
+			name:        "Within MaxProgress",
+			maxProgress: 10,
+			objects: []runtime.Object{
+				undoResetOffset(controllertesting.WithStatusSucceeded,
+					controllertesting.WithStatusTopic(controllertesting.TopicName),
+					controllertesting.WithStatusGroup(controllertesting.GroupId),
+					controllertesting.WithStatusPartitions(offsetMappings)),
+			},
+			currentOffset: ptr.Int64(110),
+		},
+		{
+			name:        "Exceeds MaxProgress",
+			maxProgress: 10,
+			objects: []runtime.Object{
+				undoResetOffset(controllertesting.WithStatusSucceeded,
+					controllertesting.WithStatusTopic(controllertesting.TopicName),
+					controllertesting.WithStatusGroup(controllertesting.GroupId),
+					controllertesting.WithStatusPartitions(offsetMappings)),
+			},
+			currentOffset: ptr.Int64(111),
+			expectErr:     "ConsumerGroup 'TestGroupId' Partition 0 has progressed 11 messages since ResetOffset 'undo-resetoffset-name', exceeding the maximum of 10",
+			expectRefused: true,
+		},
+		{
+			name:      "ResetOffset Not Found",
+			expectErr: `failed to get ResetOffset 'undo-resetoffset-name': resetoffset.kafka.eventing.knative.dev "undo-resetoffset-name" not found`,
+		},
+		{
+			name: "ResetOffset Not Succeeded",
+			objects: []runtime.Object{
+				undoResetOffset(controllertesting.WithStatusInitialized,
+					controllertesting.WithStatusTopic(controllertesting.TopicName),
+					controllertesting.WithStatusGroup(controllertesting.GroupId),
+					controllertesting.WithStatusPartitions(offsetMappings)),
+			},
+			expectErr: "ResetOffset 'undo-resetoffset-name' has not succeeded",
+		},
+		{
+			name: "ResetOffset Missing ConsumerGroup",
+			objects: []runtime.Object{
+				undoResetOffset(controllertesting.WithStatusSucceeded,
+					controllertesting.WithStatusTopic(controllertesting.TopicName),
+					controllertesting.WithStatusGroup("other-group"),
+					controllertesting.WithStatusPartitions(offsetMappings)),
+			},
+			expectErr: "ResetOffset 'undo-resetoffset-name' does not contain Topic 'TestTopicName' / ConsumerGroup 'TestGroupId'",
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Stub The Sarama Functions To Return Mocks Reporting The Current Offset If Specified
+			if test.currentOffset != nil {
+				client := controllertesting.NewMockClient(
+					controllertesting.WithClientMockPartitions(controllertesting.TopicName, []int32{partition}, nil),
+					controllertesting.WithClientMockClosed(false),
+					controllertesting.WithClientMockClose(nil))
+				offsetManager := controllertesting.NewMockOffsetManager(
+					controllertesting.WithOffsetManagerMockClose(nil))
+				partitionOffsetManager := controllertesting.NewMockPartitionOffsetManager(
+					controllertesting.WithPartitionOffsetManagerMockNextOffset(*test.currentOffset, ""),
+					controllertesting.WithPartitionOffsetManagerMockErrors(),
+					controllertesting.WithPartitionOffsetManagerMockAsyncClose())
+				controllertesting.WithOffsetManagerMockManagePartition(controllertesting.TopicName, partition, partitionOffsetManager, nil)(offsetManager)
+				stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+				defer restoreSaramaNewClientFn()
+				stubSaramaNewOffsetManagerFromClientFn(t, controllertesting.GroupId, client, offsetManager, nil)
+				defer restoreSaramaNewOffsetManagerFromClientFn()
+			}
+
+			// Create A Reconciler To Test With The ResetOffset Lister
+			listers := controllertesting.NewListers(test.objects)
+			reconciler := &Reconciler{
+				kafkaBrokers:      kafkaBrokers,
+				saramaConfig:      saramaConfig,
+				resetoffsetLister: listers.GetResetOffsetLister(),
+			}
+
+			// Perform The Test
+			resetOffset := controllertesting.NewResetOffset(controllertesting.WithSpecOffsetUndo(controllertesting.UndoResetOffsetName, test.maxProgress))
+			offsetsUpdater, err := reconciler.newOffsetsUpdater(ctx, resetOffset, refInfo)
+
+			// Verify The Results
+			if test.expectErr == "" {
+				assert.Nil(t, err)
+				assert.NotNil(t, offsetsUpdater)
+			} else {
+				assert.NotNil(t, err)
+				assert.Equal(t, test.expectErr, err.Error())
+				assert.Equal(t, test.expectRefused, isUndoRefused(err))
+				assert.Nil(t, offsetsUpdater)
+			}
+		})
+	}
+}
+
+// Test That The Undo Progress Is Verified Again When The Offsets Are Updated, After The ConsumerGroup Has Been Stopped
+func TestReconciler_NewOffsetsUpdater_UndoProgressAfterStop(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	refInfo := &refmappers.RefInfo{TopicName: controllertesting.TopicName, GroupId: controllertesting.GroupId}
+	partition := int32(0)
+	offsetMappings := []kafkav1alpha1.OffsetMapping{{Partition: partition, OldOffset: 500, NewOffset: 100}}
+
+	// Create A Context With Test Logger
+	ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+	// Stub The Sarama Functions To Return Mocks Reporting The Specified Current Offset
+	stubCurrentOffset := func(currentOffset int64) {
+		client := controllertesting.NewMockClient(
+			controllertesting.WithClientMockPartitions(controllertesting.TopicName, []int32{partition}, nil),
+			controllertesting.WithClientMockClosed(false),
+			controllertesting.WithClientMockClose(nil))
+		offsetManager := controllertesting.NewMockOffsetManager(
+			controllertesting.WithOffsetManagerMockClose(nil))
+		partitionOffsetManager := controllertesting.NewMockPartitionOffsetManager(
+			controllertesting.WithPartitionOffsetManagerMockNextOffset(currentOffset, ""),
+			controllertesting.WithPartitionOffsetManagerMockErrors(),
+			controllertesting.WithPartitionOffsetManagerMockAsyncClose())
+		controllertesting.WithOffsetManagerMockManagePartition(controllertesting.TopicName, partition, partitionOffsetManager, nil)(offsetManager)
+		stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+		stubSaramaNewOffsetManagerFromClientFn(t, controllertesting.GroupId, client, offsetManager, nil)
+	}
+	defer restoreSaramaNewClientFn()
+	defer restoreSaramaNewOffsetManagerFromClientFn()
+
+	// Create A Reconciler To Test With The ResetOffset Lister
+	listers := controllertesting.NewListers([]runtime.Object{
+		controllertesting.NewResetOffset(controllertesting.WithName(controllertesting.UndoResetOffsetName),
+			controllertesting.WithStatusSucceeded,
+			controllertesting.WithStatusTopic(controllertesting.TopicName),
+			controllertesting.WithStatusGroup(controllertesting.GroupId),
+			controllertesting.WithStatusPartitions(offsetMappings)),
+	})
+	reconciler := &Reconciler{
+		kafkaBrokers:      kafkaBrokers,
+		saramaConfig:      saramaConfig,
+		resetoffsetLister: listers.GetResetOffsetLister(),
+	}
+
+	// The ConsumerGroup Is Within MaxProgress Before Being Stopped...
+	stubCurrentOffset(110)
+	resetOffset := controllertesting.NewResetOffset(controllertesting.WithSpecOffsetUndo(controllertesting.UndoResetOffsetName, 10))
+	offsetsUpdater, err := reconciler.newOffsetsUpdater(ctx, resetOffset, refInfo)
+	assert.Nil(t, err)
+	assert.NotNil(t, offsetsUpdater)
+
+	// ...But Commits Further Offsets Before It Is Stopped, So The Offsets Must Not Be Updated
+	stubCurrentOffset(111)
+	offsetMappings, err = offsetsUpdater(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, "ConsumerGroup 'TestGroupId' Partition 0 has progressed 11 messages since ResetOffset 'undo-resetoffset-name', exceeding the maximum of 10", err.Error())
+	assert.True(t, isUndoRefused(err))
+	assert.Nil(t, offsetMappings)
+}
@@ -28,6 +28,7 @@ import (
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	control "knative.dev/control-protocol/pkg"
 	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 
@@ -53,9 +54,11 @@ type Reconciler struct {
 	saramaConfig                  *sarama.Config
 	podLister                     corev1listers.PodLister
 	resetoffsetLister             kafkalisters.ResetOffsetLister
+	snapshotLister                kafkalisters.ConsumerGroupSnapshotLister
 	refMapper                     refmappers.ResetOffsetRefMapper
 	connectionPool                ctrlreconciler.ControlPlaneConnectionPool
 	asyncCommandNotificationStore ctrlreconciler.AsyncCommandNotificationStore
+	asyncCommandRouter            *asyncCommandRouter
 }
 
 // ReconcileKind implements the Reconciler Interface and is responsible for performing Offset repositioning.
@@ -81,6 +84,11 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	// Reset The ResetOffset's Status Conditions To Unknown
 	resetOffset.Status.InitializeConditions()
 
+	// Refs Which Expand To Multiple ConsumerGroups (e.g. KafkaChannel) Are Reconciled Per ConsumerGroup
+	if multiRefMapper, ok := r.refMapper.(refmappers.ResetOffsetMultiRefMapper); ok && multiRefMapper.IsMultiRef(resetOffset) {
+		return r.reconcileConsumerGroups(ctx, resetOffset, multiRefMapper)
+	}
+
 	// Map The ResetOffset's Ref To Kafka Topic Name / ConsumerGroup ID
 	refInfo, err := r.refMapper.MapRef(resetOffset)
 	if err != nil {
@@ -106,13 +114,15 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	// Only Stop ConsumerGroups & Update Offsets Once
 	if !resetOffset.Status.IsOffsetsUpdated() {
 
-		// Parse The Sarama Offset Time From ResetOffset Spec
-		offsetTime, err := resetOffset.Spec.ParseSaramaOffsetTime()
-		if err != nil {
-			logger.Error("Failed to parse Sarama Offset Time from ResetOffset Spec", zap.Error(err))
-			return err // Should never happen assuming Validation is in place
+		// Resolve The Offset Position (Time Or ConsumerGroupSnapshot) From ResetOffset Spec
+		offsetsUpdater, err := r.newOffsetsUpdater(ctx, resetOffset, refInfo)
+		if isUndoRefused(err) {
+			return r.refuseUndo(ctx, resetOffset, dataPlaneServices, refInfo, err)
+		} else if err != nil {
+			logger.Error("Failed to resolve Offset position from ResetOffset Spec", zap.Error(err))
+			resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToResolveOffsets", "Failed to resolve Offset position: %v", err)
+			return fmt.Errorf("failed to resolve Offset position: %v", err)
 		}
-		logger.Info("Successfully parsed Sarama Offset Time from ResetOffset Spec", zap.Int64("Time (millis)", offsetTime))
 
 		// Stop The ConsumerGroup In Associated Dispatchers
 		err = r.stopConsumerGroups(ctx, resetOffset, dataPlaneServices, refInfo)
@@ -125,8 +135,10 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 		resetOffset.Status.MarkConsumerGroupsStoppedTrue()
 
 		// Update The Sarama Offsets & Update ResetOffset CRD With OffsetMappings (Single Atomic Operation For All Offsets)
-		offsetMappings, err := r.reconcileOffsets(ctx, refInfo, offsetTime)
-		if err != nil {
+		offsetMappings, err := offsetsUpdater(ctx)
+		if isUndoRefused(err) {
+			return r.refuseUndo(ctx, resetOffset, dataPlaneServices, refInfo, err)
+		} else if err != nil {
 			logger.Error("Failed to update Offsets of ConsumerGroup Partitions", zap.Error(err))
 			resetOffset.Status.MarkOffsetsUpdatedFailed("FailedToUpdateOffsets", "Failed to update Offsets of ConsumerGroup Partitions: %v", err)
 			return fmt.Errorf("failed to update Offsets of ConsumerGroup Partitions: %v", err)
@@ -152,6 +164,33 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, resetOffset *kafkav1alph
 	return reconciler.NewEvent(corev1.EventTypeNormal, ResetOffsetReconciled.String(), "Reconciled successfully")
 }
 
+// refuseUndo marks the ResetOffset as permanently failed because the ConsumerGroup has progressed too far to
+// undo the referenced ResetOffset.  The ConsumerGroups are restarted first if a previous attempt stopped them
+// without restarting them, since every retry would otherwise refuse the undo before they are started again.
+func (r *Reconciler) refuseUndo(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset, services map[string]control.Service, refInfo *refmappers.RefInfo, undoErr error) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
+	logger.Error("Refused to undo ResetOffset", zap.Error(undoErr))
+	resetOffset.Status.MarkOffsetsUpdatedFailed("UndoRefused", "Refused to undo ResetOffset: %v", undoErr)
+
+	// Restart The ConsumerGroup In Associated Dispatchers If Still Stopped
+	if resetOffset.Status.GetCondition(kafkav1alpha1.ResetOffsetConditionConsumerGroupsStopped).IsTrue() &&
+		!resetOffset.Status.GetCondition(kafkav1alpha1.ResetOffsetConditionConsumerGroupsStarted).IsTrue() {
+		err := r.startConsumerGroups(ctx, resetOffset, services, refInfo)
+		if err != nil {
+			logger.Error("Failed to restart one or more ConsumerGroups", zap.Error(err))
+			resetOffset.Status.MarkConsumerGroupsStartedFailed("FailedToStartConsumerGroups", "Failed to restart one or more ConsumerGroups: %v", err)
+			return fmt.Errorf("failed to restart one or more ConsumerGroups: %v", err)
+		}
+		logger.Info("Successfully restarted all ConsumerGroups")
+		resetOffset.Status.MarkConsumerGroupsStartedTrue()
+	}
+
+	return controller.NewPermanentError(fmt.Errorf("refused to undo ResetOffset: %v", undoErr))
+}
+
 // FinalizeKind implements the Finalizer Interface and is responsible for performing any necessary cleanup.
 func (r *Reconciler) FinalizeKind(ctx context.Context, resetOffset *kafkav1alpha1.ResetOffset) reconciler.Event {
 
@@ -175,18 +214,34 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, resetOffset *kafkav1alpha
 // updateKafkaConfig is the callback function that handles changes to the ConfigMap
 func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.ConfigMap) {
 
-	// Get The Logger From Context
-	logger := logging.FromContext(ctx).Desugar()
-
 	// Validate Reconciler Reference (Sometimes nil on startup and can be ignored)
 	if r == nil {
 		return
 	}
 
+	// Load The Kafka Brokers & Sarama Config From The ConfigMap
+	kafkaBrokers, saramaConfig := loadKafkaConfig(ctx, configMap)
+	if saramaConfig == nil {
+		return
+	}
+
+	// Update Reconciler With New Config
+	r.kafkaBrokers = kafkaBrokers
+	r.saramaConfig = saramaConfig
+}
+
+// loadKafkaConfig returns the Kafka Brokers and Sarama Config from the specified ConfigMap,
+// or a nil Sarama Config if the ConfigMap is invalid.  It is shared by the ResetOffset and
+// ConsumerGroupSnapshot Reconcilers.
+func loadKafkaConfig(ctx context.Context, configMap *corev1.ConfigMap) ([]string, *sarama.Config) {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+
 	// Validate ConfigMap Reference
 	if configMap == nil {
 		logger.Warn("Ignoring nil ConfigMap")
-		return
+		return nil, nil
 	}
 
 	// Enhance Logger With ConfigMap
@@ -195,19 +250,16 @@ func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.Co
 	// Validate ConfigMap.Data
 	if configMap.Data == nil {
 		logger.Warn("Ignoring nil ConfigMap.Data")
-		return
+		return nil, nil
 	}
 
 	// Load The EventingKafkaConfig From ConfigMap.Data
 	ekConfig, err := kafkasarama.LoadSettings(ctx, Component, configMap.Data, kafkasarama.LoadAuthConfig)
 	if err != nil || ekConfig == nil {
 		logger.Error("Failed to extract EventingKafkaConfig from ConfigMap", zap.Any("ConfigMap", configMap), zap.Error(err))
-		return
+		return nil, nil
 	}
 
-	// Update The KafkaBrokers On Reconciler
-	r.kafkaBrokers = strings.Split(ekConfig.Kafka.Brokers, ",")
-
 	// Enable/Disable Sarama Logging Based On ConfigMap Setting
 	kafkasarama.EnableSaramaLogging(ekConfig.Sarama.EnableLogging)
 	logger.Debug("Set Sarama logging", zap.Bool("Enabled", ekConfig.Sarama.EnableLogging))
@@ -218,8 +270,8 @@ func (r *Reconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.Co
 	// Force Disable Manual Commits
 	ekConfig.Sarama.Config.Consumer.Offsets.AutoCommit.Enable = false
 
-	// Update Reconciler With New Config
-	r.saramaConfig = ekConfig.Sarama.Config
+	// Return The Kafka Brokers & Sarama Config
+	return strings.Split(ekConfig.Kafka.Brokers, ","), ekConfig.Sarama.Config
 }
 
 // dataPlaneServiceIPs returns the control-protocol Service IPs which are the keys in the specified map.
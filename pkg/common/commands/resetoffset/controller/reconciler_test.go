@@ -38,6 +38,7 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
 	. "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
@@ -207,12 +208,12 @@ func TestReconcile(t *testing.T) {
 						controllertesting.WithStatusGroup(groupId),
 						controllertesting.WithStatusRefMapped(true),
 						controllertesting.WithStatusAcquireDataPlaneServices(true),
-						controllertesting.WithStatusConsumerGroupsStopped(false, "FailedToStopConsumerGroups", fmt.Sprintf("Failed to stop one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '3866008807': %v", testErr.Error()))),
+						controllertesting.WithStatusConsumerGroupsStopped(false, "FailedToStopConsumerGroups", fmt.Sprintf("Failed to stop one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '2744114338': %v", testErr.Error()))),
 				},
 			},
 			WantErr: true,
 			WantEvents: []string{
-				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to stop one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '3866008807': %v", testErr.Error())),
+				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to stop one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '2744114338': %v", testErr.Error())),
 			},
 		},
 		{
@@ -238,6 +239,31 @@ func TestReconcile(t *testing.T) {
 				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to update Offsets of ConsumerGroup Partitions: %v", testErr.Error())),
 			},
 		},
+		{
+			Name: "Resolve Snapshot Error",
+			Key:  controllertesting.ResetOffsetKey,
+			Objects: []runtime.Object{
+				controllertesting.NewResetOffset(controllertesting.WithFinalizer, controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName)),
+				controllertesting.NewConsumerGroupSnapshot(),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+				{
+					Object: controllertesting.NewResetOffset(
+						controllertesting.WithFinalizer,
+						controllertesting.WithSpecOffsetSnapshot(controllertesting.SnapshotName),
+						controllertesting.WithStatusInitialized,
+						controllertesting.WithStatusTopic(topicName),
+						controllertesting.WithStatusGroup(groupId),
+						controllertesting.WithStatusRefMapped(true),
+						controllertesting.WithStatusAcquireDataPlaneServices(true),
+						controllertesting.WithStatusOffsetsUpdated(false, "FailedToResolveOffsets", "Failed to resolve Offset position: ConsumerGroupSnapshot 'snapshot-name' has not succeeded")),
+				},
+			},
+			WantErr: true,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", "failed to resolve Offset position: ConsumerGroupSnapshot 'snapshot-name' has not succeeded"),
+			},
+		},
 		{
 			Name:          "Start ConsumerGroups Error",
 			Key:           controllertesting.ResetOffsetKey,
@@ -255,12 +281,12 @@ func TestReconcile(t *testing.T) {
 						controllertesting.WithStatusAcquireDataPlaneServices(true),
 						controllertesting.WithStatusConsumerGroupsStopped(true),
 						controllertesting.WithStatusOffsetsUpdated(true),
-						controllertesting.WithStatusConsumerGroupsStarted(false, "FailedToStartConsumerGroups", fmt.Sprintf("Failed to restart one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '3899564045': %v", testErr))),
+						controllertesting.WithStatusConsumerGroupsStarted(false, "FailedToStartConsumerGroups", fmt.Sprintf("Failed to restart one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '2710559100': %v", testErr))),
 				},
 			},
 			WantErr: true,
 			WantEvents: []string{
-				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to restart one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '3899564045': %v", testErr.Error())),
+				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("failed to restart one or more ConsumerGroups: failed to send ConsumerGroup AsyncCommand '2710559100': %v", testErr.Error())),
 			},
 		},
 
@@ -358,9 +384,9 @@ func TestReconcile(t *testing.T) {
 		lockToken := GenerateLockToken(reconcilerUID, "") // TableTest Resources Don't Have A UID And Setting Them Above Doesn't Help
 		stopCommandLock := commands.NewCommandLock(lockToken, asyncCommandLockTimeout, true, false)
 		startCommandLock := commands.NewCommandLock(lockToken, 0, false, true)
-		stopCommandId, err := GenerateCommandId(controllertesting.NewResetOffset(), podIp, commands.StopConsumerGroupOpCode)
+		stopCommandId, err := GenerateCommandId(controllertesting.NewResetOffset(), podIp, refInfo.GroupId, commands.StopConsumerGroupOpCode)
 		assert.Nil(t, err)
-		startCommandId, err := GenerateCommandId(controllertesting.NewResetOffset(), podIp, commands.StartConsumerGroupOpCode)
+		startCommandId, err := GenerateCommandId(controllertesting.NewResetOffset(), podIp, refInfo.GroupId, commands.StartConsumerGroupOpCode)
 		assert.Nil(t, err)
 		stopConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: stopCommandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: stopCommandLock}
 		startConsumerGroupAsyncCommand := &commands.ConsumerGroupAsyncCommand{Version: 1, CommandId: startCommandId, TopicName: refInfo.TopicName, GroupId: refInfo.GroupId, Lock: startCommandLock}
@@ -369,6 +395,7 @@ func TestReconcile(t *testing.T) {
 		mockDataPlaneService := &controlprotocoltesting.MockService{}
 		mockDataPlaneService.On("SendAndWaitForAck", commands.StopConsumerGroupOpCode, stopConsumerGroupAsyncCommand).Return(stopConsumerGroupsErr)
 		mockDataPlaneService.On("SendAndWaitForAck", commands.StartConsumerGroupOpCode, startConsumerGroupAsyncCommand).Return(startConsumerGroupsErr)
+		mockDataPlaneService.On("MessageHandler", mock.Anything).Return()
 		services := map[string]ctrl.Service{podIp: mockDataPlaneService}
 
 		// Create A Mock Control-Protocol ConnectionPool
@@ -418,9 +445,11 @@ func TestReconcile(t *testing.T) {
 			saramaConfig:                  saramaConfig,
 			podLister:                     mockPodLister,
 			resetoffsetLister:             listers.GetResetOffsetLister(),
+			snapshotLister:                listers.GetConsumerGroupSnapshotLister(),
 			refMapper:                     mockResetOffsetRefMapper,
 			connectionPool:                mockConnectionPool,
 			asyncCommandNotificationStore: mockAsyncCommandNotificationStore,
+			asyncCommandRouter:            newAsyncCommandRouter(),
 		}
 
 		// Create / Return The Full Reconciler
@@ -429,6 +458,107 @@ func TestReconcile(t *testing.T) {
 	}, logger.Desugar()))
 }
 
+// Test That A Refused Undo Restarts Any Stopped ConsumerGroups And Fails Permanently
+func TestReconciler_RefuseUndo(t *testing.T) {
+
+	// Test Data
+	reconcilerUID := types.UID(uuid.NewString())
+	refInfo := refmapperstesting.NewRefInfo()
+	podIp := "1.2.3.4"
+	undoErr := undoRefusedError{fmt.Errorf("progressed")}
+	testErr := fmt.Errorf("test-error")
+
+	// Define The Test Cases
+	tests := []struct {
+		name          string
+		stopped       bool
+		started       bool
+		startErr      error
+		wantStart     bool
+		wantPermanent bool
+		wantStarted   corev1.ConditionStatus
+	}{
+		{
+			name:          "Not Stopped",
+			wantPermanent: true,
+			wantStarted:   corev1.ConditionUnknown,
+		},
+		{
+			name:          "Stopped",
+			stopped:       true,
+			wantStart:     true,
+			wantPermanent: true,
+			wantStarted:   corev1.ConditionTrue,
+		},
+		{
+			name:          "Stopped And Restarted",
+			stopped:       true,
+			started:       true,
+			wantPermanent: true,
+			wantStarted:   corev1.ConditionTrue,
+		},
+		{
+			name:        "Restart Error",
+			stopped:     true,
+			startErr:    testErr,
+			wantStart:   true,
+			wantStarted: corev1.ConditionFalse,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create The ResetOffset To Test
+			resetOffset := controllertesting.NewResetOffset(controllertesting.WithStatusInitialized)
+			if test.stopped {
+				resetOffset.Status.MarkConsumerGroupsStoppedTrue()
+			}
+			if test.started {
+				resetOffset.Status.MarkConsumerGroupsStartedTrue()
+			}
+
+			// Create The Test Start ConsumerGroupAsyncCommand
+			commandId, err := GenerateCommandId(resetOffset, podIp, refInfo.GroupId, commands.StartConsumerGroupOpCode)
+			assert.Nil(t, err)
+			startLock := commands.NewCommandLock(GenerateLockToken(reconcilerUID, resetOffset.UID), 0, false, true)
+			startCommand := commands.NewConsumerGroupAsyncCommand(commandId, refInfo.TopicName, refInfo.GroupId, startLock)
+
+			// Create The Mock Service & AsyncCommandNotificationStore To Test Against
+			mockDataPlaneService := &controlprotocoltesting.MockService{}
+			mockDataPlaneService.On("SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand).Return(test.startErr)
+			mockAsyncCommandNotificationStore := &controlprotocoltesting.MockAsyncCommandNotificationStore{}
+			mockAsyncCommandNotificationStore.On("GetCommandResult", controllertesting.NewResetOffsetNamespacedName(), podIp, startCommand).Return(&ctrlmessage.AsyncCommandResult{})
+
+			// Create The Reconciler To Test
+			r := &Reconciler{
+				uid:                           reconcilerUID,
+				asyncCommandNotificationStore: mockAsyncCommandNotificationStore,
+				asyncCommandRouter:            newAsyncCommandRouter(),
+			}
+
+			// Perform The Test
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+			err = r.refuseUndo(ctx, resetOffset, map[string]ctrl.Service{podIp: mockDataPlaneService}, refInfo, undoErr)
+
+			// Verify The Results
+			assert.NotNil(t, err)
+			assert.Equal(t, test.wantPermanent, controller.IsPermanentError(err))
+			offsetsUpdated := resetOffset.Status.GetCondition(kafkav1alpha1.ResetOffsetConditionOffsetsUpdated)
+			assert.Equal(t, corev1.ConditionFalse, offsetsUpdated.Status)
+			assert.Equal(t, "UndoRefused", offsetsUpdated.Reason)
+			assert.Equal(t, test.wantStarted, resetOffset.Status.GetCondition(kafkav1alpha1.ResetOffsetConditionConsumerGroupsStarted).Status)
+			assert.False(t, resetOffset.Status.IsSucceeded())
+			if test.wantStart {
+				mockDataPlaneService.AssertCalled(t, "SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand)
+			} else {
+				mockDataPlaneService.AssertNotCalled(t, "SendAndWaitForAck", commands.StartConsumerGroupOpCode, startCommand)
+			}
+		})
+	}
+}
+
 func TestReconciler_updateKafkaConfig(t *testing.T) {
 
 	// Define EKConfig String For Use In Test (Note - Preserve Indentation!)
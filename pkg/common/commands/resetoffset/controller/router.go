@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/logging"
+)
+
+// sharedAsyncCommandRouter is the asyncCommandRouter used by the ResetOffset and ConsumerGroupPause Reconcilers.
+// It must be shared in the same way as the ConnectionPool, because the control-protocol Services created by
+// the ConnectionPool only support a single MessageHandler.
+var sharedAsyncCommandRouter = newAsyncCommandRouter()
+
+// asyncCommandRoute identifies the AsyncCommandNotificationStore entry awaiting the result of an AsyncCommand.
+type asyncCommandRoute struct {
+	store   ctrlreconciler.AsyncCommandNotificationStore
+	srcName types.NamespacedName
+	pod     string
+}
+
+// asyncCommandRouter is registered once as the MessageHandler of each control-protocol Service and
+// dispatches the AsyncCommandResults to the AsyncCommandNotificationStore of the Reconciler which
+// sent the corresponding AsyncCommand, based on the command ID (see GenerateCommandId).
+type asyncCommandRouter struct {
+	mutex    sync.RWMutex
+	services map[string]ctrl.Service      // Services (By Host) On Which The Router Is Registered
+	routes   map[string]asyncCommandRoute // Pending AsyncCommands (By Serialized Command ID)
+}
+
+// newAsyncCommandRouter returns a new, empty asyncCommandRouter.
+func newAsyncCommandRouter() *asyncCommandRouter {
+	return &asyncCommandRouter{
+		services: make(map[string]ctrl.Service),
+		routes:   make(map[string]asyncCommandRoute),
+	}
+}
+
+// register sets the router as the MessageHandler of the specified Service unless it already is.
+func (a *asyncCommandRouter) register(host string, service ctrl.Service) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.services[host] == service {
+		return
+	}
+	a.services[host] = service
+	service.MessageHandler(ctrl.MessageHandlerFunc(a.handleServiceMessage))
+}
+
+// unregister forgets the Service of the specified host, which is no longer part of the ConnectionPool.
+func (a *asyncCommandRouter) unregister(host string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.services, host)
+}
+
+// addRoute directs the result of the specified AsyncCommand to the AsyncCommandNotificationStore entry
+// of the specified resource and pod.  The returned function removes the route once it is no longer needed.
+func (a *asyncCommandRouter) addRoute(command ctrlmessage.AsyncCommand, route asyncCommandRoute) func() {
+	key := string(command.SerializedId())
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.routes[key] = route
+	return func() {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		delete(a.routes, key)
+	}
+}
+
+// handleServiceMessage implements the control-protocol MessageHandler by forwarding the AsyncCommandResult
+// to the MessageHandler of the AsyncCommandNotificationStore awaiting it.  Results for unknown commands
+// (e.g. which already timed out) are acknowledged and dropped.
+func (a *asyncCommandRouter) handleServiceMessage(ctx context.Context, message ctrl.ServiceMessage) {
+
+	logger := logging.FromContext(ctx).Desugar()
+
+	// Parse The AsyncCommandResult To Determine The Command ID
+	payload, err := ctrlmessage.ParseAsyncCommandResult(message.Payload())
+	if err != nil {
+		logger.Error("Failed to parse AsyncCommandResult", zap.Uint8("OpCode", message.Headers().OpCode()), zap.Error(err))
+		return
+	}
+	result := payload.(ctrlmessage.AsyncCommandResult)
+
+	// Lookup The Route For The Command ID
+	a.mutex.RLock()
+	route, ok := a.routes[string(result.CommandId)]
+	a.mutex.RUnlock()
+	if !ok {
+		logger.Debug("Dropping AsyncCommandResult for unknown command", zap.Binary("CommandID", result.CommandId))
+		message.Ack()
+		return
+	}
+
+	// Forward The Message To The AsyncCommandNotificationStore (Which Acknowledges It)
+	route.store.MessageHandler(route.srcName, route.pod).HandleServiceMessage(ctx, message)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlmessage "knative.dev/control-protocol/pkg/message"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol"
+	"knative.dev/eventing-kafka/pkg/common/controlprotocol/commands"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+)
+
+// Test that concurrent ResetOffset & ConsumerGroupPause reconciliations sharing a single data-plane
+// Service each receive their own AsyncCommandResults, and that the Service's MessageHandler is only
+// registered once.
+func TestAsyncCommandRouter_SharedService(t *testing.T) {
+
+	// Test Data
+	podIp := "1.2.3.4"
+	podIpPort := fmt.Sprintf("%s:%d", podIp, controlprotocol.ServerPort)
+	pods := []*corev1.Pod{{Status: corev1.PodStatus{PodIP: podIp}}}
+	refInfo := refmapperstesting.NewRefInfo()
+	resetOffset := controllertesting.NewResetOffset()
+	pause := controllertesting.NewConsumerGroupPause()
+	pause.UID = "TestPauseUID"
+
+	// Create A Context With Test Logger
+	logger := logtesting.TestLogger(t)
+	ctx := logging.WithLogger(context.Background(), logger)
+
+	// Create A Single Fake Data-Plane Service Which Replies Only Once Both Reconcilers Have Sent Their Commands
+	service := newFakeDataPlaneService(2)
+	services := map[string]ctrl.Service{podIpPort: service}
+
+	// Create Mock PodLister & ConnectionPool Shared By Both Reconcilers
+	mockPodNamespaceLister := &controllertesting.MockPodNamespaceLister{}
+	mockPodNamespaceLister.On("List", labels.Set(refInfo.DataPlaneLabels).AsSelector()).Return(pods, nil)
+	mockPodLister := &controllertesting.MockPodLister{}
+	mockPodLister.On("Pods", refInfo.DataPlaneNamespace).Return(mockPodNamespaceLister)
+	mockConnectionPool := &controlprotocoltesting.MockConnectionPool{}
+	mockConnectionPool.On("ReconcileConnections",
+		mock.Anything,
+		refInfo.ConnectionPoolKey,
+		[]string{podIpPort},
+		mock.AnythingOfType("func(string, control.Service)"),
+		mock.AnythingOfType("func(string)")).
+		Return(services, nil)
+
+	// Create The Two Reconcilers, Each With Their Own AsyncCommandNotificationStore But Sharing The Router
+	router := newAsyncCommandRouter()
+	newReconciler := func(uid types.UID) *Reconciler {
+		return &Reconciler{
+			uid:                           uid,
+			podLister:                     mockPodLister,
+			connectionPool:                mockConnectionPool,
+			asyncCommandNotificationStore: ctrlreconciler.NewAsyncCommandNotificationStore(func(types.NamespacedName) {}),
+			asyncCommandRouter:            router,
+		}
+	}
+	resetOffsetReconciler := newReconciler(types.UID(uuid.NewString()))
+	pauseReconciler := newReconciler(pauseReconcilerUID)
+
+	// Perform The Test - Stop The ConsumerGroups From Both Reconcilers Concurrently
+	waitGroup := sync.WaitGroup{}
+	errs := make([]error, 2)
+	reconcile := func(index int, r *Reconciler, resource metav1.Object) {
+		defer waitGroup.Done()
+		reconciledServices, err := r.reconcileDataPlaneServices(ctx, resource, refInfo)
+		if err != nil {
+			errs[index] = err
+			return
+		}
+		errs[index] = r.sendConsumerGroupAsyncCommands(ctx, resource, reconciledServices, refInfo, commands.StopConsumerGroupOpCode, asyncCommandLockTimeout)
+	}
+	waitGroup.Add(2)
+	go reconcile(0, resetOffsetReconciler, resetOffset)
+	go reconcile(1, pauseReconciler, pause)
+	waitGroup.Wait()
+
+	// Verify Both Reconcilers Received Their Results & The Router Was Registered Once
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, 1, service.registrations())
+	assert.Empty(t, router.routes)
+}
+
+// Test that results for unknown (e.g. timed out) commands are acknowledged and dropped.
+func TestAsyncCommandRouter_UnknownCommand(t *testing.T) {
+	router := newAsyncCommandRouter()
+	payload, err := ctrlmessage.AsyncCommandResult{CommandId: ctrlmessage.Int64CommandId(1234)}.MarshalBinary()
+	assert.Nil(t, err)
+	message := ctrl.NewMessage([16]byte{}, uint8(commands.StopConsumerGroupResultOpCode), payload)
+	acked := false
+	router.handleServiceMessage(context.TODO(), ctrl.NewServiceMessage(&message, func(err error) {
+		assert.Nil(t, err)
+		acked = true
+	}))
+	assert.True(t, acked)
+}
+
+// fakeDataPlaneService is a control-protocol Service which replies to ConsumerGroupAsyncCommands
+// with successful AsyncCommandResults, delivered to its MessageHandler once the expected number
+// of commands has been received.
+type fakeDataPlaneService struct {
+	mutex    sync.Mutex
+	expected int
+	commands []*commands.ConsumerGroupAsyncCommand
+	handler  ctrl.MessageHandler
+	handlers int
+}
+
+var _ ctrl.Service = (*fakeDataPlaneService)(nil)
+
+func newFakeDataPlaneService(expected int) *fakeDataPlaneService {
+	return &fakeDataPlaneService{expected: expected}
+}
+
+func (s *fakeDataPlaneService) SendAndWaitForAck(_ ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.commands = append(s.commands, payload.(*commands.ConsumerGroupAsyncCommand))
+	if len(s.commands) == s.expected {
+		for _, command := range s.commands {
+			go s.reply(command)
+		}
+	}
+	return nil
+}
+
+func (s *fakeDataPlaneService) reply(command *commands.ConsumerGroupAsyncCommand) {
+	s.mutex.Lock()
+	handler := s.handler
+	s.mutex.Unlock()
+	payload, _ := ctrlmessage.AsyncCommandResult{CommandId: command.SerializedId()}.MarshalBinary()
+	message := ctrl.NewMessage([16]byte{}, uint8(commands.StopConsumerGroupResultOpCode), payload)
+	handler.HandleServiceMessage(context.TODO(), ctrl.NewServiceMessage(&message, func(err error) {}))
+}
+
+func (s *fakeDataPlaneService) MessageHandler(handler ctrl.MessageHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handler = handler
+	s.handlers++
+}
+
+func (s *fakeDataPlaneService) ErrorHandler(_ ctrl.ErrorHandler) {}
+
+func (s *fakeDataPlaneService) registrations() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.handlers
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/kafka/v1alpha1/consumergroupsnapshot"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+)
+
+var (
+	_ consumergroupsnapshot.Interface = (*SnapshotReconciler)(nil) // Verify SnapshotReconciler Implements Interface
+)
+
+// SnapshotReconciler Implements controller.Reconciler for ConsumerGroupSnapshot Resources
+type SnapshotReconciler struct {
+	kafkaBrokers []string
+	saramaConfig *sarama.Config
+	refMapper    refmappers.ResetOffsetRefMapper
+}
+
+// ReconcileKind implements the Reconciler Interface and is responsible for capturing the committed Offsets.
+func (r *SnapshotReconciler) ReconcileKind(ctx context.Context, snapshot *kafkav1alpha1.ConsumerGroupSnapshot) reconciler.Event {
+
+	// Get The Logger From Context
+	logger := logging.FromContext(ctx).Desugar()
+	logger.Debug("<==========  START CONSUMER-GROUP-SNAPSHOT RECONCILIATION  ==========>")
+
+	// Ignore Previously Successful ConsumerGroupSnapshot Instances (Snapshots Are Only Captured Once)
+	if snapshot.Status.IsSucceeded() {
+		logger.Debug("Skipping reconciliation of previously successful ConsumerGroupSnapshot instance")
+		return reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupSnapshotSkipped.String(), "Skipped previously successful ConsumerGroupSnapshot")
+	}
+
+	// Reset The ConsumerGroupSnapshot's Status Conditions To Unknown
+	snapshot.Status.InitializeConditions()
+
+	// Map The ConsumerGroupSnapshot's Ref To Kafka Topic Names / ConsumerGroup IDs
+	refInfos, err := mapRefs(r.refMapper, snapshot.Namespace, snapshot.Name, snapshot.Spec.Ref)
+	if err != nil {
+		logger.Error("Failed to map ConsumerGroupSnapshot.Spec.Ref to Kafka Topic name and ConsumerGroup ID", zap.Error(err))
+		snapshot.Status.MarkRefMappedFailed("FailedToMapRef", "Failed to map 'ref' to Kafka Topic and Group: %v", err)
+		return fmt.Errorf("failed to map 'ref' to Kafka Topic and Group: %v", err)
+	}
+	logger.Info("Successfully mapped ConsumerGroupSnapshot.Spec.Ref", zap.Int("Count", len(refInfos)))
+	snapshot.Status.MarkRefMappedTrue()
+
+	// Capture The Committed Offsets Of Each ConsumerGroup
+	snapshotTime := metav1.Now()
+	groupOffsets := make([]kafkav1alpha1.ConsumerGroupOffsets, len(refInfos))
+	for index, refInfo := range refInfos {
+		partitionOffsets, err := captureOffsets(ctx, r.kafkaBrokers, r.saramaConfig, refInfo)
+		if err != nil {
+			logger.Error("Failed to capture Offsets of ConsumerGroup Partitions", zap.String("Group", refInfo.GroupId), zap.Error(err))
+			snapshot.Status.MarkOffsetsCapturedFailed("FailedToCaptureOffsets", "Failed to capture Offsets of ConsumerGroup '%s': %v", refInfo.GroupId, err)
+			return fmt.Errorf("failed to capture Offsets of ConsumerGroup '%s': %v", refInfo.GroupId, err)
+		}
+		groupOffsets[index] = kafkav1alpha1.ConsumerGroupOffsets{
+			Topic:      refInfo.TopicName,
+			Group:      refInfo.GroupId,
+			Partitions: partitionOffsets,
+		}
+	}
+	snapshot.Status.SetSnapshotTime(&snapshotTime)
+	snapshot.Status.SetGroups(groupOffsets)
+	logger.Info("Successfully captured Offsets of all ConsumerGroups")
+	snapshot.Status.MarkOffsetsCapturedTrue()
+
+	// Return Reconciled Success Event
+	return reconciler.NewEvent(corev1.EventTypeNormal, ConsumerGroupSnapshotReconciled.String(), "Reconciled successfully")
+}
+
+// mapRefs maps the specified Ref to one or more RefInfos using the ResetOffsetRefMapper.  The RefMappers
+// operate on ResetOffsets, so an equivalent ResetOffset is used for the mapping.  It is shared by the
+// ConsumerGroupSnapshot and ConsumerGroupPause Reconcilers.
+func mapRefs(refMapper refmappers.ResetOffsetRefMapper, namespace string, name string, ref duckv1.KReference) ([]*refmappers.RefInfo, error) {
+
+	// Create An Equivalent ResetOffset For Use With The RefMapper
+	resetOffset := &kafkav1alpha1.ResetOffset{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       kafkav1alpha1.ResetOffsetSpec{Ref: ref},
+	}
+
+	// Refs Which Expand To Multiple ConsumerGroups (e.g. KafkaChannel) Are Mapped Via MapRefs()
+	if multiRefMapper, ok := refMapper.(refmappers.ResetOffsetMultiRefMapper); ok && multiRefMapper.IsMultiRef(resetOffset) {
+		return multiRefMapper.MapRefs(resetOffset)
+	}
+
+	// Otherwise Map The Single Ref
+	refInfo, err := refMapper.MapRef(resetOffset)
+	if err != nil {
+		return nil, err
+	}
+	return []*refmappers.RefInfo{refInfo}, nil
+}
+
+// updateKafkaConfig is the callback function that handles changes to the ConfigMap
+func (r *SnapshotReconciler) updateKafkaConfig(ctx context.Context, configMap *corev1.ConfigMap) {
+
+	// Validate Reconciler Reference (Sometimes nil on startup and can be ignored)
+	if r == nil {
+		return
+	}
+
+	// Load The Kafka Brokers & Sarama Config From The ConfigMap
+	kafkaBrokers, saramaConfig := loadKafkaConfig(ctx, configMap)
+	if saramaConfig == nil {
+		return
+	}
+
+	// Update Reconciler With New Config
+	r.kafkaBrokers = kafkaBrokers
+	r.saramaConfig = saramaConfig
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+	"knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers"
+	refmapperstesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/refmappers/testing"
+)
+
+// Test The SnapshotReconciler's ReconcileKind() Functionality
+func TestSnapshotReconciler_ReconcileKind(t *testing.T) {
+
+	// Test Data
+	kafkaBrokers := []string{controllertesting.Brokers}
+	saramaConfig := sarama.NewConfig()
+	topicName := controllertesting.TopicName
+	groupId1 := "TestGroupId1"
+	groupId2 := "TestGroupId2"
+	partition := int32(0)
+	offset1 := int64(100)
+	offset2 := int64(200)
+	testErr := fmt.Errorf("test-error")
+
+	// Create The RefInfos For The Two ConsumerGroups
+	refInfo1 := refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = groupId1 })
+	refInfo2 := refmapperstesting.NewRefInfo(func(refInfo *refmappers.RefInfo) { refInfo.GroupId = groupId2 })
+
+	// The Expected Captured ConsumerGroupOffsets
+	groupOffsets1 := kafkav1alpha1.ConsumerGroupOffsets{Topic: topicName, Group: groupId1, Partitions: []kafkav1alpha1.PartitionOffset{{Partition: partition, Offset: offset1}}}
+	groupOffsets2 := kafkav1alpha1.ConsumerGroupOffsets{Topic: topicName, Group: groupId2, Partitions: []kafkav1alpha1.PartitionOffset{{Partition: partition, Offset: offset2}}}
+
+	// Define The Test Cases
+	tests := []struct {
+		name         string
+		snapshot     *kafkav1alpha1.ConsumerGroupSnapshot
+		multiRef     bool
+		mapErr       error
+		clientErr    error
+		wantErr      bool
+		wantEvent    string
+		wantGroups   []kafkav1alpha1.ConsumerGroupOffsets
+		wantCaptured bool
+	}{
+		{
+			name:         "Previously Succeeded",
+			snapshot:     controllertesting.NewConsumerGroupSnapshot(controllertesting.WithSnapshotStatusSucceeded),
+			wantEvent:    ConsumerGroupSnapshotSkipped.String(),
+			wantCaptured: true,
+		},
+		{
+			name:         "Single ConsumerGroup",
+			snapshot:     controllertesting.NewConsumerGroupSnapshot(),
+			wantEvent:    ConsumerGroupSnapshotReconciled.String(),
+			wantGroups:   []kafkav1alpha1.ConsumerGroupOffsets{groupOffsets1},
+			wantCaptured: true,
+		},
+		{
+			name:         "Multiple ConsumerGroups",
+			snapshot:     controllertesting.NewConsumerGroupSnapshot(),
+			multiRef:     true,
+			wantEvent:    ConsumerGroupSnapshotReconciled.String(),
+			wantGroups:   []kafkav1alpha1.ConsumerGroupOffsets{groupOffsets1, groupOffsets2},
+			wantCaptured: true,
+		},
+		{
+			name:     "Map Ref Failure",
+			snapshot: controllertesting.NewConsumerGroupSnapshot(),
+			mapErr:   testErr,
+			wantErr:  true,
+		},
+		{
+			name:      "Capture Offsets Failure",
+			snapshot:  controllertesting.NewConsumerGroupSnapshot(),
+			clientErr: testErr,
+			wantErr:   true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Context With Test Logger
+			ctx := logging.WithLogger(context.Background(), logtesting.TestLogger(t))
+
+			// Create The Mock RefMapper
+			mockMultiRefMapper := &refmapperstesting.MockResetOffsetMultiRefMapper{}
+			mockMultiRefMapper.On("IsMultiRef", mock.Anything).Return(test.multiRef)
+			if test.multiRef {
+				mockMultiRefMapper.On("MapRefs", mock.Anything).Return([]*refmappers.RefInfo{refInfo1, refInfo2}, test.mapErr)
+			} else {
+				mockMultiRefMapper.On("MapRef", mock.Anything).Return(refInfo1, test.mapErr)
+			}
+
+			// Stub The Sarama NewClient() Implementation To Return A Mock Sarama Client
+			client := controllertesting.NewMockClient(
+				controllertesting.WithClientMockPartitions(topicName, []int32{partition}, nil),
+				controllertesting.WithClientMockClosed(false),
+				controllertesting.WithClientMockClose(nil))
+			if test.clientErr != nil {
+				stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, nil, test.clientErr)
+			} else {
+				stubSaramaNewClientFn(t, kafkaBrokers, saramaConfig, client, nil)
+			}
+			defer restoreSaramaNewClientFn()
+
+			// Stub The Sarama NewOffsetManagerFromClient() Implementation To Return A Mock OffsetManager Per ConsumerGroup
+			offsets := map[string]int64{groupId1: offset1, groupId2: offset2}
+			SaramaNewOffsetManagerFromClientFn = func(group string, _ sarama.Client) (sarama.OffsetManager, error) {
+				return controllertesting.NewMockOffsetManager(
+					controllertesting.WithOffsetManagerMockManagePartition(topicName, partition,
+						controllertesting.NewMockPartitionOffsetManager(
+							controllertesting.WithPartitionOffsetManagerMockNextOffset(offsets[group], ""),
+							controllertesting.WithPartitionOffsetManagerMockErrors(),
+							controllertesting.WithPartitionOffsetManagerMockAsyncClose()), nil),
+					controllertesting.WithOffsetManagerMockClose(nil)), nil
+			}
+			defer restoreSaramaNewOffsetManagerFromClientFn()
+
+			// Create A SnapshotReconciler To Test
+			r := &SnapshotReconciler{
+				kafkaBrokers: kafkaBrokers,
+				saramaConfig: saramaConfig,
+				refMapper:    mockMultiRefMapper,
+			}
+
+			// Perform The Test
+			err := r.ReconcileKind(ctx, test.snapshot)
+
+			// Verify The Results
+			event := &reconciler.ReconcilerEvent{}
+			isNormalEvent := reconciler.EventAs(err, &event) && event.EventType == corev1.EventTypeNormal
+			assert.Equal(t, test.wantErr, !isNormalEvent)
+			if !test.wantErr {
+				assert.Equal(t, test.wantEvent, event.Reason)
+			}
+			assert.Equal(t, test.wantGroups, test.snapshot.Status.GetGroups())
+			assert.Equal(t, test.wantCaptured, test.snapshot.Status.IsSucceeded())
+			if test.wantGroups != nil {
+				assert.NotNil(t, test.snapshot.Status.GetSnapshotTime())
+			}
+		})
+	}
+}
+
+// Test The SnapshotReconciler's updateKafkaConfig() Functionality
+func TestSnapshotReconciler_UpdateKafkaConfig(t *testing.T) {
+
+	// Verify A Nil SnapshotReconciler Is Ignored
+	var nilReconciler *SnapshotReconciler
+	nilReconciler.updateKafkaConfig(context.TODO(), nil)
+
+	// Verify A Nil ConfigMap Does Not Change The Config
+	r := &SnapshotReconciler{}
+	r.updateKafkaConfig(logging.WithLogger(context.Background(), logtesting.TestLogger(t)), nil)
+	assert.Nil(t, r.kafkaBrokers)
+	assert.Nil(t, r.saramaConfig)
+}
@@ -39,6 +39,12 @@ const (
 
 	TopicName = "TestTopicName"
 	GroupId   = "TestGroupId"
+
+	SnapshotName = "snapshot-name"
+
+	UndoResetOffsetName = "undo-resetoffset-name"
+
+	PauseName = "consumergrouppause-name"
 )
 
 var DeletionTimestamp = metav1.Now()
@@ -91,6 +97,26 @@ func WithSpecOffsetTime(time string) ResetOffsetOption {
 	}
 }
 
+func WithSpecOffsetSnapshot(snapshotName string) ResetOffsetOption {
+	return func(resetOffset *kafkav1alpha1.ResetOffset) {
+		resetOffset.Spec.Offset.Time = ""
+		resetOffset.Spec.Offset.Snapshot = snapshotName
+	}
+}
+
+func WithSpecOffsetUndo(resetOffsetName string, maxProgress int64) ResetOffsetOption {
+	return func(resetOffset *kafkav1alpha1.ResetOffset) {
+		resetOffset.Spec.Offset.Time = ""
+		resetOffset.Spec.Offset.Undo = &kafkav1alpha1.OffsetUndoSpec{ResetOffset: resetOffsetName, MaxProgress: &maxProgress}
+	}
+}
+
+func WithName(name string) ResetOffsetOption {
+	return func(resetOffset *kafkav1alpha1.ResetOffset) {
+		resetOffset.Name = name
+	}
+}
+
 func WithSpecRef(ref *duckv1.KReference) ResetOffsetOption {
 	return func(resetOffset *kafkav1alpha1.ResetOffset) {
 		resetOffset.Spec.Ref = *ref
@@ -177,9 +203,126 @@ func WithStatusConsumerGroupsStarted(state bool, failed ...string) ResetOffsetOp
 	}
 }
 
+func WithStatusSucceeded(resetOffset *kafkav1alpha1.ResetOffset) {
+	resetOffset.Status.InitializeConditions()
+	resetOffset.Status.MarkRefMappedTrue()
+	resetOffset.Status.MarkAcquireDataPlaneServicesTrue()
+	resetOffset.Status.MarkConsumerGroupsStoppedTrue()
+	resetOffset.Status.MarkOffsetsUpdatedTrue()
+	resetOffset.Status.MarkConsumerGroupsStartedTrue()
+}
+
 func NewResetOffsetNamespacedName() types.NamespacedName {
 	return types.NamespacedName{
 		Namespace: ResetOffsetNamespace,
 		Name:      ResetOffsetName,
 	}
 }
+
+//
+// ConsumerGroupSnapshot Resources
+//
+
+// ConsumerGroupSnapshotOption allow for customizing a ConsumerGroupSnapshot
+type ConsumerGroupSnapshotOption func(snapshot *kafkav1alpha1.ConsumerGroupSnapshot)
+
+// NewConsumerGroupSnapshot creates a custom ConsumerGroupSnapshot in the ResetOffset's namespace
+func NewConsumerGroupSnapshot(options ...ConsumerGroupSnapshotOption) *kafkav1alpha1.ConsumerGroupSnapshot {
+
+	// Create The Base ConsumerGroupSnapshot
+	snapshot := &kafkav1alpha1.ConsumerGroupSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConsumerGroupSnapshot",
+			APIVersion: kafkav1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ResetOffsetNamespace,
+			Name:      SnapshotName,
+		},
+		Spec: kafkav1alpha1.ConsumerGroupSnapshotSpec{
+			Ref: duckv1.KReference{
+				APIVersion: RefAPIVersion,
+				Kind:       RefKind,
+				Namespace:  RefNamespace,
+				Name:       RefName,
+			},
+		},
+	}
+
+	// Apply The Specified Customizations
+	for _, option := range options {
+		option(snapshot)
+	}
+
+	// Return The Custom ConsumerGroupSnapshot
+	return snapshot
+}
+
+func WithSnapshotStatusGroups(groups []kafkav1alpha1.ConsumerGroupOffsets) ConsumerGroupSnapshotOption {
+	return func(snapshot *kafkav1alpha1.ConsumerGroupSnapshot) {
+		snapshot.Status.Groups = groups
+	}
+}
+
+func WithSnapshotStatusSucceeded(snapshot *kafkav1alpha1.ConsumerGroupSnapshot) {
+	snapshot.Status.InitializeConditions()
+	snapshot.Status.MarkRefMappedTrue()
+	snapshot.Status.MarkOffsetsCapturedTrue()
+}
+
+//
+// ConsumerGroupPause Resources
+//
+
+// ConsumerGroupPauseOption allow for customizing a ConsumerGroupPause
+type ConsumerGroupPauseOption func(pause *kafkav1alpha1.ConsumerGroupPause)
+
+// NewConsumerGroupPause creates a custom ConsumerGroupPause in the ResetOffset's namespace
+func NewConsumerGroupPause(options ...ConsumerGroupPauseOption) *kafkav1alpha1.ConsumerGroupPause {
+
+	// Create The Base ConsumerGroupPause
+	pause := &kafkav1alpha1.ConsumerGroupPause{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConsumerGroupPause",
+			APIVersion: kafkav1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ResetOffsetNamespace,
+			Name:      PauseName,
+		},
+		Spec: kafkav1alpha1.ConsumerGroupPauseSpec{
+			Ref: duckv1.KReference{
+				APIVersion: RefAPIVersion,
+				Kind:       RefKind,
+				Namespace:  RefNamespace,
+				Name:       RefName,
+			},
+		},
+	}
+
+	// Apply The Specified Customizations
+	for _, option := range options {
+		option(pause)
+	}
+
+	// Return The Custom ConsumerGroupPause
+	return pause
+}
+
+func WithPauseWindow(schedule string, duration string) ConsumerGroupPauseOption {
+	return func(pause *kafkav1alpha1.ConsumerGroupPause) {
+		pause.Spec.Windows = append(pause.Spec.Windows, kafkav1alpha1.PauseWindow{Schedule: schedule, Duration: duration})
+	}
+}
+
+func WithPauseStatusPaused(pause *kafkav1alpha1.ConsumerGroupPause) {
+	pause.Status.Paused = true
+}
+
+// NewConsumerGroupPauseNamespacedName returns a NamespacedName for the default ConsumerGroupPause
+func NewConsumerGroupPauseNamespacedName() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: ResetOffsetNamespace,
+		Name:      PauseName,
+	}
+}
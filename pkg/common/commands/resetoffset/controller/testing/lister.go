@@ -80,3 +80,7 @@ func (l *Listers) GetAllObjects() []runtime.Object {
 func (l *Listers) GetResetOffsetLister() resetoffsetlisters.ResetOffsetLister {
 	return resetoffsetlisters.NewResetOffsetLister(l.indexerFor(&kafkav1alpha1.ResetOffset{}))
 }
+
+func (l *Listers) GetConsumerGroupSnapshotLister() resetoffsetlisters.ConsumerGroupSnapshotLister {
+	return resetoffsetlisters.NewConsumerGroupSnapshotLister(l.indexerFor(&kafkav1alpha1.ConsumerGroupSnapshot{}))
+}
@@ -20,21 +20,21 @@ import (
 	"fmt"
 	"hash/fnv"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "knative.dev/control-protocol/pkg"
-
-	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
 )
 
-// GenerateLockToken returns a string representing a Lock.Token unique to the specified Reconciler and ResetOffset
-func GenerateLockToken(reconcilerId types.UID, resetOffsetId types.UID) string {
-	return fmt.Sprintf("%s-%s", string(reconcilerId), string(resetOffsetId))
+// GenerateLockToken returns a string representing a Lock.Token unique to the specified Reconciler and resource (ResetOffset, etc.)
+func GenerateLockToken(reconcilerId types.UID, resourceId types.UID) string {
+	return fmt.Sprintf("%s-%s", string(reconcilerId), string(resourceId))
 }
 
-// GenerateCommandId returns an int64 hash based on the specified ResetOffset.
-func GenerateCommandId(resetOffset *kafkav1alpha1.ResetOffset, podIP string, opCode ctrl.OpCode) (int64, error) {
+// GenerateCommandId returns an int64 hash based on the specified resource (ResetOffset, etc.)  The ConsumerGroup ID is included
+// so that the commands for multiple ConsumerGroups of a single ResetOffset (e.g. KafkaChannel Ref) are distinct.
+func GenerateCommandId(resource metav1.Object, podIP string, groupId string, opCode ctrl.OpCode) (int64, error) {
 	hash := fnv.New32a()
-	_, err := hash.Write([]byte(fmt.Sprintf("%s-%d-%s-%d", string(resetOffset.UID), resetOffset.Generation, podIP, opCode)))
+	_, err := hash.Write([]byte(fmt.Sprintf("%s-%d-%s-%s-%d", string(resource.GetUID()), resource.GetGeneration(), podIP, groupId, opCode)))
 	if err != nil {
 		return -1, err
 	}
@@ -55,12 +55,18 @@ func TestGenerateCommandId(t *testing.T) {
 		},
 	}
 	podIP := "TestPodIP"
+	groupId := "TestGroupId"
 	opCode := commands.StopConsumerGroupOpCode
 
 	// Perform The Test
-	actualCommandId, err := GenerateCommandId(resetOffset, podIP, opCode)
+	actualCommandId, err := GenerateCommandId(resetOffset, podIP, groupId, opCode)
 
 	// Verify The Results
 	assert.Nil(t, err)
-	assert.Equal(t, int64(3448459854), actualCommandId)
+	assert.Equal(t, int64(3415928255), actualCommandId)
+
+	// Verify Different ConsumerGroups Of The Same ResetOffset Result In Different CommandIDs
+	otherCommandId, err := GenerateCommandId(resetOffset, podIP, "OtherGroupId", opCode)
+	assert.Nil(t, err)
+	assert.NotEqual(t, actualCommandId, otherCommandId)
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/eventing/pkg/apis/messaging"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	subscriptioninformers "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
@@ -94,10 +95,16 @@ type SubscriptionDataPlaneNamespaceMapper func(subscription *messagingv1.Subscri
 // SubscriptionDataPlaneLabelsMapper defines a function signature for mapping a Subscription to the Kubernetes labels of the DataPlane Pods.
 type SubscriptionDataPlaneLabelsMapper func(subscription *messagingv1.Subscription) (map[string]string, error)
 
-// Verify The Subscription ResetOffsetRefMapper Implements The Interface
+// KafkaChannelKind is the Kind of a ResetOffset.Spec.Ref which expands to all Subscriptions of a KafkaChannel.
+const KafkaChannelKind = "KafkaChannel"
+
+// Verify The Subscription ResetOffsetRefMapper Implements The Interfaces
 var _ ResetOffsetRefMapper = &SubscriptionRefMapper{}
+var _ ResetOffsetMultiRefMapper = &SubscriptionRefMapper{}
 
-// SubscriptionRefMapper implements the ResetOffsetRefMapper for Knative Subscriptions
+// SubscriptionRefMapper implements the ResetOffsetRefMapper for Knative Subscriptions (and the Triggers
+// they back), as well as the ResetOffsetMultiRefMapper for KafkaChannels (expanding to all of the
+// KafkaChannel's Subscriptions).
 type SubscriptionRefMapper struct {
 	logger                   *zap.Logger
 	subscriptionLister       messaginglisters.SubscriptionLister
@@ -134,8 +141,9 @@ func NewSubscriptionRefMapper(ctx context.Context,
 	}
 }
 
-// MapRef implements the ResetOffsetRefMapper interface for Subscription references. It will return an
-// error in all cases other than successfully mapping the ResetOffset.Spec.Ref to a Kafka Topic / Group.
+// MapRef implements the ResetOffsetRefMapper interface for Subscription references, as well as Trigger
+// references of MT channel-based Brokers backed by KafkaChannels.  It will return an error in all cases
+// other than successfully mapping the ResetOffset.Spec.Ref to a Kafka Topic / Group.
 func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (*RefInfo, error) {
 
 	// Validate The ResetOffset
@@ -144,6 +152,11 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 		return nil, fmt.Errorf("unable to map nil ResetOffset")
 	}
 
+	// Map Trigger References Via Their Backing Subscription
+	if isTriggerRef(resetOffset) {
+		return m.mapTrigger(resetOffset)
+	}
+
 	// Get The ResetOffset Ref From Spec & Enhance Logger
 	ref := resetOffset.Spec.Ref
 	logger := m.logger.With(zap.Any("Ref", ref))
@@ -175,6 +188,16 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 		return nil, fmt.Errorf("no Subscription found for ResetOffset.Spec.Ref %v", ref)
 	}
 
+	// Map The Subscription To The RefInfo
+	return m.mapSubscription(logger, subscription)
+}
+
+// mapSubscription uses the custom mappers to create the RefInfo for the specified Subscription.
+func (m *SubscriptionRefMapper) mapSubscription(logger *zap.Logger, subscription *messagingv1.Subscription) (*RefInfo, error) {
+
+	// Create A Reference To The Subscription For Error Messages
+	ref := fmt.Sprintf("%s/%s", subscription.Namespace, subscription.Name)
+
 	// Map The Subscription To Kafka Topic Name Via Custom SubscriptionTopicNameMapper
 	topicName, err := m.topicNameMapper(subscription)
 	if err != nil {
@@ -222,3 +245,74 @@ func (m *SubscriptionRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset) (
 	// Successfully Mapped The Ref - Return Results
 	return refInfo, nil
 }
+
+// IsMultiRef implements the ResetOffsetMultiRefMapper interface and returns true if the
+// ResetOffset.Spec.Ref is a KafkaChannel which expands to all of its Subscriptions.
+func (m *SubscriptionRefMapper) IsMultiRef(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	if resetOffset == nil {
+		return false
+	}
+	ref := resetOffset.Spec.Ref
+	return strings.HasPrefix(ref.APIVersion, messaging.GroupName) && ref.Kind == KafkaChannelKind
+}
+
+// MapRefs implements the ResetOffsetMultiRefMapper interface for KafkaChannel references.  It will
+// return the RefInfo of every Subscription to the referenced KafkaChannel, or an error if the
+// KafkaChannel has no Subscriptions or any one of them could not be mapped.
+func (m *SubscriptionRefMapper) MapRefs(resetOffset *kafkav1alpha1.ResetOffset) ([]*RefInfo, error) {
+
+	// Validate The ResetOffset
+	if resetOffset == nil {
+		m.logger.Warn("Received nil ResetOffset argument")
+		return nil, fmt.Errorf("unable to map nil ResetOffset")
+	}
+
+	// Get The ResetOffset Ref From Spec & Enhance Logger
+	ref := resetOffset.Spec.Ref
+	logger := m.logger.With(zap.Any("Ref", ref))
+
+	// Validate The Reference
+	if !m.IsMultiRef(resetOffset) {
+		logger.Warn("Received ResetOffset with non KafkaChannel reference")
+		return nil, fmt.Errorf("received ResetOffset with non KafkaChannel reference: %v", ref)
+	}
+	if ref.Name == "" {
+		logger.Warn("Received ResetOffset with unnamed KafkaChannel reference")
+		return nil, fmt.Errorf("received ResetOffset with unnamed KafkaChannel reference: %v", ref)
+	}
+
+	// Default Optional Ref.Namespace If Not Provided
+	refNamespace := ref.Namespace
+	if refNamespace == "" {
+		refNamespace = resetOffset.Namespace
+	}
+
+	// List All Subscriptions In The KafkaChannel's Namespace (Subscriptions Must Be Co-Located With Their Channel)
+	subscriptions, err := m.subscriptionLister.Subscriptions(refNamespace).List(labels.Everything())
+	if err != nil {
+		logger.Error("Failed to list Subscriptions of KafkaChannel referenced by ResetOffset", zap.Error(err))
+		return nil, fmt.Errorf("failed to list Subscriptions of KafkaChannel referenced by ResetOffset.Spec.Ref '%v': %v", ref, err)
+	}
+
+	// Map Each Subscription Of The KafkaChannel To A RefInfo
+	refInfos := make([]*RefInfo, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription == nil || subscription.Spec.Channel.Kind != KafkaChannelKind || subscription.Spec.Channel.Name != ref.Name {
+			continue
+		}
+		refInfo, err := m.mapSubscription(logger, subscription)
+		if err != nil {
+			return nil, err
+		}
+		refInfos = append(refInfos, refInfo)
+	}
+
+	// Fail If There Were No Subscriptions To Reset
+	if len(refInfos) == 0 {
+		logger.Info("No Subscriptions found for KafkaChannel referenced by ResetOffset")
+		return nil, fmt.Errorf("no Subscriptions found for KafkaChannel referenced by ResetOffset.Spec.Ref %v", ref)
+	}
+
+	// Successfully Mapped The Ref - Return Results
+	return refInfos, nil
+}
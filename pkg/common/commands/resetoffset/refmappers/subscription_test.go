@@ -41,6 +41,7 @@ import (
 const (
 	SubscriptionNamespace = "subscription-namespace"
 	SubscriptionName      = "subscription-name"
+	ChannelName           = "channel-name"
 
 	TopicName            = "TestTopicName"
 	GroupId              = "TestGroupId"
@@ -311,6 +312,152 @@ func TestResetOffsetSubscriptionRefMapper_MapRef(t *testing.T) {
 	}
 }
 
+func TestResetOffsetSubscriptionRefMapper_IsMultiRef(t *testing.T) {
+	subscriptionRefMapper := &SubscriptionRefMapper{logger: logtesting.TestLogger(t).Desugar()}
+	channelRef := &duckv1.KReference{Kind: KafkaChannelKind, APIVersion: "messaging.knative.dev/v1beta1", Name: ChannelName}
+	subscriptionRef := &duckv1.KReference{Kind: "Subscription", APIVersion: messagingv1.SchemeGroupVersion.String(), Name: SubscriptionName}
+	assert.True(t, subscriptionRefMapper.IsMultiRef(controllertesting.NewResetOffset(controllertesting.WithSpecRef(channelRef))))
+	assert.False(t, subscriptionRefMapper.IsMultiRef(controllertesting.NewResetOffset(controllertesting.WithSpecRef(subscriptionRef))))
+	assert.False(t, subscriptionRefMapper.IsMultiRef(nil))
+}
+
+func TestResetOffsetSubscriptionRefMapper_MapRefs(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	testErr := fmt.Errorf("test-error")
+
+	// Create Test Subscriptions To The KafkaChannel (And One To Another Channel)
+	newSubscription := func(name string, channelName string) *messagingv1.Subscription {
+		return &messagingv1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: SubscriptionNamespace, Name: name},
+			Spec: messagingv1.SubscriptionSpec{
+				Channel: duckv1.KReference{Kind: KafkaChannelKind, APIVersion: "messaging.knative.dev/v1beta1", Name: channelName},
+			},
+		}
+	}
+	subscription1 := newSubscription("subscription-1", ChannelName)
+	subscription2 := newSubscription("subscription-2", ChannelName)
+	otherSubscription := newSubscription("subscription-3", "other-channel")
+
+	// Create The KafkaChannel Reference
+	channelRef := &duckv1.KReference{
+		Kind:       KafkaChannelKind,
+		APIVersion: "messaging.knative.dev/v1beta1",
+		Namespace:  SubscriptionNamespace,
+		Name:       ChannelName,
+	}
+
+	// Create Mappers Which Derive The GroupId From The Subscription Name
+	groupIdMapper := func(subscription *messagingv1.Subscription) (string, error) {
+		return GroupId + "-" + subscription.Name, nil
+	}
+	topicNameMapper := func(*messagingv1.Subscription) (string, error) { return TopicName, nil }
+	connectionPoolKeyMapper := func(*messagingv1.Subscription) (string, error) { return ConnectionPoolKey, nil }
+	dataPlaneNamespaceMapper := func(*messagingv1.Subscription) (string, error) { return DataPlaneNamespace, nil }
+	dataPlaneLabelsMapper := func(*messagingv1.Subscription) (map[string]string, error) { return DataPlaneLabels, nil }
+	newRefInfo := func(subscription *messagingv1.Subscription) *RefInfo {
+		return &RefInfo{
+			TopicName:          TopicName,
+			GroupId:            GroupId + "-" + subscription.Name,
+			ConnectionPoolKey:  ConnectionPoolKey,
+			DataPlaneNamespace: DataPlaneNamespace,
+			DataPlaneLabels:    DataPlaneLabels,
+		}
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name             string
+		subscriptions    []*messagingv1.Subscription
+		subscriptionsErr error
+		resetOffset      *kafkav1alpha1.ResetOffset
+		groupIdMapper    SubscriptionConsumerGroupIdMapper
+		wantRefInfos     []*RefInfo
+		wantErr          bool
+	}{
+		{
+			name:          "Success",
+			subscriptions: []*messagingv1.Subscription{subscription1, otherSubscription, subscription2},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(channelRef)),
+			groupIdMapper: groupIdMapper,
+			wantRefInfos:  []*RefInfo{newRefInfo(subscription1), newRefInfo(subscription2)},
+		},
+		{
+			name:        "Nil ResetOffset",
+			resetOffset: nil,
+			wantErr:     true,
+		},
+		{
+			name: "Non KafkaChannel Ref",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       "Subscription",
+				APIVersion: messagingv1.SchemeGroupVersion.String(),
+				Name:       SubscriptionName,
+			})),
+			wantErr: true,
+		},
+		{
+			name: "KafkaChannel Ref Without Name",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       KafkaChannelKind,
+				APIVersion: "messaging.knative.dev/v1beta1",
+			})),
+			wantErr: true,
+		},
+		{
+			name:             "Subscription List Error",
+			subscriptionsErr: testErr,
+			resetOffset:      controllertesting.NewResetOffset(controllertesting.WithSpecRef(channelRef)),
+			wantErr:          true,
+		},
+		{
+			name:          "No Subscriptions",
+			subscriptions: []*messagingv1.Subscription{otherSubscription},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(channelRef)),
+			groupIdMapper: groupIdMapper,
+			wantErr:       true,
+		},
+		{
+			name:          "GroupId Mapper Error",
+			subscriptions: []*messagingv1.Subscription{subscription1, subscription2},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(channelRef)),
+			groupIdMapper: func(*messagingv1.Subscription) (string, error) { return "", testErr },
+			wantErr:       true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Mock SubscriptionLister To Return The Test Subscriptions
+			mockSubscriptionNamespaceLister := &MockSubscriptionNamespaceLister{}
+			mockSubscriptionNamespaceLister.On("List", labels.Everything()).Return(test.subscriptions, test.subscriptionsErr)
+			mockSubscriptionLister := &MockSubscriptionLister{}
+			mockSubscriptionLister.On("Subscriptions", SubscriptionNamespace).Return(mockSubscriptionNamespaceLister)
+
+			// Create A New SubscriptionRefMapper To Test
+			subscriptionRefMapper := &SubscriptionRefMapper{
+				logger:                   logger,
+				subscriptionLister:       mockSubscriptionLister,
+				topicNameMapper:          topicNameMapper,
+				groupIdMapper:            test.groupIdMapper,
+				connectionPoolKeyMapper:  connectionPoolKeyMapper,
+				dataPlaneNamespaceMapper: dataPlaneNamespaceMapper,
+				dataPlaneLabelsMapper:    dataPlaneLabelsMapper,
+			}
+
+			// Perform The Test - Map A KafkaChannel To The Kafka Topic Name & ConsumerGroup IDs Of Its Subscriptions
+			refInfos, err := subscriptionRefMapper.MapRefs(test.resetOffset)
+
+			// Validate The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantRefInfos, refInfos)
+		})
+	}
+}
+
 //
 // Mock SubscriptionLister
 //
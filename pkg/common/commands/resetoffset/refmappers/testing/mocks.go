@@ -54,3 +54,23 @@ func (m *MockResetOffsetRefMapper) MapRef(resetOffset *kafkav1alpha1.ResetOffset
 	args := m.Called(resetOffset)
 	return args.Get(0).(*refmappers.RefInfo), args.Error(1)
 }
+
+//
+// Mock ResetOffsetMultiRefMapper
+//
+
+var _ refmappers.ResetOffsetMultiRefMapper = &MockResetOffsetMultiRefMapper{}
+
+type MockResetOffsetMultiRefMapper struct {
+	MockResetOffsetRefMapper
+}
+
+func (m *MockResetOffsetMultiRefMapper) IsMultiRef(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	args := m.Called(resetOffset)
+	return args.Bool(0)
+}
+
+func (m *MockResetOffsetMultiRefMapper) MapRefs(resetOffset *kafkav1alpha1.ResetOffset) ([]*refmappers.RefInfo, error) {
+	args := m.Called(resetOffset)
+	return args.Get(0).([]*refmappers.RefInfo), args.Error(1)
+}
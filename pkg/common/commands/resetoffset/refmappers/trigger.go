@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/eventing/pkg/apis/eventing"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+)
+
+// TriggerKind is the Kind of a ResetOffset.Spec.Ref which maps to the Subscription backing a Trigger
+// of an MT channel-based Broker.
+const TriggerKind = "Trigger"
+
+// isTriggerRef returns true if the ResetOffset.Spec.Ref is an eventing Trigger.
+func isTriggerRef(resetOffset *kafkav1alpha1.ResetOffset) bool {
+	ref := resetOffset.Spec.Ref
+	return strings.HasPrefix(ref.APIVersion, eventing.GroupName) && ref.Kind == TriggerKind
+}
+
+// mapTrigger maps a Trigger reference to the RefInfo of the Subscription which the MT channel-based
+// Broker created for it.  Such Subscriptions are owned (controlled) by their Trigger and subscribe to
+// the Broker's "trigger" channel, which must be a KafkaChannel in order to be repositioned.
+func (m *SubscriptionRefMapper) mapTrigger(resetOffset *kafkav1alpha1.ResetOffset) (*RefInfo, error) {
+
+	// Get The ResetOffset Ref From Spec & Enhance Logger
+	ref := resetOffset.Spec.Ref
+	logger := m.logger.With(zap.Any("Ref", ref))
+
+	// Validate The Reference
+	if ref.Name == "" {
+		logger.Warn("Received ResetOffset with unnamed Trigger reference")
+		return nil, fmt.Errorf("received ResetOffset with unnamed Trigger reference: %v", ref)
+	}
+
+	// Default Optional Ref.Namespace If Not Provided
+	refNamespace := ref.Namespace
+	if refNamespace == "" {
+		refNamespace = resetOffset.Namespace
+	}
+
+	// List All Subscriptions In The Trigger's Namespace (The Broker Creates Them Alongside The Trigger)
+	subscriptions, err := m.subscriptionLister.Subscriptions(refNamespace).List(labels.Everything())
+	if err != nil {
+		logger.Error("Failed to list Subscriptions of Trigger referenced by ResetOffset", zap.Error(err))
+		return nil, fmt.Errorf("failed to list Subscriptions of Trigger referenced by ResetOffset.Spec.Ref '%v': %v", ref, err)
+	}
+
+	// Find The Subscription Controlled By The Trigger
+	var triggerSubscription *messagingv1.Subscription
+	for _, subscription := range subscriptions {
+		if subscription == nil || !isControlledByTrigger(subscription, ref.Name) {
+			continue
+		}
+		if triggerSubscription != nil {
+			logger.Warn("Found multiple Subscriptions for Trigger referenced by ResetOffset")
+			return nil, fmt.Errorf("found multiple Subscriptions for Trigger referenced by ResetOffset.Spec.Ref %v", ref)
+		}
+		triggerSubscription = subscription
+	}
+	if triggerSubscription == nil {
+		logger.Info("No Subscription found for Trigger referenced by ResetOffset")
+		return nil, fmt.Errorf("no Subscription found for Trigger referenced by ResetOffset.Spec.Ref %v", ref)
+	}
+
+	// Only KafkaChannel Backed Brokers Can Be Repositioned
+	if triggerSubscription.Spec.Channel.Kind != KafkaChannelKind {
+		logger.Warn("Trigger referenced by ResetOffset is not backed by a KafkaChannel", zap.Any("Channel", triggerSubscription.Spec.Channel))
+		return nil, fmt.Errorf("trigger referenced by ResetOffset.Spec.Ref %v is not backed by a KafkaChannel", ref)
+	}
+
+	// Map The Trigger's Subscription To The RefInfo
+	return m.mapSubscription(logger, triggerSubscription)
+}
+
+// isControlledByTrigger returns true if the specified Subscription's controlling OwnerReference is the named Trigger.
+func isControlledByTrigger(subscription *messagingv1.Subscription, triggerName string) bool {
+	ownerRef := metav1.GetControllerOf(subscription)
+	return ownerRef != nil &&
+		strings.HasPrefix(ownerRef.APIVersion, eventing.GroupName) &&
+		ownerRef.Kind == TriggerKind &&
+		ownerRef.Name == triggerName
+}
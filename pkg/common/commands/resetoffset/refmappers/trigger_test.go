@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refmappers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+
+	kafkav1alpha1 "knative.dev/eventing-kafka/pkg/apis/kafka/v1alpha1"
+	controllertesting "knative.dev/eventing-kafka/pkg/common/commands/resetoffset/controller/testing"
+)
+
+const (
+	TriggerName = "trigger-name"
+)
+
+// Test The SubscriptionRefMapper's MapRef() Functionality For Trigger References
+func TestResetOffsetSubscriptionRefMapper_MapRef_Trigger(t *testing.T) {
+
+	// Test Data
+	logger := logtesting.TestLogger(t).Desugar()
+	testErr := fmt.Errorf("test-error")
+
+	// Create Test Subscriptions Controlled By Triggers (Or Not At All)
+	newSubscription := func(name string, channelKind string, triggerName string) *messagingv1.Subscription {
+		subscription := &messagingv1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: SubscriptionNamespace, Name: name},
+			Spec: messagingv1.SubscriptionSpec{
+				Channel: duckv1.KReference{Kind: channelKind, APIVersion: "messaging.knative.dev/v1beta1", Name: "default-kne-trigger"},
+			},
+		}
+		if triggerName != "" {
+			subscription.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: eventingv1.SchemeGroupVersion.String(),
+				Kind:       TriggerKind,
+				Name:       triggerName,
+				Controller: ptr.Bool(true),
+			}}
+		}
+		return subscription
+	}
+	triggerSubscription := newSubscription(SubscriptionName, KafkaChannelKind, TriggerName)
+	duplicateSubscription := newSubscription("duplicate-subscription", KafkaChannelKind, TriggerName)
+	otherTriggerSubscription := newSubscription("other-trigger-subscription", KafkaChannelKind, "other-trigger")
+	unownedSubscription := newSubscription("unowned-subscription", KafkaChannelKind, "")
+	inMemorySubscription := newSubscription(SubscriptionName, "InMemoryChannel", TriggerName)
+
+	// Create The Trigger Reference
+	triggerRef := &duckv1.KReference{
+		Kind:       TriggerKind,
+		APIVersion: eventingv1.SchemeGroupVersion.String(),
+		Namespace:  SubscriptionNamespace,
+		Name:       TriggerName,
+	}
+
+	// Define The Test Cases
+	tests := []struct {
+		name             string
+		subscriptions    []*messagingv1.Subscription
+		subscriptionsErr error
+		resetOffset      *kafkav1alpha1.ResetOffset
+		wantRefInfo      *RefInfo
+		wantErr          bool
+	}{
+		{
+			name:          "Success",
+			subscriptions: []*messagingv1.Subscription{unownedSubscription, otherTriggerSubscription, triggerSubscription},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantRefInfo: &RefInfo{
+				TopicName:          TopicName,
+				GroupId:            GroupId,
+				ConnectionPoolKey:  ConnectionPoolKey,
+				DataPlaneNamespace: DataPlaneNamespace,
+				DataPlaneLabels:    DataPlaneLabels,
+			},
+		},
+		{
+			name: "Trigger Ref Without Name",
+			resetOffset: controllertesting.NewResetOffset(controllertesting.WithSpecRef(&duckv1.KReference{
+				Kind:       TriggerKind,
+				APIVersion: eventingv1.SchemeGroupVersion.String(),
+			})),
+			wantErr: true,
+		},
+		{
+			name:             "Subscription List Error",
+			subscriptionsErr: testErr,
+			resetOffset:      controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantErr:          true,
+		},
+		{
+			name:          "No Trigger Subscription",
+			subscriptions: []*messagingv1.Subscription{unownedSubscription, otherTriggerSubscription},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantErr:       true,
+		},
+		{
+			name:          "Multiple Trigger Subscriptions",
+			subscriptions: []*messagingv1.Subscription{triggerSubscription, duplicateSubscription},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantErr:       true,
+		},
+		{
+			name:          "Non KafkaChannel Broker",
+			subscriptions: []*messagingv1.Subscription{inMemorySubscription},
+			resetOffset:   controllertesting.NewResetOffset(controllertesting.WithSpecRef(triggerRef)),
+			wantErr:       true,
+		},
+	}
+
+	// Execute The Test Cases
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Create A Mock SubscriptionLister To Return The Test Subscriptions
+			mockSubscriptionNamespaceLister := &MockSubscriptionNamespaceLister{}
+			mockSubscriptionNamespaceLister.On("List", labels.Everything()).Return(test.subscriptions, test.subscriptionsErr)
+			mockSubscriptionLister := &MockSubscriptionLister{}
+			mockSubscriptionLister.On("Subscriptions", SubscriptionNamespace).Return(mockSubscriptionNamespaceLister)
+
+			// Create A New SubscriptionRefMapper To Test
+			subscriptionRefMapper := &SubscriptionRefMapper{
+				logger:                   logger,
+				subscriptionLister:       mockSubscriptionLister,
+				topicNameMapper:          newMockSubscriptionTopicNameMapper(t, triggerSubscription, TopicName, nil),
+				groupIdMapper:            newMockSubscriptionConsumerGroupIdMapper(t, triggerSubscription, GroupId, nil),
+				connectionPoolKeyMapper:  newMockSubscriptionConnectionPoolKeyMapper(t, triggerSubscription, ConnectionPoolKey, nil),
+				dataPlaneNamespaceMapper: newMockSubscriptionDataPlaneNamespaceMapper(t, triggerSubscription, DataPlaneNamespace, nil),
+				dataPlaneLabelsMapper:    newMockSubscriptionDataPlaneLabelsMapper(t, triggerSubscription, DataPlaneLabels, nil),
+			}
+
+			// Perform The Test - Map A Trigger To The Kafka Topic Name & ConsumerGroup ID Of Its Subscription
+			refInfo, err := subscriptionRefMapper.MapRef(test.resetOffset)
+
+			// Validate The Results
+			assert.Equal(t, test.wantErr, err != nil)
+			assert.Equal(t, test.wantRefInfo, refInfo)
+		})
+	}
+}
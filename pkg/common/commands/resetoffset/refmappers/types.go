@@ -37,6 +37,16 @@ type ResetOffsetRefMapper interface {
 	MapRef(*kafkav1alpha1.ResetOffset) (*RefInfo, error)
 }
 
+// ResetOffsetMultiRefMapper is an optional extension of the ResetOffsetRefMapper interface for
+// implementations which support ResetOffset.Spec.Ref types that expand to multiple Kafka
+// ConsumerGroups (e.g. a KafkaChannel and all of its Subscriptions).  The Reconciler will use
+// MapRefs() instead of MapRef() whenever IsMultiRef() returns true for a ResetOffset.
+type ResetOffsetMultiRefMapper interface {
+	ResetOffsetRefMapper
+	IsMultiRef(*kafkav1alpha1.ResetOffset) bool
+	MapRefs(*kafkav1alpha1.ResetOffset) ([]*RefInfo, error)
+}
+
 // RefInfo contains the data necessary for ResetOffset reconciliation which is specific
 // to a particular use-case, as provided by a customized ResetOffsetRefMapper implementation.
 // This allows implementations of Kafka Channels/Brokers/etc to differ from one another
@@ -20,6 +20,7 @@ import (
 	"github.com/Shopify/sarama"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/client"
 )
 
@@ -73,9 +74,10 @@ type EKSourceConfig struct {
 // EKChannelConfig contains items relevant to the eventing-kafka channels
 // NOTE:  Currently the consolidated channel type does not make use of most of these fields
 type EKChannelConfig struct {
-	Dispatcher EKDispatcherConfig `json:"dispatcher,omitempty"` // Consolidated and Distributed channels
-	Receiver   EKReceiverConfig   `json:"receiver,omitempty"`   // Distributed channel only
-	AdminType  string             `json:"adminType,omitempty"`  // Distributed channel only
+	Dispatcher     EKDispatcherConfig            `json:"dispatcher,omitempty"`     // Consolidated and Distributed channels
+	Receiver       EKReceiverConfig              `json:"receiver,omitempty"`       // Distributed channel only
+	AdminType      string                        `json:"adminType,omitempty"`      // Distributed channel only
+	IsolationLevel sourcesv1beta1.IsolationLevel `json:"isolationLevel,omitempty"` // Consolidated and Distributed channels
 }
 
 // EKSaramaConfig holds the sarama.Config struct (populated separately), and the global Sarama debug logging flag
@@ -36,6 +36,35 @@ type KafkaConsumerHandler interface {
 	GetConsumerGroup() string
 }
 
+// KafkaConsumerBatchHandler is a KafkaConsumerHandler which can also handle
+// the messages of a partition in batches, see WithBatching.
+type KafkaConsumerBatchHandler interface {
+	KafkaConsumerHandler
+
+	// When this function returns true, the consumer group offsets of all the messages are marked as consumed.
+	// Otherwise the messages are handled again (after a backoff) until they are marked, or the session closes.
+	// The returned error is enqueued in errors channel.
+	HandleBatch(context context.Context, messages []*sarama.ConsumerMessage) (bool, error)
+}
+
+// BatchConfig defines when the batches of messages are handled.
+type BatchConfig struct {
+	// MaxMessages is the maximum number of messages of a batch.
+	MaxMessages int
+	// MaxBytes is the maximum size of the keys and values of the messages of a batch.
+	// The size of the batches is not limited when 0.
+	MaxBytes int64
+	// MaxWait is the maximum duration a batch waits for more messages.
+	MaxWait time.Duration
+}
+
+// The backoff before a batch of messages which was not marked is handled again, doubled after each attempt
+// up to maxBatchRetryBackoff.
+var (
+	batchRetryBackoff    = time.Second
+	maxBatchRetryBackoff = time.Minute
+)
+
 type SaramaConsumerLifecycleListener interface {
 	// Setup is invoked when the consumer is joining the session
 	Setup(sess sarama.ConsumerGroupSession)
@@ -63,6 +92,14 @@ func WithTimeout(timeout time.Duration) SaramaConsumerHandlerOption {
 	}
 }
 
+// WithBatching configures the batching of the messages of each partition,
+// when the handler implements KafkaConsumerBatchHandler.
+func WithBatching(config BatchConfig) SaramaConsumerHandlerOption {
+	return func(handler *SaramaConsumerHandler) {
+		handler.batching = &config
+	}
+}
+
 // ConsumerHandler implements sarama.ConsumerGroupHandler and provides some glue code to simplify message handling
 // You must implement KafkaConsumerHandler and create a new SaramaConsumerHandler with it
 type SaramaConsumerHandler struct {
@@ -74,6 +111,9 @@ type SaramaConsumerHandler struct {
 
 	lifecycleListener SaramaConsumerLifecycleListener
 
+	// Batching configuration, messages are handled one by one when nil
+	batching *BatchConfig
+
 	logger *zap.SugaredLogger
 
 	// Errors channel
@@ -123,7 +163,12 @@ func (consumer *SaramaConsumerHandler) Cleanup(session sarama.ConsumerGroupSessi
 func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	consumer.logger.Infow(fmt.Sprintf("Starting partition consumer, topic: %s, partition: %d, initialOffset: %d", claim.Topic(), claim.Partition(), claim.InitialOffset()), zap.String("ConsumeGroup", consumer.handler.GetConsumerGroup()))
 	consumer.handler.SetReady(claim.Partition(), true)
-	c := make(chan bool)
+
+	if batchHandler, ok := consumer.handler.(KafkaConsumerBatchHandler); ok && consumer.batching != nil {
+		consumer.consumeClaimBatches(session, claim, batchHandler)
+		consumer.logger.Infof("Stopping partition consumer, topic: %s, partition: %d", claim.Topic(), claim.Partition())
+		return nil
+	}
 
 	// NOTE:
 	// Do not move the code below to a goroutine.
@@ -151,11 +196,7 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 			break
 		}
 
-		// We need to control when to cancel Handle calls so give it a downstream context
-		hctx, cancel := context.WithCancel(context.Background())
-
-		// Start Handle goroutine
-		go func() {
+		mustMark := consumer.handle(session, func(hctx context.Context) bool {
 			mustMark, err := consumer.handler.Handle(hctx, message)
 
 			if err != nil {
@@ -164,27 +205,8 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 				consumer.handler.SetReady(claim.Partition(), false)
 			}
 
-			c <- mustMark
-		}()
-
-		var mustMark bool
-		select {
-		case mustMark = <-c:
-			// Handle returned gracefully, call cancel to free the context resources.
-			cancel()
-		case <-session.Context().Done():
-			// Consumer session canceled, wait for in-flight request to finish before we hit a rebalance timeout
-			select {
-			case <-time.After(consumer.timeout):
-				// Handle still didn't return, cancel the in-flight request
-				cancel()
-				// Unblock the Handle goroutine
-				mustMark = <-c
-			case mustMark = <-c:
-				// Handle returned gracefully, call cancel to free the context resources.
-				cancel()
-			}
-		}
+			return mustMark
+		})
 
 		if mustMark {
 			session.MarkMessage(message, "") // Mark kafka message as processed
@@ -199,3 +221,146 @@ func (consumer *SaramaConsumerHandler) ConsumeClaim(session sarama.ConsumerGroup
 }
 
 var _ sarama.ConsumerGroupHandler = (*SaramaConsumerHandler)(nil)
+
+// consumeClaimBatches is the consumer loop of ConsumeClaim when batching is enabled. The messages are
+// aggregated until the batch is full, or until the oldest message of the batch waited for MaxWait.
+// A batch which is not marked is handled again until it is, since marking the next batch would also
+// mark its messages, and the loop stops when the session closes before then.
+func (consumer *SaramaConsumerHandler) consumeClaimBatches(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, handler KafkaConsumerBatchHandler) {
+	var batch []*sarama.ConsumerMessage
+	var batchBytes int64
+
+	timer := time.NewTimer(consumer.batching.MaxWait)
+	timer.Stop()
+	defer timer.Stop()
+
+	// flush handles the pending batch and returns false when it could not be marked before the session closed
+	flush := func() bool {
+		if len(batch) == 0 {
+			return true
+		}
+		messages := batch
+		batch, batchBytes = nil, 0
+		if !timer.Stop() {
+			// Drain the timer if it fired concurrently, so that it does not flush the next batch
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		for attempt := 0; ; attempt++ {
+			mustMark := consumer.handle(session, func(hctx context.Context) bool {
+				mustMark, err := handler.HandleBatch(hctx, messages)
+
+				if err != nil {
+					consumer.logger.Infow("Failure while handling a batch of messages", zap.String("topic", claim.Topic()), zap.Int32("partition", claim.Partition()),
+						zap.Int64("firstOffset", messages[0].Offset), zap.Int64("lastOffset", messages[len(messages)-1].Offset), zap.Int("attempt", attempt), zap.Error(err))
+					consumer.errors <- err
+					consumer.handler.SetReady(claim.Partition(), false)
+				}
+
+				return mustMark
+			})
+
+			if mustMark {
+				// Marking the last message marks all the messages of the partition before it as well
+				session.MarkMessage(messages[len(messages)-1], "")
+				if consumer.logger.Desugar().Core().Enabled(zap.DebugLevel) {
+					consumer.logger.Debugw("Batch marked", zap.String("topic", claim.Topic()), zap.Int("messages", len(messages)))
+				}
+				return true
+			}
+
+			// The messages of the batch are consumed again by the next session
+			if !waitBatchRetry(session, attempt) {
+				consumer.logger.Infof("Session closed for %s/%d before the batch was handled. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+				return false
+			}
+		}
+	}
+
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				flush()
+				return
+			}
+
+			// Preemptively interrupt processing messages if the session is closed,
+			// the messages of the pending batch are consumed again by the next session.
+			if session.Context().Err() != nil {
+				consumer.logger.Infof("Session closed for %s/%d. Exiting ConsumeClaim ", claim.Topic(), claim.Partition())
+				return
+			}
+
+			batch = append(batch, message)
+			batchBytes += int64(len(message.Key) + len(message.Value))
+			if len(batch) == 1 {
+				timer.Reset(consumer.batching.MaxWait)
+			}
+
+			if len(batch) >= consumer.batching.MaxMessages || (consumer.batching.MaxBytes > 0 && batchBytes >= consumer.batching.MaxBytes) {
+				if !flush() {
+					return
+				}
+			}
+		case <-timer.C:
+			if !flush() {
+				return
+			}
+		}
+	}
+}
+
+// waitBatchRetry waits for the backoff of the specified attempt to handle a batch, and returns false
+// if the session closed in the meantime.
+func waitBatchRetry(session sarama.ConsumerGroupSession, attempt int) bool {
+	backoff := maxBatchRetryBackoff
+	if attempt < 32 && batchRetryBackoff<<attempt < maxBatchRetryBackoff {
+		backoff = batchRetryBackoff << attempt
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-session.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// handle calls f in a new goroutine with a downstream context, which is canceled when f does not
+// return within the timeout after the session is closed. It returns whether the messages must be marked.
+func (consumer *SaramaConsumerHandler) handle(session sarama.ConsumerGroupSession, f func(context.Context) bool) bool {
+	// We need to control when to cancel Handle calls so give it a downstream context
+	hctx, cancel := context.WithCancel(context.Background())
+	c := make(chan bool)
+
+	// Start Handle goroutine
+	go func() {
+		c <- f(hctx)
+	}()
+
+	var mustMark bool
+	select {
+	case mustMark = <-c:
+		// Handle returned gracefully, call cancel to free the context resources.
+		cancel()
+	case <-session.Context().Done():
+		// Consumer session canceled, wait for in-flight request to finish before we hit a rebalance timeout
+		select {
+		case <-time.After(consumer.timeout):
+			// Handle still didn't return, cancel the in-flight request
+			cancel()
+			// Unblock the Handle goroutine
+			mustMark = <-c
+		case mustMark = <-c:
+			// Handle returned gracefully, call cancel to free the context resources.
+			cancel()
+		}
+	}
+	return mustMark
+}
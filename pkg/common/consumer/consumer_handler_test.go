@@ -20,7 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -39,7 +41,9 @@ var mockMessage = sarama.ConsumerMessage{
 }
 
 type mockConsumerGroupSession struct {
-	marked bool
+	ctx        context.Context
+	marked     bool
+	lastMarked *sarama.ConsumerMessage
 }
 
 func (m *mockConsumerGroupSession) Commit() {
@@ -66,9 +70,13 @@ func (m *mockConsumerGroupSession) ResetOffset(topic string, partition int32, of
 
 func (m *mockConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
 	m.marked = true
+	m.lastMarked = msg
 }
 
 func (m *mockConsumerGroupSession) Context() context.Context {
+	if m.ctx != nil {
+		return m.ctx
+	}
 	return context.Background()
 }
 
@@ -121,6 +129,50 @@ func (m mockMessageHandler) GetConsumerGroup() string {
 	return "consumer group"
 }
 
+type mockBatchClaim struct {
+	mockConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (m mockBatchClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return m.messages
+}
+
+type mockBatchHandler struct {
+	mockMessageHandler
+
+	// failures is the number of batches which fail before the mockMessageHandler is used
+	failures int
+
+	lock    sync.Mutex
+	batches [][]*sarama.ConsumerMessage
+	handled chan struct{}
+}
+
+func (m *mockBatchHandler) HandleBatch(ctx context.Context, messages []*sarama.ConsumerMessage) (bool, error) {
+	m.lock.Lock()
+	m.batches = append(m.batches, messages)
+	failed := len(m.batches) <= m.failures
+	m.lock.Unlock()
+	if m.handled != nil {
+		m.handled <- struct{}{}
+	}
+	if failed {
+		return false, errors.New("bla")
+	}
+	return m.mockMessageHandler.Handle(ctx, nil)
+}
+
+func (m *mockBatchHandler) batchSizes() []int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	sizes := make([]int, 0, len(m.batches))
+	for _, batch := range m.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
 //------ Tests
 
 func Test(t *testing.T) {
@@ -168,3 +220,139 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestConsumeClaimBatches(t *testing.T) {
+	tests := map[string]struct {
+		config    BatchConfig
+		messages  []*sarama.ConsumerMessage
+		wantSizes []int
+	}{
+		"max messages": {
+			config:    BatchConfig{MaxMessages: 2, MaxWait: time.Hour},
+			messages:  makeMessages(5, 1),
+			wantSizes: []int{2, 2, 1},
+		},
+		"max bytes": {
+			config:    BatchConfig{MaxMessages: 10, MaxBytes: 30, MaxWait: time.Hour},
+			messages:  makeMessages(5, 10),
+			wantSizes: []int{3, 2},
+		},
+	}
+	for n, test := range tests {
+		t.Run(n, func(t *testing.T) {
+			handler := &mockBatchHandler{mockMessageHandler: mockMessageHandler{shouldMark: true}}
+			cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, make(chan error, 1), WithBatching(test.config))
+
+			claim := mockBatchClaim{messages: make(chan *sarama.ConsumerMessage, len(test.messages))}
+			for _, message := range test.messages {
+				claim.messages <- message
+			}
+			close(claim.messages)
+
+			session := mockConsumerGroupSession{}
+			_ = cgh.ConsumeClaim(&session, claim)
+
+			if got := handler.batchSizes(); fmt.Sprint(got) != fmt.Sprint(test.wantSizes) {
+				t.Errorf("unexpected batch sizes, want %v, got %v", test.wantSizes, got)
+			}
+			if session.lastMarked != test.messages[len(test.messages)-1] {
+				t.Errorf("the last message was not marked")
+			}
+		})
+	}
+}
+
+func TestConsumeClaimBatchesMaxWait(t *testing.T) {
+	handler := &mockBatchHandler{
+		mockMessageHandler: mockMessageHandler{shouldErr: true},
+		handled:            make(chan struct{}, 1),
+	}
+	errorCh := make(chan error, 1)
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, errorCh, WithBatching(BatchConfig{MaxMessages: 10, MaxWait: 10 * time.Millisecond}))
+
+	claim := mockBatchClaim{messages: make(chan *sarama.ConsumerMessage)}
+	ctx, cancel := context.WithCancel(context.Background())
+	session := mockConsumerGroupSession{ctx: ctx}
+	done := make(chan struct{})
+	go func() {
+		_ = cgh.ConsumeClaim(&session, claim)
+		close(done)
+	}()
+
+	messages := makeMessages(2, 1)
+	claim.messages <- messages[0]
+	claim.messages <- messages[1]
+
+	select {
+	case <-handler.handled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the batch was not handled after the max wait")
+	}
+	if e := <-errorCh; e.Error() != "bla" {
+		t.Errorf("Wrong error received %v", e)
+	}
+
+	// The failed batch is retried until the session closes
+	cancel()
+	<-done
+
+	if got := handler.batchSizes(); fmt.Sprint(got) != fmt.Sprint([]int{2}) {
+		t.Errorf("unexpected batch sizes, want %v, got %v", []int{2}, got)
+	}
+	if session.marked {
+		t.Errorf("the batch should not be marked")
+	}
+}
+
+func TestConsumeClaimBatchesRetry(t *testing.T) {
+	defer func(backoff time.Duration) { batchRetryBackoff = backoff }(batchRetryBackoff)
+	batchRetryBackoff = time.Millisecond
+
+	handler := &mockBatchHandler{mockMessageHandler: mockMessageHandler{shouldMark: true}, failures: 1}
+	errorCh := make(chan error, 1)
+	cgh := NewConsumerHandler(zap.NewNop().Sugar(), handler, errorCh, WithBatching(BatchConfig{MaxMessages: 2, MaxWait: time.Hour}))
+
+	messages := makeMessages(4, 1)
+	claim := mockBatchClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	close(claim.messages)
+
+	session := &recordingConsumerGroupSession{}
+	_ = cgh.ConsumeClaim(session, claim)
+
+	if e := <-errorCh; e.Error() != "bla" {
+		t.Errorf("Wrong error received %v", e)
+	}
+
+	// The failed batch is handled again before the next one, and is marked
+	if got := handler.batchSizes(); fmt.Sprint(got) != fmt.Sprint([]int{2, 2, 2}) {
+		t.Errorf("unexpected batch sizes, want %v, got %v", []int{2, 2, 2}, got)
+	}
+	if handler.batches[0][0] != handler.batches[1][0] {
+		t.Errorf("the failed batch was not handled again")
+	}
+	if fmt.Sprint(session.markedOffsets) != fmt.Sprint([]int64{1, 3}) {
+		t.Errorf("unexpected marked offsets, want %v, got %v", []int64{1, 3}, session.markedOffsets)
+	}
+}
+
+// recordingConsumerGroupSession is a mockConsumerGroupSession which records the offsets of all the marked messages.
+type recordingConsumerGroupSession struct {
+	mockConsumerGroupSession
+	markedOffsets []int64
+}
+
+func (m *recordingConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	m.mockConsumerGroupSession.MarkMessage(msg, metadata)
+	m.markedOffsets = append(m.markedOffsets, msg.Offset)
+}
+
+func makeMessages(count int, size int) []*sarama.ConsumerMessage {
+	messages := make([]*sarama.ConsumerMessage, 0, count)
+	for i := 0; i < count; i++ {
+		messages = append(messages, &sarama.ConsumerMessage{Offset: int64(i), Value: make([]byte, size)})
+	}
+	return messages
+}
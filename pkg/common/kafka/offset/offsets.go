@@ -32,7 +32,10 @@ import (
 // is closed before at least one message is consumed from ALL partitions.
 // Without InitOffsets, an event sent to a partition with an uninitialized offset
 // will not be forwarded when the session is closed (or a rebalancing is in progress).
-func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) (int32, error) {
+// The uninitialized offsets are set to the initialOffset, which is either sarama.OffsetOldest,
+// sarama.OffsetNewest or a timestamp (millis since epoch). Partitions without any message
+// after the timestamp are set to their newest offset.
+func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string, initialOffset int64) (int32, error) {
 	offsetManager, err := sarama.NewOffsetManagerFromClient(consumerGroup, kafkaClient)
 	if err != nil {
 		return -1, err
@@ -44,10 +47,15 @@ func InitOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClien
 	}
 
 	// Fetch topic offsets
-	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	topicOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, initialOffset)
 	if err != nil {
 		return -1, fmt.Errorf("failed to get the topic offsets: %w", err)
 	}
+	if initialOffset >= 0 {
+		if err := newestOffsetsAfterTimestamp(kafkaClient, topicPartitions, topicOffsets); err != nil {
+			return -1, err
+		}
+	}
 
 	// Look for uninitialized offset (-1)
 	offsets, err := kafkaAdminClient.ListConsumerGroupOffsets(consumerGroup, topicPartitions)
@@ -117,6 +125,70 @@ func CheckIfAllOffsetsInitialized(kafkaClient sarama.Client, kafkaAdminClient sa
 	return true, nil
 }
 
+// GroupLag is the lag of a consumer group on a set of topics.
+type GroupLag struct {
+	// Total is the sum of the lag of all the partitions.
+	Total int64
+	// NewestOffsets is the sum of the newest offsets of all the partitions.
+	NewestOffsets int64
+}
+
+// ConsumerGroupLag returns the lag of the consumer group on the given topics,
+// which is the difference between the newest offsets and the offsets committed by
+// the consumer group. The partitions without committed offset have no lag.
+func ConsumerGroupLag(kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, consumerGroup string) (*GroupLag, error) {
+	_, topicPartitions, err := retrieveAllPartitions(topics, kafkaClient)
+	if err != nil {
+		return nil, err
+	}
+
+	newestOffsets, err := knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the newest topic offsets: %w", err)
+	}
+
+	offsets, err := kafkaAdminClient.ListConsumerGroupOffsets(consumerGroup, topicPartitions)
+	if err != nil {
+		return nil, err
+	}
+
+	lag := &GroupLag{}
+	for topic, partitionsOffsets := range newestOffsets {
+		for partitionID, newestOffset := range partitionsOffsets {
+			lag.NewestOffsets += newestOffset
+			block := offsets.GetBlock(topic, partitionID)
+			if block == nil || block.Offset == -1 || block.Offset >= newestOffset {
+				continue
+			}
+			lag.Total += newestOffset - block.Offset
+		}
+	}
+	return lag, nil
+}
+
+// newestOffsetsAfterTimestamp replaces the offsets of the partitions without any message after
+// the timestamp (-1) with their newest offset.
+func newestOffsetsAfterTimestamp(kafkaClient sarama.Client, topicPartitions map[string][]int32, topicOffsets map[string]map[int32]int64) error {
+	var newestOffsets map[string]map[int32]int64
+	for topic, partitionsOffsets := range topicOffsets {
+		for partitionID, offset := range partitionsOffsets {
+			if offset != -1 {
+				continue
+			}
+			if newestOffsets == nil {
+				var err error
+				if newestOffsets, err = knsarama.GetOffsets(kafkaClient, topicPartitions, sarama.OffsetNewest); err != nil {
+					return fmt.Errorf("failed to get the newest topic offsets: %w", err)
+				}
+			}
+			if newestOffset, ok := newestOffsets[topic][partitionID]; ok {
+				partitionsOffsets[partitionID] = newestOffset
+			}
+		}
+	}
+	return nil
+}
+
 func retrieveAllPartitions(topics []string, kafkaClient sarama.Client) (int, map[string][]int32, error) {
 	totalPartitions := 0
 
@@ -102,7 +102,7 @@ func TestInitOffsets(t *testing.T) {
 
 			// test InitOffsets
 			ctx := logtesting.TestContextWithLogger(t)
-			partitionCt, err := InitOffsets(ctx, sc, kac, tc.topics, group)
+			partitionCt, err := InitOffsets(ctx, sc, kac, tc.topics, group, sarama.OffsetNewest)
 			total := 0
 			for _, partitions := range tc.topicOffsets {
 				total += len(partitions)
@@ -198,3 +198,75 @@ func configureMockBroker(t *testing.T, group string, topicOffsets map[string]map
 		"MetadataRequest": metadataResponse,
 	})
 }
+
+func TestNewestOffsetsAfterTimestamp(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	topicOffsets := map[string]map[int32]int64{
+		"my-topic":   {0: 5, 1: 7},
+		"my-topic-2": {0: 5},
+	}
+	configureMockBroker(t, "my-group", topicOffsets, nil, false, broker)
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+
+	sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sc.Close()
+
+	// The partitions without any message after the timestamp start from their newest offset
+	timestampOffsets := map[string]map[int32]int64{
+		"my-topic":   {0: 2, 1: -1},
+		"my-topic-2": {0: -1},
+	}
+	topicPartitions := map[string][]int32{
+		"my-topic":   {0, 1},
+		"my-topic-2": {0},
+	}
+	err = newestOffsetsAfterTimestamp(sc, topicPartitions, timestampOffsets)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[int32]int64{
+		"my-topic":   {0: 2, 1: 7},
+		"my-topic-2": {0: 5},
+	}, timestampOffsets)
+}
+
+func TestConsumerGroupLag(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	topicOffsets := map[string]map[int32]int64{
+		"my-topic":   {0: 5, 1: 7},
+		"my-topic-2": {0: 10},
+	}
+	cgOffsets := map[string]map[int32]int64{
+		"my-topic":   {0: 2, 1: -1},
+		"my-topic-2": {0: 4},
+	}
+	configureMockBroker(t, group, topicOffsets, cgOffsets, true, broker)
+
+	config := sarama.NewConfig()
+	config.Version = sarama.MaxVersion
+
+	sc, err := sarama.NewClient([]string{broker.Addr()}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer sc.Close()
+
+	kac, err := sarama.NewClusterAdminFromClient(sc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer kac.Close()
+
+	// The partition without committed offset has no lag
+	lag, err := ConsumerGroupLag(sc, kac, []string{"my-topic", "my-topic-2"}, group)
+	assert.Nil(t, err)
+	assert.Equal(t, &GroupLag{Total: 9, NewestOffsets: 22}, lag)
+}
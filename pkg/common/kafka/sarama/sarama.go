@@ -109,6 +109,7 @@ func LoadSettings(ctx context.Context, clientId string, configMap map[string]str
 		FromYaml(saramaConfigString).
 		WithAuth(ekConfig.Auth).
 		WithClientId(clientId).
+		WithIsolationLevel(ekConfig.Channel.IsolationLevel).
 		Build(ctx)
 
 	return ekConfig, err
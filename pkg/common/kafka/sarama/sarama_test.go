@@ -511,11 +511,12 @@ func TestLoadSettings(t *testing.T) {
 
 	// Define The TestCase Struct
 	type TestCase struct {
-		name           string
-		config         map[string]string
-		authConfig     *client.KafkaAuthConfig
-		expectErr      bool
-		expectDefaults bool
+		name                 string
+		config               map[string]string
+		authConfig           *client.KafkaAuthConfig
+		expectErr            bool
+		expectDefaults       bool
+		expectIsolationLevel sarama.IsolationLevel
 	}
 
 	// Create The TestCases
@@ -553,6 +554,24 @@ func TestLoadSettings(t *testing.T) {
 			config:         map[string]string{constants.SaramaSettingsConfigKey: ""},
 			expectDefaults: true,
 		},
+		{
+			name: "Read Committed Channels",
+			config: map[string]string{
+				constants.VersionConfigKey:               constants.CurrentConfigVersion,
+				constants.SaramaSettingsConfigKey:        commontesting.OldSaramaConfig,
+				constants.EventingKafkaSettingsConfigKey: "channel:\n  isolationLevel: read_committed\n",
+			},
+			expectIsolationLevel: sarama.ReadCommitted,
+		},
+		{
+			name: "Invalid Isolation Level",
+			config: map[string]string{
+				constants.VersionConfigKey:               constants.CurrentConfigVersion,
+				constants.SaramaSettingsConfigKey:        commontesting.OldSaramaConfig,
+				constants.EventingKafkaSettingsConfigKey: "channel:\n  isolationLevel: serializable\n",
+			},
+			expectErr: true,
+		},
 		{
 			name:      "Nil Config",
 			config:    nil,
@@ -581,6 +600,7 @@ func TestLoadSettings(t *testing.T) {
 					assert.Equal(t, commontesting.OldUsername, settings.Sarama.Config.Net.SASL.User)
 					assert.Equal(t, commontesting.OldPassword, settings.Sarama.Config.Net.SASL.Password)
 				}
+				assert.Equal(t, testCase.expectIsolationLevel, settings.Sarama.Config.Consumer.IsolationLevel)
 			}
 		})
 	}
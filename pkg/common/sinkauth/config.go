@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinkauth
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+const (
+	// defaultServiceAccountName is the service account of the tokens when
+	// the SinkAuthSpec does not name one.
+	defaultServiceAccountName = "default"
+
+	// Environment variables of the receive adapters presenting credentials to
+	// their sink.
+	TokenEnvVar     = "K_SINK_AUTH_TOKEN"
+	TokenFileEnvVar = "K_SINK_AUTH_TOKEN_FILE"
+	TLSCertEnvVar   = "K_SINK_TLS_CERT"
+	TLSKeyEnvVar    = "K_SINK_TLS_KEY"
+	TLSCACertEnvVar = "K_SINK_TLS_CA_CERT"
+)
+
+// SinkEnvConfig holds the credentials presented to the sink, set in the
+// environment of the receive adapters.
+type SinkEnvConfig struct {
+	// Bearer token sent to the sink.
+	SinkAuthToken string `envconfig:"K_SINK_AUTH_TOKEN" required:"false"`
+
+	// File the bearer token sent to the sink is read from, like a projected
+	// service account token.
+	SinkAuthTokenFile string `envconfig:"K_SINK_AUTH_TOKEN_FILE" required:"false"`
+
+	// PEM encoded client certificate and key presented to the sink.
+	SinkTLSCert string `envconfig:"K_SINK_TLS_CERT" required:"false"`
+	SinkTLSKey  string `envconfig:"K_SINK_TLS_KEY" required:"false"`
+
+	// PEM encoded CA certificate verifying the sink.
+	SinkTLSCACert string `envconfig:"K_SINK_TLS_CA_CERT" required:"false"`
+}
+
+// Credentials returns the credentials of the environment, empty when none
+// is set.
+func (env *SinkEnvConfig) Credentials() (*Credentials, error) {
+	creds := &Credentials{}
+	if env.SinkAuthTokenFile != "" {
+		creds.Token = NewFileTokenSource(env.SinkAuthTokenFile)
+	} else if env.SinkAuthToken != "" {
+		creds.Token = StaticToken(env.SinkAuthToken)
+	}
+	if err := creds.SetTLS(env.SinkTLSCert, env.SinkTLSKey, env.SinkTLSCACert); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// FromSpec returns the credentials of spec, read from the secrets and
+// requested for the service accounts of namespace. The bearer tokens are
+// read again and renewed when sent, the certificates are read once. The
+// service accounts must allow the audience of their tokens.
+func FromSpec(ctx context.Context, kc kubernetes.Interface, namespace string, spec *sourcesv1beta1.SinkAuthSpec) (*Credentials, error) {
+	creds := &Credentials{}
+	if spec == nil {
+		return creds, nil
+	}
+
+	if spec.BearerToken != nil && spec.BearerToken.SecretKeyRef != nil {
+		creds.Token = NewSecretTokenSource(kc, namespace, spec.BearerToken.SecretKeyRef)
+	}
+
+	if sat := spec.ServiceAccountToken; sat != nil {
+		expirationSeconds := sourcesv1beta1.DefaultServiceAccountTokenExpirationSeconds
+		if sat.ExpirationSeconds != nil {
+			expirationSeconds = *sat.ExpirationSeconds
+		}
+		if err := CheckServiceAccountTokenSpec(ctx, kc, namespace, sat); err != nil {
+			return nil, err
+		}
+		creds.Token = NewServiceAccountTokenSource(kc, namespace, serviceAccountName(sat), sat.Audience, expirationSeconds)
+	}
+
+	if spec.TLS != nil {
+		var values [3]string
+		for i, value := range []bindingsv1beta1.SecretValueFromSource{spec.TLS.Cert, spec.TLS.Key, spec.TLS.CACert} {
+			if value.SecretKeyRef == nil {
+				continue
+			}
+			var err error
+			if values[i], err = readSecretValue(ctx, kc, namespace, value.SecretKeyRef); err != nil {
+				return nil, err
+			}
+		}
+		if err := creds.SetTLS(values[0], values[1], values[2]); err != nil {
+			return nil, err
+		}
+	}
+	return creds, nil
+}
+
+// CheckServiceAccountTokenSpec returns an error unless the service account of
+// spec, in namespace, allows the audience of spec (see CheckServiceAccountToken).
+func CheckServiceAccountTokenSpec(ctx context.Context, kc kubernetes.Interface, namespace string, spec *sourcesv1beta1.ServiceAccountTokenSpec) error {
+	return CheckServiceAccountToken(ctx, kc, namespace, serviceAccountName(spec), spec.Audience)
+}
+
+// serviceAccountName returns the service account of the tokens of spec.
+func serviceAccountName(spec *sourcesv1beta1.ServiceAccountTokenSpec) string {
+	if spec.ServiceAccountName == "" {
+		return defaultServiceAccountName
+	}
+	return spec.ServiceAccountName
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinkauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/ptr"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestSinkEnvConfigCredentials(t *testing.T) {
+	cert, key := generateCert(t, "client")
+
+	creds, err := (&SinkEnvConfig{}).Credentials()
+	require.NoError(t, err)
+	assert.True(t, creds.IsEmpty())
+
+	creds, err = (&SinkEnvConfig{SinkAuthToken: "token", SinkTLSCert: cert, SinkTLSKey: key}).Credentials()
+	require.NoError(t, err)
+	assert.Equal(t, StaticToken("token"), creds.Token)
+	assert.NotNil(t, creds.Certificate)
+	assert.Nil(t, creds.RootCAs)
+
+	creds, err = (&SinkEnvConfig{SinkAuthTokenFile: "/var/run/secrets/sink/token", SinkTLSCACert: cert}).Credentials()
+	require.NoError(t, err)
+	assert.IsType(t, &cachedToken{}, creds.Token)
+	assert.Nil(t, creds.Certificate)
+	assert.NotNil(t, creds.RootCAs)
+
+	_, err = (&SinkEnvConfig{SinkTLSCert: cert}).Credentials()
+	assert.Error(t, err)
+}
+
+func TestFromSpec(t *testing.T) {
+	cert, key := generateCert(t, "client")
+	kc := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gateway"},
+		Data: map[string][]byte{
+			"token":   []byte("token"),
+			"tls.crt": []byte(cert),
+			"tls.key": []byte(key),
+			"ca.crt":  []byte(cert),
+		},
+	}, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "default",
+			Annotations: map[string]string{sourcesv1beta1.ServiceAccountTokenAudiencesAnnotation: "gateway"},
+		},
+	})
+
+	creds, err := FromSpec(context.Background(), kc, "ns", nil)
+	require.NoError(t, err)
+	assert.True(t, creds.IsEmpty())
+
+	creds, err = FromSpec(context.Background(), kc, "ns", &sourcesv1beta1.SinkAuthSpec{
+		BearerToken: &bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("gateway", "token")},
+		TLS: &sourcesv1beta1.SinkTLSSpec{
+			Cert:   bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("gateway", "tls.crt")},
+			Key:    bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("gateway", "tls.key")},
+			CACert: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("gateway", "ca.crt")},
+		},
+	})
+	require.NoError(t, err)
+	token, err := creds.Token.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	assert.NotNil(t, creds.Certificate)
+	assert.NotNil(t, creds.RootCAs)
+
+	creds, err = FromSpec(context.Background(), kc, "ns", &sourcesv1beta1.SinkAuthSpec{
+		ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "gateway", ExpirationSeconds: ptr.Int64(600)},
+		TLS: &sourcesv1beta1.SinkTLSSpec{
+			CACert: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("gateway", "ca.crt")},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, creds.Token)
+	assert.Nil(t, creds.Certificate)
+	assert.NotNil(t, creds.RootCAs)
+
+	_, err = FromSpec(context.Background(), kc, "ns", &sourcesv1beta1.SinkAuthSpec{
+		TLS: &sourcesv1beta1.SinkTLSSpec{
+			CACert: bindingsv1beta1.SecretValueFromSource{SecretKeyRef: secretKeyRef("missing", "ca.crt")},
+		},
+	})
+	assert.Error(t, err)
+	// The service account does not allow the audience
+	_, err = FromSpec(context.Background(), kc, "ns", &sourcesv1beta1.SinkAuthSpec{
+		ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "other"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sinkauth presents credentials, bearer tokens and client
+// certificates, to the sinks of the sources and the subscribers of the
+// channels.
+package sinkauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opencensus.io/plugin/ochttp"
+)
+
+// Credentials are presented to a sink.
+type Credentials struct {
+	// Token is the source of the bearer token sent in the Authorization
+	// header (if any).
+	Token TokenSource
+
+	// Certificate is the client certificate (if any).
+	Certificate *tls.Certificate
+
+	// RootCAs verify the certificate of the sink instead of the system ones
+	// (if any).
+	RootCAs *x509.CertPool
+}
+
+// IsEmpty returns true when no credential is presented.
+func (c *Credentials) IsEmpty() bool {
+	return c == nil || (c.Token == nil && c.Certificate == nil && c.RootCAs == nil)
+}
+
+// SetTLS sets the client certificate and the root CAs from their PEM
+// encoding. The certificate is not set when cert and key are empty, nor the
+// root CAs when caCert is.
+func (c *Credentials) SetTLS(cert, key, caCert string) error {
+	if cert != "" || key != "" {
+		certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return fmt.Errorf("failed to parse the client certificate: %w", err)
+		}
+		c.Certificate = &certificate
+	}
+	if caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return errors.New("failed to parse the CA certificate")
+		}
+		c.RootCAs = pool
+	}
+	return nil
+}
+
+// NewClient returns a copy of client presenting creds to the scheme and host
+// of target only: the requests to the other hosts, like the dead letter and
+// reply sinks sharing the client, are sent as is. client is returned when
+// there are no credentials.
+func NewClient(client *http.Client, target string, creds *Credentials) (*http.Client, error) {
+	if creds.IsEmpty() {
+		return client, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the sink URI %q: %w", target, err)
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &transport{
+		base:          base,
+		authenticated: base,
+		scheme:        u.Scheme,
+		host:          u.Host,
+		token:         creds.Token,
+	}
+	if creds.Certificate != nil || creds.RootCAs != nil {
+		if t.authenticated, err = withTLS(base, creds); err != nil {
+			return nil, err
+		}
+	}
+
+	c := *client
+	c.Transport = t
+	return &c, nil
+}
+
+// transport sends the requests to the sink with authenticated, after setting
+// their Authorization header, and the others with base.
+type transport struct {
+	base          http.RoundTripper
+	authenticated http.RoundTripper
+	scheme        string
+	host          string
+	token         TokenSource
+}
+
+var _ http.RoundTripper = (*transport)(nil)
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != t.scheme || req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
+
+	if t.token != nil {
+		token, err := t.token.Token(req.Context())
+		if err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, fmt.Errorf("failed to get the token of the sink: %w", err)
+		}
+
+		// RoundTrippers must not modify the request
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return t.authenticated.RoundTrip(req)
+}
+
+// withTLS returns a copy of rt presenting the client certificate and
+// verifying the sink with the root CAs of creds.
+func withTLS(rt http.RoundTripper, creds *Credentials) (http.RoundTripper, error) {
+	switch t := rt.(type) {
+	case *ochttp.Transport:
+		base := t.Base
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		tlsBase, err := withTLS(base, creds)
+		if err != nil {
+			return nil, err
+		}
+		traced := *t
+		traced.Base = tlsBase
+		return &traced, nil
+
+	case *http.Transport:
+		clone := t.Clone()
+		if clone.TLSClientConfig == nil {
+			clone.TLSClientConfig = &tls.Config{}
+		}
+		if creds.Certificate != nil {
+			clone.TLSClientConfig.Certificates = []tls.Certificate{*creds.Certificate}
+		}
+		if creds.RootCAs != nil {
+			clone.TLSClientConfig.RootCAs = creds.RootCAs
+		}
+		return clone, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported transport %T for the client certificate", rt)
+	}
+}
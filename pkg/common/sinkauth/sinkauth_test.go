@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinkauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/plugin/ochttp"
+)
+
+func TestCredentialsIsEmpty(t *testing.T) {
+	var nilCreds *Credentials
+	assert.True(t, nilCreds.IsEmpty())
+	assert.True(t, (&Credentials{}).IsEmpty())
+	assert.False(t, (&Credentials{Token: StaticToken("token")}).IsEmpty())
+	assert.False(t, (&Credentials{RootCAs: x509.NewCertPool()}).IsEmpty())
+}
+
+func TestCredentialsSetTLS(t *testing.T) {
+	cert, key := generateCert(t, "client")
+
+	creds := &Credentials{}
+	require.NoError(t, creds.SetTLS("", "", ""))
+	assert.True(t, creds.IsEmpty())
+
+	require.NoError(t, creds.SetTLS(cert, key, cert))
+	assert.NotNil(t, creds.Certificate)
+	assert.NotNil(t, creds.RootCAs)
+
+	assert.Error(t, (&Credentials{}).SetTLS(cert, "", ""))
+	assert.Error(t, (&Credentials{}).SetTLS("", "", "not a certificate"))
+}
+
+func TestNewClientWithoutCredentials(t *testing.T) {
+	client := &http.Client{}
+
+	got, err := NewClient(client, "http://sink", nil)
+	require.NoError(t, err)
+	assert.Same(t, client, got)
+
+	got, err = NewClient(client, "http://sink", &Credentials{})
+	require.NoError(t, err)
+	assert.Same(t, client, got)
+}
+
+func TestNewClientToken(t *testing.T) {
+	sinkAuthorization := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sinkAuthorization <- r.Header.Get("Authorization")
+	}))
+	defer sink.Close()
+
+	deadLetterAuthorization := make(chan string, 1)
+	deadLetterSink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadLetterAuthorization <- r.Header.Get("Authorization")
+	}))
+	defer deadLetterSink.Close()
+
+	base := &http.Client{Transport: &ochttp.Transport{Base: http.DefaultTransport}}
+	client, err := NewClient(base, sink.URL, &Credentials{Token: StaticToken("token")})
+	require.NoError(t, err)
+	assert.Nil(t, base.Transport.(*ochttp.Transport).Base.(*http.Transport).TLSClientConfig)
+
+	req, err := http.NewRequest(http.MethodPost, sink.URL+"/path", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Bearer token", <-sinkAuthorization)
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	resp, err = client.Post(deadLetterSink.URL, "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, <-deadLetterAuthorization)
+}
+
+func TestNewClientTokenError(t *testing.T) {
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+	defer sink.Close()
+
+	client, err := NewClient(http.DefaultClient, sink.URL, &Credentials{Token: tokenFunc(func(context.Context) (string, error) {
+		return "", errors.New("unavailable")
+	})})
+	require.NoError(t, err)
+
+	_, err = client.Post(sink.URL, "text/plain", nil)
+	assert.Error(t, err)
+}
+
+func TestNewClientTLS(t *testing.T) {
+	cert, key := generateCert(t, "client")
+	certificate, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(cert))
+
+	clientName := make(chan string, 1)
+	sink := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientName <- r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	sink.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	sink.StartTLS()
+	defer sink.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(sink.Certificate())
+
+	base := &http.Client{Transport: &ochttp.Transport{Base: http.DefaultTransport.(*http.Transport).Clone()}}
+
+	// The sink is not trusted without the root CAs
+	_, err = base.Post(sink.URL, "text/plain", nil)
+	assert.Error(t, err)
+
+	client, err := NewClient(base, sink.URL, &Credentials{Certificate: &certificate, RootCAs: rootCAs})
+	require.NoError(t, err)
+	_, ok := client.Transport.(*transport).authenticated.(*ochttp.Transport)
+	assert.True(t, ok, "the tracing transport is preserved")
+
+	resp, err := client.Post(sink.URL, "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "client", <-clientName)
+}
+
+func TestNewClientUnsupportedTransport(t *testing.T) {
+	base := &http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, nil
+	})}
+	_, err := NewClient(base, "https://sink", &Credentials{RootCAs: x509.NewCertPool()})
+	assert.Error(t, err)
+}
+
+type tokenFunc func(ctx context.Context) (string, error)
+
+func (f tokenFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Lifted from the RSA path of https://golang.org/src/crypto/tls/generate_cert.go.
+func generateCert(t *testing.T, commonName string) (string, string) {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notBefore := time.Now().Add(-5 * time.Minute)
+	notAfter := notBefore.Add(time.Hour)
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var certOut bytes.Buffer
+	if err := pem.Encode(&certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
+		t.Fatal(err)
+	}
+
+	var keyOut bytes.Buffer
+	if err := pem.Encode(&keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
+		t.Fatal(err)
+	}
+
+	return certOut.String(), keyOut.String()
+}
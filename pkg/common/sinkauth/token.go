@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinkauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+const (
+	// tokenRefreshPeriod is how long the tokens read from files and secrets
+	// are cached, so that their rotation is picked up.
+	tokenRefreshPeriod = time.Minute
+
+	// tokenRefreshRatio is the part of the lifetime of the service account
+	// tokens after which they are renewed.
+	tokenRefreshRatio = 0.8
+)
+
+// TokenSource returns the bearer token sent to a sink.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a token which never changes.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// NewFileTokenSource returns the token read from path, like a projected
+// service account token. The file is read again every minute.
+func NewFileTokenSource(path string) TokenSource {
+	return newCachedToken(func(context.Context) (string, time.Duration, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read the token file %s: %w", path, err)
+		}
+		return strings.TrimSpace(string(b)), tokenRefreshPeriod, nil
+	})
+}
+
+// NewSecretTokenSource returns the token read from the secret key ref of
+// namespace. The secret is read again every minute.
+func NewSecretTokenSource(kc kubernetes.Interface, namespace string, ref *corev1.SecretKeySelector) TokenSource {
+	return newCachedToken(func(ctx context.Context) (string, time.Duration, error) {
+		token, err := readSecretValue(ctx, kc, namespace, ref)
+		if err != nil {
+			return "", 0, err
+		}
+		return strings.TrimSpace(token), tokenRefreshPeriod, nil
+	})
+}
+
+// NewServiceAccountTokenSource returns the tokens of the service account of
+// namespace requested for audience, renewed after 80% of their lifetime. The
+// service account must allow the audience (see CheckServiceAccountToken)
+// whenever a token is requested.
+func NewServiceAccountTokenSource(kc kubernetes.Interface, namespace, serviceAccountName, audience string, expirationSeconds int64) TokenSource {
+	c := newCachedToken(nil)
+	c.fetch = func(ctx context.Context) (string, time.Duration, error) {
+		if err := CheckServiceAccountToken(ctx, kc, namespace, serviceAccountName, audience); err != nil {
+			return "", 0, err
+		}
+		tr, err := kc.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, serviceAccountName, &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         []string{audience},
+				ExpirationSeconds: &expirationSeconds,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to request a token of the service account %s/%s: %w", namespace, serviceAccountName, err)
+		}
+		lifetime := tr.Status.ExpirationTimestamp.Sub(c.now())
+		return tr.Status.Token, time.Duration(float64(lifetime) * tokenRefreshRatio), nil
+	}
+	return c
+}
+
+// CheckServiceAccountToken returns an error unless the service account of
+// namespace lists audience in its ServiceAccountTokenAudiencesAnnotation, so
+// that only the tokens its owner opted in to are presented to a sink. The
+// audiences of the Kubernetes API server are never allowed.
+func CheckServiceAccountToken(ctx context.Context, kc kubernetes.Interface, namespace, serviceAccountName, audience string) error {
+	if sourcesv1beta1.IsAPIServerAudience(audience) {
+		return fmt.Errorf("the audience %q of the Kubernetes API server is not allowed", audience)
+	}
+	sa, err := kc.CoreV1().ServiceAccounts(namespace).Get(ctx, serviceAccountName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to read the service account %s/%s: %w", namespace, serviceAccountName, err)
+	}
+	for _, allowed := range strings.Split(sa.Annotations[sourcesv1beta1.ServiceAccountTokenAudiencesAnnotation], ",") {
+		if allowed = strings.TrimSpace(allowed); allowed != "" && allowed == audience {
+			return nil
+		}
+	}
+	return fmt.Errorf("the service account %s/%s does not allow tokens for the audience %q (annotation %s)",
+		namespace, serviceAccountName, audience, sourcesv1beta1.ServiceAccountTokenAudiencesAnnotation)
+}
+
+// cachedToken caches the token returned by fetch for the duration it returns.
+type cachedToken struct {
+	fetch func(ctx context.Context) (string, time.Duration, error)
+	now   func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+func newCachedToken(fetch func(ctx context.Context) (string, time.Duration, error)) *cachedToken {
+	return &cachedToken{fetch: fetch, now: time.Now}
+}
+
+func (c *cachedToken) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && c.now().Before(c.refreshAt) {
+		return c.token, nil
+	}
+
+	token, refresh, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", errors.New("empty token")
+	}
+	c.token = token
+	c.refreshAt = c.now().Add(refresh)
+	return token, nil
+}
+
+// readSecretValue returns the value of the secret key ref of namespace.
+func readSecretValue(ctx context.Context, kc kubernetes.Interface, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret, err := kc.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("missing key %s in the secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+	return string(value), nil
+}
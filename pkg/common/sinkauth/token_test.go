@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinkauth
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientgotesting "k8s.io/client-go/testing"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestCachedToken(t *testing.T) {
+	now := time.Now()
+	fetches := 0
+	var fetchErr error
+	c := newCachedToken(func(context.Context) (string, time.Duration, error) {
+		fetches++
+		if fetchErr != nil {
+			return "", 0, fetchErr
+		}
+		return "token", time.Minute, nil
+	})
+	c.now = func() time.Time { return now }
+
+	token, err := c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	now = now.Add(59 * time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+
+	now = now.Add(time.Second)
+	_, err = c.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	now = now.Add(time.Minute)
+	fetchErr = errors.New("unavailable")
+	_, err = c.Token(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCachedTokenEmpty(t *testing.T) {
+	_, err := NewFileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(context.Background())
+	assert.Error(t, err)
+
+	c := newCachedToken(func(context.Context) (string, time.Duration, error) {
+		return "", time.Minute, nil
+	})
+	_, err = c.Token(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, ioutil.WriteFile(path, []byte("token\n"), 0600))
+
+	token, err := NewFileTokenSource(path).Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+}
+
+func TestSecretTokenSource(t *testing.T) {
+	kc := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gateway"},
+		Data:       map[string][]byte{"token": []byte("token")},
+	})
+
+	token, err := NewSecretTokenSource(kc, "ns", secretKeyRef("gateway", "token")).Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+
+	_, err = NewSecretTokenSource(kc, "ns", secretKeyRef("gateway", "missing")).Token(context.Background())
+	assert.Error(t, err)
+
+	_, err = NewSecretTokenSource(kc, "other", secretKeyRef("gateway", "token")).Token(context.Background())
+	assert.Error(t, err)
+}
+
+func TestServiceAccountTokenSource(t *testing.T) {
+	now := time.Now()
+	kc := fake.NewSimpleClientset(serviceAccount("ns", "sa", "gateway"))
+	var requests []*authenticationv1.TokenRequest
+	kc.PrependReactor("create", "serviceaccounts", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		create := action.(clientgotesting.CreateAction)
+		assert.Equal(t, "token", create.GetSubresource())
+		assert.Equal(t, "ns", create.GetNamespace())
+
+		tr := create.GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		requests = append(requests, tr)
+		tr.Status = authenticationv1.TokenRequestStatus{
+			Token:               "token",
+			ExpirationTimestamp: metav1.NewTime(now.Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second)),
+		}
+		return true, tr, nil
+	})
+
+	ts := NewServiceAccountTokenSource(kc, "ns", "sa", "gateway", 1000)
+	ts.(*cachedToken).now = func() time.Time { return now }
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token", token)
+	require.Len(t, requests, 1)
+	assert.Equal(t, []string{"gateway"}, requests[0].Spec.Audiences)
+	assert.Equal(t, int64(1000), *requests[0].Spec.ExpirationSeconds)
+
+	// Renewed after 80% of the lifetime
+	now = now.Add(799 * time.Second)
+	_, err = ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+
+	now = now.Add(time.Second)
+	_, err = ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, requests, 2)
+}
+
+func TestCheckServiceAccountToken(t *testing.T) {
+	kc := fake.NewSimpleClientset(
+		serviceAccount("ns", "sa", "gateway, https://kubernetes.default.svc"),
+		serviceAccount("ns", "default", ""),
+	)
+
+	testCases := map[string]struct {
+		serviceAccountName string
+		audience           string
+		wantErr            bool
+	}{
+		"allowed audience": {
+			serviceAccountName: "sa",
+			audience:           "gateway",
+		},
+		"audience not allowed": {
+			serviceAccountName: "sa",
+			audience:           "other",
+			wantErr:            true,
+		},
+		"audience of the API server": {
+			serviceAccountName: "sa",
+			audience:           "https://kubernetes.default.svc",
+			wantErr:            true,
+		},
+		"service account without annotation": {
+			serviceAccountName: "default",
+			audience:           "gateway",
+			wantErr:            true,
+		},
+		"empty audience": {
+			serviceAccountName: "default",
+			wantErr:            true,
+		},
+		"missing service account": {
+			serviceAccountName: "missing",
+			audience:           "gateway",
+			wantErr:            true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			err := CheckServiceAccountToken(context.Background(), kc, "ns", tc.serviceAccountName, tc.audience)
+			assert.Equal(t, tc.wantErr, err != nil, err)
+		})
+	}
+
+	// No token is requested for a service account not allowing the audience
+	ts := NewServiceAccountTokenSource(kc, "ns", "default", "gateway", 1000)
+	_, err := ts.Token(context.Background())
+	assert.Error(t, err)
+}
+
+func serviceAccount(namespace, name, audiences string) *corev1.ServiceAccount {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if audiences != "" {
+		sa.Annotations = map[string]string{sourcesv1beta1.ServiceAccountTokenAudiencesAnnotation: audiences}
+	}
+	return sa
+}
+
+func secretKeyRef(name, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
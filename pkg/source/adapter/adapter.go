@@ -18,6 +18,7 @@ package kafka
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -35,15 +36,19 @@ import (
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/metrics/source"
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
 )
 
 const (
@@ -53,12 +58,49 @@ const (
 type AdapterConfig struct {
 	adapter.EnvConfig
 	client.KafkaEnvConfig
+	sinkauth.SinkEnvConfig
 
 	Topics        []string `envconfig:"KAFKA_TOPICS" required:"true"`
 	ConsumerGroup string   `envconfig:"KAFKA_CONSUMER_GROUP" required:"true"`
 	Name          string   `envconfig:"NAME" required:"true"`
 	KeyType       string   `envconfig:"KEY_TYPE" required:"false"`
 
+	// Regular expression matched against the topics of the cluster, consumed
+	// instead of Topics when not empty.
+	TopicPattern string `envconfig:"KAFKA_TOPIC_PATTERN" required:"false"`
+
+	// Send the record values which are structured CloudEvents JSON as is,
+	// even without the application/cloudevents+json content-type header.
+	DetectStructuredCloudEvents bool `envconfig:"KAFKA_DETECT_STRUCTURED_CLOUDEVENTS" required:"false"`
+
+	// JSON serialized DeliverySpec (retry, backoff and timeout). The default
+	// retry configuration is used when empty.
+	Delivery string `envconfig:"KAFKA_DELIVERY" required:"false"`
+
+	// Resolved URI of the DeliverySpec dead letter sink (if any).
+	DeadLetterSink string `envconfig:"KAFKA_DEAD_LETTER_SINK" required:"false"`
+
+	// JSON serialized DeserializerSpec. Values and keys are forwarded unchanged when empty.
+	Deserializer string `envconfig:"KAFKA_DESERIALIZER" required:"false"`
+
+	// JSON serialized CloudEventMappingSpec. The default attributes are used when empty.
+	CloudEventMapping string `envconfig:"KAFKA_CLOUDEVENT_MAPPING" required:"false"`
+
+	// JSON serialized HeaderMappingSpec. Every header is set as the kafkaheader<name> extension when empty.
+	HeaderMapping string `envconfig:"KAFKA_HEADER_MAPPING" required:"false"`
+
+	// JSON serialized TombstoneSpec. The records without value are sent as events without data when empty.
+	Tombstones string `envconfig:"KAFKA_TOMBSTONES" required:"false"`
+
+	// JSON serialized BatchingSpec. The events are sent one by one when empty.
+	Batching string `envconfig:"KAFKA_BATCHING" required:"false"`
+
+	// Resolved URI of the Reply sink (if any) the replies of the sink are sent to.
+	ReplySink string `envconfig:"KAFKA_REPLY_SINK" required:"false"`
+
+	// Kafka topic the replies of the sink are produced to (if any).
+	ReplyTopic string `envconfig:"KAFKA_REPLY_TOPIC" required:"false"`
+
 	// Turn off the control server.
 	DisableControlServer bool
 }
@@ -67,25 +109,43 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 	return &AdapterConfig{}
 }
 
+// ClaimsNotifier sends the claims of the consumer group session of the
+// adapter to the control plane.
+type ClaimsNotifier func(opCode ctrl.OpCode, claims kafkasourcecontrol.Claims) error
+
 type Adapter struct {
-	config        *AdapterConfig
-	controlServer *ctrlnetwork.ControlServer
-	saramaConfig  *sarama.Config
+	config         *AdapterConfig
+	controlServer  *ctrlnetwork.ControlServer
+	claimsNotifier ClaimsNotifier
+	saramaConfig   *sarama.Config
 
 	httpMessageSender *kncloudevents.HTTPMessageSender
+	deadLetterSender  *kncloudevents.HTTPMessageSender
+	retryConfig       *kncloudevents.RetryConfig
 	reporter          source.StatsReporter
 	logger            *zap.SugaredLogger
 	keyTypeMapper     func([]byte) interface{}
+	keyDeserializer   *schemaregistry.Deserializer
+	valueDeserializer *schemaregistry.Deserializer
+	ceMapping         *cloudEventMapping
+	headerMapping     *headerMapping
+	tombstones        *v1beta1.TombstoneSpec
+	batching          *consumer.BatchConfig
+	replySender       *kncloudevents.HTTPMessageSender
+	replyProducer     sarama.SyncProducer
 	rateLimiter       *rate.Limiter
 	extensions        map[string]string
+
+	// The panics recovered while handling messages, which stop the adapter
+	panics chan *PanicError
 }
 
 var (
-	_           adapter.MessageAdapter                   = (*Adapter)(nil)
-	_           consumer.KafkaConsumerHandler            = (*Adapter)(nil)
-	_           consumer.SaramaConsumerLifecycleListener = (*Adapter)(nil)
-	_           adapter.MessageAdapterConstructor        = NewAdapter
-	retryConfig                                          = defaultRetryConfig()
+	_ adapter.MessageAdapter                   = (*Adapter)(nil)
+	_ consumer.KafkaConsumerHandler            = (*Adapter)(nil)
+	_ consumer.KafkaConsumerBatchHandler       = (*Adapter)(nil)
+	_ consumer.SaramaConsumerLifecycleListener = (*Adapter)(nil)
+	_ adapter.MessageAdapterConstructor        = NewAdapter
 )
 
 func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMessageSender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
@@ -95,9 +155,11 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMe
 	return &Adapter{
 		config:            config,
 		httpMessageSender: httpMessageSender,
+		retryConfig:       defaultRetryConfig(),
 		reporter:          reporter,
 		logger:            logger,
 		keyTypeMapper:     getKeyTypeMapper(config.KeyType),
+		panics:            make(chan *PanicError, 1),
 	}
 }
 func (a *Adapter) GetConsumerGroup() string {
@@ -107,8 +169,12 @@ func (a *Adapter) GetConsumerGroup() string {
 func (a *Adapter) Start(ctx context.Context) (err error) {
 	a.logger.Infow("Starting with config: ",
 		zap.String("Topics", strings.Join(a.config.Topics, ",")),
+		zap.String("TopicPattern", a.config.TopicPattern),
 		zap.String("ConsumerGroup", a.config.ConsumerGroup),
 		zap.String("SinkURI", a.config.Sink),
+		zap.String("DeadLetterSinkURI", a.config.DeadLetterSink),
+		zap.String("ReplySinkURI", a.config.ReplySink),
+		zap.String("ReplyTopic", a.config.ReplyTopic),
 		zap.String("Name", a.config.Name),
 		zap.String("Namespace", a.config.Namespace),
 	)
@@ -124,6 +190,97 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		}
 	}
 
+	// Preprocess sink credentials, presented to the sink only
+	creds, err := a.config.SinkEnvConfig.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to load the sink credentials: %w", err)
+	}
+	if !creds.IsEmpty() {
+		httpClient, err := sinkauth.NewClient(a.httpMessageSender.Client, a.httpMessageSender.Target, creds)
+		if err != nil {
+			return fmt.Errorf("failed to create the sink client: %w", err)
+		}
+		a.httpMessageSender = &kncloudevents.HTTPMessageSender{Client: httpClient, Target: a.httpMessageSender.Target}
+	}
+
+	// Preprocess delivery
+	if a.config.Delivery != "" {
+		delivery := eventingduckv1.DeliverySpec{}
+		if err := json.Unmarshal([]byte(a.config.Delivery), &delivery); err != nil {
+			return fmt.Errorf("failed to parse the delivery spec: %w", err)
+		}
+		retryConfig, err := kncloudevents.RetryConfigFromDeliverySpec(delivery)
+		if err != nil {
+			return fmt.Errorf("failed to create the retry config: %w", err)
+		}
+		a.retryConfig = &retryConfig
+	}
+	if a.config.DeadLetterSink != "" {
+		a.deadLetterSender, err = kncloudevents.NewHTTPMessageSenderWithTarget(a.config.DeadLetterSink)
+		if err != nil {
+			return fmt.Errorf("failed to create the dead letter sink sender: %w", err)
+		}
+	}
+
+	if a.config.ReplySink != "" {
+		a.replySender, err = kncloudevents.NewHTTPMessageSenderWithTarget(a.config.ReplySink)
+		if err != nil {
+			return fmt.Errorf("failed to create the reply sink sender: %w", err)
+		}
+	}
+
+	// Preprocess deserializer
+	if a.config.Deserializer != "" {
+		if err := a.initDeserializers(); err != nil {
+			return err
+		}
+	}
+
+	// Preprocess CloudEvent mapping
+	if a.config.CloudEventMapping != "" {
+		mapping := v1beta1.CloudEventMappingSpec{}
+		if err := json.Unmarshal([]byte(a.config.CloudEventMapping), &mapping); err != nil {
+			return fmt.Errorf("failed to parse the CloudEvent mapping: %w", err)
+		}
+		if a.ceMapping, err = newCloudEventMapping(&mapping); err != nil {
+			return err
+		}
+	}
+
+	// Preprocess header mapping
+	if a.config.HeaderMapping != "" {
+		mapping := v1beta1.HeaderMappingSpec{}
+		if err := json.Unmarshal([]byte(a.config.HeaderMapping), &mapping); err != nil {
+			return fmt.Errorf("failed to parse the header mapping: %w", err)
+		}
+		a.headerMapping = newHeaderMapping(&mapping)
+	}
+
+	// Preprocess tombstones
+	if a.config.Tombstones != "" {
+		a.tombstones = &v1beta1.TombstoneSpec{}
+		if err := json.Unmarshal([]byte(a.config.Tombstones), a.tombstones); err != nil {
+			return fmt.Errorf("failed to parse the tombstones: %w", err)
+		}
+	}
+
+	// Preprocess batching
+	if a.config.Batching != "" {
+		batching := v1beta1.BatchingSpec{}
+		if err := json.Unmarshal([]byte(a.config.Batching), &batching); err != nil {
+			return fmt.Errorf("failed to parse the batching: %w", err)
+		}
+		maxWait, err := batching.ParseMaxWait()
+		if err != nil {
+			return fmt.Errorf("failed to parse the batching max wait: %w", err)
+		}
+		a.batching = &consumer.BatchConfig{
+			MaxMessages: int(batching.MaxEvents),
+			MaxBytes:    batching.MaxBytes,
+			MaxWait:     maxWait,
+		}
+	}
+
 	// Init control service
 	if !a.config.DisableControlServer {
 		a.controlServer, err = ctrlnetwork.StartInsecureControlServer(ctx)
@@ -131,6 +288,9 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 			return err
 		}
 		a.controlServer.MessageHandler(a)
+		a.claimsNotifier = func(opCode ctrl.OpCode, claims kafkasourcecontrol.Claims) error {
+			return a.controlServer.SendAndWaitForAck(opCode, claims)
+		}
 	}
 
 	// init consumer group
@@ -140,25 +300,50 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 	}
 	a.saramaConfig = config
 
-	options := []consumer.SaramaConsumerHandlerOption{consumer.WithSaramaConsumerLifecycleListener(a)}
+	if a.config.ReplyTopic != "" {
+		if a.replyProducer, err = newReplyProducer(addrs, config); err != nil {
+			return fmt.Errorf("failed to create the reply producer: %w", err)
+		}
+		defer a.closeReplyProducer()
+	}
+
 	consumerGroupFactory := consumer.NewConsumerGroupFactory(addrs, config, &consumer.NoopConsumerGroupOffsetsChecker{}, func(ref types.NamespacedName) {})
+	if a.config.TopicPattern != "" {
+		return a.consumeTopicPattern(ctx, consumerGroupFactory, addrs)
+	}
+
+	group, err := a.startConsumerGroup(ctx, consumerGroupFactory, a.config.Topics)
+	if err != nil {
+		return err
+	}
+	defer a.closeConsumerGroup(group)
+
+	select {
+	case <-ctx.Done():
+		a.logger.Info("Shutting down...")
+		return nil
+	case panicErr := <-a.panics:
+		return panicErr
+	}
+}
+
+// startConsumerGroup starts consuming the topics and tracks the consumer group errors.
+func (a *Adapter) startConsumerGroup(ctx context.Context, consumerGroupFactory consumer.KafkaConsumerGroupFactory, topics []string) (sarama.ConsumerGroup, error) {
+	options := []consumer.SaramaConsumerHandlerOption{consumer.WithSaramaConsumerLifecycleListener(a)}
+	if a.batching != nil {
+		options = append(options, consumer.WithBatching(*a.batching))
+	}
 	group, err := consumerGroupFactory.StartConsumerGroup(
 		ctx,
 		a.config.ConsumerGroup,
-		a.config.Topics,
+		topics,
 		a,
 		types.NamespacedName{Namespace: a.config.Namespace, Name: a.config.Name},
 		options...,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to start consumer group: %w", err)
+		return nil, fmt.Errorf("failed to start consumer group: %w", err)
 	}
-	defer func() {
-		err := group.Close()
-		if err != nil {
-			a.logger.Errorw("Failed to close consumer group", zap.Error(err))
-		}
-	}()
 
 	// Track errors
 	go func() {
@@ -167,14 +352,45 @@ func (a *Adapter) Start(ctx context.Context) (err error) {
 		}
 	}()
 
-	<-ctx.Done()
-	a.logger.Info("Shutting down...")
+	return group, nil
+}
+
+func (a *Adapter) closeConsumerGroup(group sarama.ConsumerGroup) {
+	if err := group.Close(); err != nil {
+		a.logger.Errorw("Failed to close consumer group", zap.Error(err))
+	}
+}
+
+// initDeserializers creates the schema registry deserializers of the message values and keys.
+func (a *Adapter) initDeserializers() (err error) {
+	deserializer := v1beta1.DeserializerSpec{}
+	if err := json.Unmarshal([]byte(a.config.Deserializer), &deserializer); err != nil {
+		return fmt.Errorf("failed to parse the deserializer spec: %w", err)
+	}
+
+	registryClient := schemaregistry.NewClient(deserializer.SchemaRegistryURL, nil)
+	if deserializer.Value != "" {
+		if a.valueDeserializer, err = schemaregistry.NewDeserializer(registryClient, deserializer.Value); err != nil {
+			return fmt.Errorf("failed to create the value deserializer: %w", err)
+		}
+	}
+	if deserializer.Key != "" {
+		if a.keyDeserializer, err = schemaregistry.NewDeserializer(registryClient, deserializer.Key); err != nil {
+			return fmt.Errorf("failed to create the key deserializer: %w", err)
+		}
+	}
 	return nil
 }
 
 func (a *Adapter) SetReady(int32, bool) {}
 
-func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool, error) {
+func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (mustMark bool, err error) {
+	defer a.recoverPanic(&mustMark, &err)
+
+	if a.skipTombstone(msg) {
+		return true, nil
+	}
+
 	if a.rateLimiter != nil {
 		a.rateLimiter.Wait(ctx)
 	}
@@ -188,26 +404,126 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 	}
 
 	err = a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	if schemaregistry.IsTemporary(err) {
+		return false, err // The schema registry may recover, don't commit offset
+	} else if err != nil {
+		return a.sendUndecodableToDeadLetterSink(ctx, msg, err)
+	}
+
+	res, err := a.send(ctx, a.httpMessageSender, req)
 	if err != nil {
-		a.logger.Debug("failed to create request", zap.Error(err))
-		return true, err
+		if a.deadLetterSender == nil {
+			return false, err // Error while sending, don't commit offset
+		}
+		res, err = a.sendToDeadLetterSink(ctx, msg, err)
+		if err != nil {
+			return false, err // Error while dead lettering, don't commit offset
+		}
 	}
 
-	res, err := a.httpMessageSender.SendWithRetries(req, retryConfig)
+	reportArgs := &source.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+	}
 
+	_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
+	return true, nil
+}
+
+// skipTombstone returns whether the message is a record without value
+// which is not sent to the sink.
+func (a *Adapter) skipTombstone(msg *sarama.ConsumerMessage) bool {
+	if msg.Value == nil && a.tombstones != nil && a.tombstones.Policy == v1beta1.TombstonePolicySkip {
+		a.logger.Debug("Skipping the record without value")
+		return true
+	}
+	return false
+}
+
+// send sends the request with the configured retries, returning an error if
+// the message could not be delivered. The replies of the sink are forwarded
+// to the reply destination (if any), without affecting the delivery.
+func (a *Adapter) send(ctx context.Context, sender *kncloudevents.HTTPMessageSender, req *http.Request) (*http.Response, error) {
+	res, err := sender.SendWithRetries(req, a.retryConfig)
 	if err != nil {
 		a.logger.Debug("Error while sending the message", zap.Error(err))
-		return false, err // Error while sending, don't commit offset
+		return nil, err
 	}
 	// Always try to read and close body so the connection can be reused afterwards
 	if res.Body != nil {
-		io.Copy(ioutil.Discard, res.Body)
-		res.Body.Close()
+		defer func() {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}()
 	}
 
 	if res.StatusCode/100 != 2 {
 		a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
-		return false, fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+		return nil, fmt.Errorf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
+	}
+
+	// Only the replies of the sink are forwarded, not the ones of the dead letter sink.
+	if sender == a.httpMessageSender && (a.replySender != nil || a.replyProducer != nil) {
+		a.forwardReplies(ctx, res)
+	}
+	return res, nil
+}
+
+// sendToDeadLetterSink sends a message which could not be delivered to the sink
+// to the dead letter sink instead, so that the partition is not blocked by it.
+func (a *Adapter) sendToDeadLetterSink(ctx context.Context, msg *sarama.ConsumerMessage, sinkErr error) (*http.Response, error) {
+	a.logger.Warnw("Failed to send the message to the sink, sending it to the dead letter sink",
+		zap.String("topic", msg.Topic),
+		zap.Int32("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+		zap.Error(sinkErr))
+
+	req, err := a.deadLetterSender.NewCloudEventRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.ConsumerMessageToHttpRequest(ctx, msg, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.send(ctx, a.deadLetterSender, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the message to the dead letter sink: %w (sink error: %v)", err, sinkErr)
+	}
+	return res, nil
+}
+
+// sendUndecodableToDeadLetterSink sends the raw value of a message which cannot
+// be translated to a CloudEvent to the dead letter sink, or drops it when there
+// is no dead letter sink.
+func (a *Adapter) sendUndecodableToDeadLetterSink(ctx context.Context, msg *sarama.ConsumerMessage, decodeErr error) (bool, error) {
+	a.logger.Errorw("Failed to create the event of the message",
+		zap.String("topic", msg.Topic),
+		zap.Int32("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+		zap.Bool("deadLettered", a.deadLetterSender != nil),
+		zap.Error(decodeErr))
+
+	if a.deadLetterSender == nil {
+		return true, decodeErr // The message is dropped, commit offset
+	}
+
+	req, err := a.deadLetterSender.NewCloudEventRequest(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = a.rawConsumerMessageToHttpRequest(ctx, msg, req)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := a.send(ctx, a.deadLetterSender, req)
+	if err != nil {
+		return false, fmt.Errorf("failed to send the message to the dead letter sink: %w (decode error: %v)", err, decodeErr) // Don't commit offset
 	}
 
 	reportArgs := &source.ReportArgs{
@@ -220,9 +536,16 @@ func (a *Adapter) Handle(ctx context.Context, msg *sarama.ConsumerMessage) (bool
 	return true, nil
 }
 
-// SetRateLimiter sets the global consumer rate limiter
+// SetRateLimits sets the global consumer rate limiter. Once set, the limits
+// of the rate limiter are updated in place so that they can be changed while
+// the adapter is running.
 func (a *Adapter) SetRateLimits(r rate.Limit, b int) {
-	a.rateLimiter = rate.NewLimiter(r, b)
+	if a.rateLimiter == nil {
+		a.rateLimiter = rate.NewLimiter(r, b)
+		return
+	}
+	a.rateLimiter.SetLimit(r)
+	a.rateLimiter.SetBurst(b)
 }
 
 func (a *Adapter) HandleServiceMessage(ctx context.Context, message ctrl.ServiceMessage) {
@@ -232,18 +555,23 @@ func (a *Adapter) HandleServiceMessage(ctx context.Context, message ctrl.Service
 	message.Ack()
 }
 
-func (a *Adapter) Setup(sess sarama.ConsumerGroupSession) {
-	if a.controlServer != nil {
-		if err := a.controlServer.SendAndWaitForAck(kafkasourcecontrol.NotifySetupClaimsOpCode, kafkasourcecontrol.Claims(sess.Claims())); err != nil {
-			a.logger.Warnf("Cannot send the claims update: %v", err)
-		}
-	}
+// SetClaimsNotifier sets the notifier of the claims, when the adapter does
+// not run its own control server.
+func (a *Adapter) SetClaimsNotifier(notifier ClaimsNotifier) {
+	a.claimsNotifier = notifier
+}
 
+func (a *Adapter) Setup(sess sarama.ConsumerGroupSession) {
+	a.notifyClaims(kafkasourcecontrol.NotifySetupClaimsOpCode, sess.Claims())
 }
 
 func (a *Adapter) Cleanup(sess sarama.ConsumerGroupSession) {
-	if a.controlServer != nil {
-		if err := a.controlServer.SendAndWaitForAck(kafkasourcecontrol.NotifyCleanupClaimsOpCode, kafkasourcecontrol.Claims(sess.Claims())); err != nil {
+	a.notifyClaims(kafkasourcecontrol.NotifyCleanupClaimsOpCode, sess.Claims())
+}
+
+func (a *Adapter) notifyClaims(opCode ctrl.OpCode, claims map[string][]int32) {
+	if a.claimsNotifier != nil {
+		if err := a.claimsNotifier(opCode, kafkasourcecontrol.Claims(claims)); err != nil {
 			a.logger.Warnf("Cannot send the claims update: %v", err)
 		}
 	}
@@ -17,23 +17,32 @@ limitations under the License.
 package kafka
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
 
+	ctrl "knative.dev/control-protocol/pkg"
+
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 )
 
 func TestPostMessage_ServeHTTP_binary_mode(t *testing.T) {
@@ -441,3 +450,537 @@ func TestAdapter_Start(t *testing.T) { // just increase code coverage
 	}
 	cancel()
 }
+
+func TestAdapter_HandleDeadLetterSink(t *testing.T) {
+	testCases := map[string]struct {
+		sink           func(http.ResponseWriter, *http.Request)
+		deadLetterSink func(http.ResponseWriter, *http.Request)
+		noDeadLetter   bool
+		wantCommit     bool
+		wantError      bool
+		wantDeadLetter bool
+	}{
+		"sink accepted": {
+			sink:           sinkAccepted,
+			deadLetterSink: sinkAccepted,
+			wantCommit:     true,
+		},
+		"sink rejected, no dead letter sink": {
+			sink:         sinkRejected,
+			noDeadLetter: true,
+			wantError:    true,
+		},
+		"sink rejected, dead letter sink accepted": {
+			sink:           sinkRejected,
+			deadLetterSink: sinkAccepted,
+			wantCommit:     true,
+			wantDeadLetter: true,
+		},
+		"sink rejected, dead letter sink rejected": {
+			sink:           sinkRejected,
+			deadLetterSink: sinkRejected,
+			wantError:      true,
+			wantDeadLetter: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			h := &fakeHandler{handler: tc.sink}
+			sinkServer := httptest.NewServer(h)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Sink:      sinkServer.URL,
+						Namespace: "test",
+					},
+					Topics:        []string{"topic1"},
+					ConsumerGroup: "group",
+					Name:          "test",
+				},
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          statsReporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+			}
+
+			dlh := &fakeHandler{handler: tc.deadLetterSink}
+			if !tc.noDeadLetter {
+				deadLetterServer := httptest.NewServer(dlh)
+				defer deadLetterServer.Close()
+				a.deadLetterSender, err = kncloudevents.NewHTTPMessageSenderWithTarget(deadLetterServer.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+				Key:       []byte("key"),
+				Topic:     "topic1",
+				Value:     mustJsonMarshal(t, map[string]string{"key": "value"}),
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			})
+
+			if commit != tc.wantCommit {
+				t.Errorf("expected commit %t, but got %t", tc.wantCommit, commit)
+			}
+			if tc.wantError != (err != nil) {
+				t.Errorf("expected error %t, but got %v", tc.wantError, err)
+			}
+			if tc.wantDeadLetter != (dlh.body != nil) {
+				t.Errorf("expected dead letter %t, but got body %q", tc.wantDeadLetter, dlh.body)
+			}
+			if tc.wantDeadLetter && string(dlh.body) != `{"key":"value"}` {
+				t.Errorf("expected dead letter body '%q', but got '%q'", `{"key":"value"}`, dlh.body)
+			}
+			if tc.wantDeadLetter && dlh.header.Get("ce-id") != makeEventId(1, 2) {
+				t.Errorf("expected dead letter ce-id '%q', but got '%q'", makeEventId(1, 2), dlh.header.Get("ce-id"))
+			}
+		})
+	}
+}
+
+func TestAdapter_StartInvalidDelivery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := NewAdapter(ctx, &AdapterConfig{
+		Delivery:             `{"backoffPolicy":"exponential","backoffDelay":"foo"}`,
+		DisableControlServer: true,
+	}, nil, nil)
+	err := a.Start(ctx)
+	if err == nil || !strings.Contains(err.Error(), "failed to create the retry config") {
+		t.Errorf("expected retry config error, but got %v", err)
+	}
+}
+
+func TestAdapter_StartInvalidSinkCredentials(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := NewAdapter(ctx, &AdapterConfig{
+		SinkEnvConfig:        sinkauth.SinkEnvConfig{SinkTLSCACert: "foo"},
+		DisableControlServer: true,
+	}, nil, nil)
+	err := a.Start(ctx)
+	if err == nil || !strings.Contains(err.Error(), "failed to load the sink credentials") {
+		t.Errorf("expected sink credentials error, but got %v", err)
+	}
+}
+
+func TestAdapter_HandleDeserializer(t *testing.T) {
+	schemas := map[string]string{
+		"/schemas/ids/1": `{"schema":"{\"type\":\"record\",\"name\":\"Event\",\"fields\":[{\"name\":\"a\",\"type\":\"string\"}]}"}`,
+		"/schemas/ids/2": `{"schema":"\"string\""}`,
+	}
+	registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/schemas/ids/4" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		schema, ok := schemas[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(schema))
+	}))
+	defer registryServer.Close()
+
+	testCases := map[string]struct {
+		key            []byte
+		value          []byte
+		deadLetter     bool
+		wantCommit     bool
+		wantError      bool
+		wantKey        string
+		wantBody       string
+		wantDeadLetter bool
+	}{
+		"key and value deserialized": {
+			key:        []byte{0, 0, 0, 0, 2, 6, 'k', 'e', 'y'},
+			value:      []byte{0, 0, 0, 0, 1, 2, 'b'},
+			wantCommit: true,
+			wantKey:    `"key"`,
+			wantBody:   `{"a":"b"}`,
+		},
+		"empty key is not deserialized": {
+			value:      []byte{0, 0, 0, 0, 1, 2, 'b'},
+			wantCommit: true,
+			wantBody:   `{"a":"b"}`,
+		},
+		"invalid value wire format": {
+			value:      []byte(`{"a":"b"}`),
+			wantCommit: true,
+			wantError:  true,
+		},
+		"unknown key schema": {
+			key:        []byte{0, 0, 0, 0, 3, 6, 'k', 'e', 'y'},
+			value:      []byte{0, 0, 0, 0, 1, 2, 'b'},
+			wantCommit: true,
+			wantError:  true,
+		},
+		"invalid value wire format, dead lettered": {
+			value:          []byte(`{"a":"b"}`),
+			deadLetter:     true,
+			wantCommit:     true,
+			wantDeadLetter: true,
+		},
+		"schema registry unavailable": {
+			value:      []byte{0, 0, 0, 0, 4, 2, 'b'},
+			deadLetter: true,
+			wantCommit: false,
+			wantError:  true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			h := &fakeHandler{handler: sinkAccepted}
+			sinkServer := httptest.NewServer(h)
+			defer sinkServer.Close()
+
+			statsReporter, _ := source.NewStatsReporter()
+
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Sink:      sinkServer.URL,
+						Namespace: "test",
+					},
+					Topics:        []string{"topic1"},
+					ConsumerGroup: "group",
+					Name:          "test",
+					Deserializer:  `{"schemaRegistryUrl":"` + registryServer.URL + `","value":"avro","key":"avro"}`,
+				},
+				httpMessageSender: s,
+				logger:            zap.NewNop().Sugar(),
+				reporter:          statsReporter,
+				keyTypeMapper:     getKeyTypeMapper(""),
+			}
+			if err := a.initDeserializers(); err != nil {
+				t.Fatal(err)
+			}
+
+			dlh := &fakeHandler{handler: sinkAccepted}
+			if tc.deadLetter {
+				deadLetterServer := httptest.NewServer(dlh)
+				defer deadLetterServer.Close()
+				a.deadLetterSender, err = kncloudevents.NewHTTPMessageSenderWithTarget(deadLetterServer.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+				Key:       tc.key,
+				Topic:     "topic1",
+				Value:     tc.value,
+				Partition: 1,
+				Offset:    2,
+				Timestamp: time.Now(),
+			})
+
+			if commit != tc.wantCommit {
+				t.Errorf("expected commit %t, but got %t", tc.wantCommit, commit)
+			}
+			if tc.wantError != (err != nil) {
+				t.Errorf("expected error %t, but got %v", tc.wantError, err)
+			}
+			if tc.wantDeadLetter != (dlh.body != nil) {
+				t.Errorf("expected dead letter %t, but got body %q", tc.wantDeadLetter, dlh.body)
+			}
+			if tc.wantDeadLetter {
+				if !bytes.Equal(dlh.body, tc.value) {
+					t.Errorf("expected dead letter body %q, but got %q", tc.value, dlh.body)
+				}
+				if dlh.header.Get("ce-id") != makeEventId(1, 2) {
+					t.Errorf("expected dead letter ce-id %q, but got %q", makeEventId(1, 2), dlh.header.Get("ce-id"))
+				}
+			}
+			if tc.wantError || tc.wantDeadLetter {
+				if h.body != nil {
+					t.Errorf("expected no event to be sent, but got body %q", h.body)
+				}
+				return
+			}
+			if string(h.body) != tc.wantBody {
+				t.Errorf("expected body %q, but got %q", tc.wantBody, h.body)
+			}
+			if h.header.Get("Content-Type") != "application/json" {
+				t.Errorf("expected content type %q, but got %q", "application/json", h.header.Get("Content-Type"))
+			}
+			if h.header.Get("ce-dataschema") != registryServer.URL+"/schemas/ids/1" {
+				t.Errorf("expected dataschema %q, but got %q", registryServer.URL+"/schemas/ids/1", h.header.Get("ce-dataschema"))
+			}
+			if h.header.Get("ce-key") != tc.wantKey {
+				t.Errorf("expected key %q, but got %q", tc.wantKey, h.header.Get("ce-key"))
+			}
+		})
+	}
+}
+
+func TestAdapter_DetectStructuredCloudEvents(t *testing.T) {
+	structured := []byte(` {"specversion":"1.0","id":"42","source":"/orders","type":"order.created","datacontenttype":"application/json","data":{"order":"42"}}`)
+
+	testCases := map[string]struct {
+		detect   bool
+		value    []byte
+		wantID   string
+		wantType string
+		wantBody string
+	}{
+		"structured cloudevent": {
+			detect:   true,
+			value:    structured,
+			wantID:   "42",
+			wantType: "order.created",
+			wantBody: `{"order":"42"}`,
+		},
+		"detection disabled": {
+			value:    structured,
+			wantID:   makeEventId(1, 2),
+			wantType: sourcesv1beta1.KafkaEventType,
+			wantBody: string(structured),
+		},
+		"invalid cloudevent": {
+			detect:   true,
+			value:    []byte(`{"specversion":"1.0","id":"42","type":"order.created"}`),
+			wantID:   makeEventId(1, 2),
+			wantType: sourcesv1beta1.KafkaEventType,
+			wantBody: `{"specversion":"1.0","id":"42","type":"order.created"}`,
+		},
+		"not json": {
+			detect:   true,
+			value:    []byte(`specversion`),
+			wantID:   makeEventId(1, 2),
+			wantType: sourcesv1beta1.KafkaEventType,
+			wantBody: `specversion`,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Namespace: "test",
+					},
+					Name:                        "test",
+					DetectStructuredCloudEvents: tc.detect,
+				},
+				logger:        zap.NewNop().Sugar(),
+				keyTypeMapper: getKeyTypeMapper(""),
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://sink", nil)
+			require.Nil(t, err)
+			require.Nil(t, a.ConsumerMessageToHttpRequest(context.TODO(), &sarama.ConsumerMessage{
+				Topic:     "topic1",
+				Value:     tc.value,
+				Partition: 1,
+				Offset:    2,
+			}, req))
+
+			assert.Equal(t, tc.wantID, req.Header.Get("ce-id"))
+			assert.Equal(t, tc.wantType, req.Header.Get("ce-type"))
+			body, err := ioutil.ReadAll(req.Body)
+			require.Nil(t, err)
+			assert.Equal(t, tc.wantBody, string(body))
+		})
+	}
+}
+
+func TestAdapter_Tombstones(t *testing.T) {
+	tombstone := &sarama.ConsumerMessage{
+		Key:       []byte("42"),
+		Topic:     "topic1",
+		Partition: 1,
+		Offset:    2,
+	}
+
+	testCases := map[string]struct {
+		tombstones *sourcesv1beta1.TombstoneSpec
+		message    *sarama.ConsumerMessage
+		wantType   string
+	}{
+		"default": {
+			message:  tombstone,
+			wantType: sourcesv1beta1.KafkaEventType,
+		},
+		"emit with the default type": {
+			tombstones: &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicyEmit},
+			message:    tombstone,
+			wantType:   sourcesv1beta1.KafkaTombstoneEventType,
+		},
+		"emit with a custom type": {
+			tombstones: &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicyEmit, Type: "com.example.delete"},
+			message:    tombstone,
+			wantType:   "com.example.delete",
+		},
+		"emit ignores empty values": {
+			tombstones: &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicyEmit},
+			message: &sarama.ConsumerMessage{
+				Key:       []byte("42"),
+				Topic:     "topic1",
+				Value:     []byte{},
+				Partition: 1,
+				Offset:    2,
+			},
+			wantType: sourcesv1beta1.KafkaEventType,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Namespace: "test",
+					},
+					Name: "test",
+				},
+				logger:        zap.NewNop().Sugar(),
+				keyTypeMapper: getKeyTypeMapper(""),
+				tombstones:    tc.tombstones,
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://sink", nil)
+			require.Nil(t, err)
+			require.Nil(t, a.ConsumerMessageToHttpRequest(context.TODO(), tc.message, req))
+
+			assert.Equal(t, tc.wantType, req.Header.Get("ce-type"))
+			assert.Equal(t, "42", req.Header.Get("ce-key"))
+			if req.Body != nil {
+				body, err := ioutil.ReadAll(req.Body)
+				require.Nil(t, err)
+				assert.Empty(t, body)
+			}
+		})
+	}
+}
+
+func TestAdapter_SkipTombstones(t *testing.T) {
+	h := &fakeHandler{
+		handler: sinkAccepted,
+	}
+	sinkServer := httptest.NewServer(h)
+	defer sinkServer.Close()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkServer.URL)
+	require.Nil(t, err)
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Sink:      sinkServer.URL,
+				Namespace: "test",
+			},
+			Name: "test",
+		},
+		httpMessageSender: s,
+		logger:            zap.NewNop().Sugar(),
+		keyTypeMapper:     getKeyTypeMapper(""),
+		tombstones:        &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicySkip},
+	}
+
+	commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Key:       []byte("42"),
+		Topic:     "topic1",
+		Partition: 1,
+		Offset:    2,
+	})
+	assert.True(t, commit)
+	assert.Nil(t, err)
+	assert.Nil(t, h.header, "the record without value should not be sent")
+}
+
+func TestAdapter_ClaimsNotifier(t *testing.T) {
+	type notification struct {
+		opCode ctrl.OpCode
+		claims kafkasourcecontrol.Claims
+	}
+	var notifications []notification
+
+	a := &Adapter{logger: zap.NewNop().Sugar()}
+
+	// No notifier, no notification
+	sess := &claimsSession{claims: map[string][]int32{"topic1": {0, 1}}}
+	a.Setup(sess)
+
+	a.SetClaimsNotifier(func(opCode ctrl.OpCode, claims kafkasourcecontrol.Claims) error {
+		notifications = append(notifications, notification{opCode: opCode, claims: claims})
+		return nil
+	})
+	a.Setup(sess)
+	a.Cleanup(sess)
+
+	assert.Equal(t, []notification{
+		{opCode: kafkasourcecontrol.NotifySetupClaimsOpCode, claims: kafkasourcecontrol.Claims{"topic1": {0, 1}}},
+		{opCode: kafkasourcecontrol.NotifyCleanupClaimsOpCode, claims: kafkasourcecontrol.Claims{"topic1": {0, 1}}},
+	}, notifications)
+}
+
+func TestAdapter_SetRateLimits(t *testing.T) {
+	a := &Adapter{}
+
+	a.SetRateLimits(rate.Limit(10), 20)
+	limiter := a.rateLimiter
+	assert.Equal(t, rate.Limit(10), limiter.Limit())
+	assert.Equal(t, 20, limiter.Burst())
+
+	// The rate limiter in use is updated in place
+	a.SetRateLimits(rate.Limit(30), 60)
+	assert.Same(t, limiter, a.rateLimiter)
+	assert.Equal(t, rate.Limit(30), limiter.Limit())
+	assert.Equal(t, 60, limiter.Burst())
+}
+
+func TestAdapter_RecoverPanic(t *testing.T) {
+	// Handling messages without a sender panics
+	a := &Adapter{
+		config: &AdapterConfig{},
+		logger: zap.NewNop().Sugar(),
+		panics: make(chan *PanicError, 1),
+	}
+
+	mustMark, err := a.Handle(context.Background(), &sarama.ConsumerMessage{Value: []byte("data")})
+	assert.False(t, mustMark)
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+
+	// Only the first panic stops the adapter
+	mustMark, err = a.HandleBatch(context.Background(), []*sarama.ConsumerMessage{{Value: []byte("data")}})
+	assert.False(t, mustMark)
+	var batchPanicErr *PanicError
+	require.ErrorAs(t, err, &batchPanicErr)
+
+	assert.Same(t, panicErr, <-a.panics)
+	assert.Empty(t, a.panics)
+}
+
+// claimsSession is a sarama.ConsumerGroupSession which only has claims.
+type claimsSession struct {
+	sarama.ConsumerGroupSession
+	claims map[string][]int32
+}
+
+func (s *claimsSession) Claims() map[string][]int32 {
+	return s.claims
+}
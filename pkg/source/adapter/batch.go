@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+
+	"knative.dev/eventing-kafka/pkg/source/schemaregistry"
+)
+
+// HandleBatch sends the messages of a partition to the sink as a single
+// CloudEvents batch request.
+func (a *Adapter) HandleBatch(ctx context.Context, messages []*sarama.ConsumerMessage) (mustMark bool, err error) {
+	defer a.recoverPanic(&mustMark, &err)
+
+	ctx, span := trace.StartSpan(ctx, "kafka-source")
+	defer span.End()
+
+	reportArgs := &source.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
+	}
+
+	events := make([]*cloudevents.Event, 0, len(messages))
+	var undecodable []*cloudevents.Event
+	for _, msg := range messages {
+		if a.rateLimiter != nil {
+			a.rateLimiter.Wait(ctx)
+		}
+
+		if a.skipTombstone(msg) {
+			continue
+		}
+
+		event, err := a.consumerMessageToEvent(ctx, msg)
+		if schemaregistry.IsTemporary(err) {
+			return false, err // The schema registry may recover, don't commit offsets
+		} else if err != nil {
+			// Same as Handle, the messages which cannot be converted are dead lettered, or dropped.
+			a.logger.Errorw("Failed to create the event of the message",
+				zap.String("topic", msg.Topic),
+				zap.Int32("partition", msg.Partition),
+				zap.Int64("offset", msg.Offset),
+				zap.Bool("deadLettered", a.deadLetterSender != nil),
+				zap.Error(err))
+			if a.deadLetterSender != nil {
+				undecodable = append(undecodable, batchEvent(a.makeRawEvent(msg)))
+			}
+			continue
+		}
+		events = append(events, batchEvent(event))
+	}
+
+	if len(undecodable) > 0 {
+		body, err := json.Marshal(undecodable)
+		if err != nil {
+			return true, fmt.Errorf("failed to encode the batch of undecodable messages: %w", err)
+		}
+		res, err := a.sendBatch(ctx, a.deadLetterSender, body)
+		if err != nil {
+			return false, fmt.Errorf("failed to send the undecodable messages to the dead letter sink: %w", err) // Don't commit offsets
+		}
+		for range undecodable {
+			_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
+		}
+	}
+
+	if len(events) == 0 {
+		return true, nil
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return true, fmt.Errorf("failed to encode the batch: %w", err)
+	}
+
+	res, err := a.sendBatch(ctx, a.httpMessageSender, body)
+	if err != nil {
+		if a.deadLetterSender == nil {
+			return false, err // Error while sending, don't commit offsets
+		}
+		a.logger.Warnw("Failed to send the batch to the sink, sending it to the dead letter sink",
+			zap.String("topic", messages[0].Topic),
+			zap.Int32("partition", messages[0].Partition),
+			zap.Int64("firstOffset", messages[0].Offset),
+			zap.Int64("lastOffset", messages[len(messages)-1].Offset),
+			zap.Error(err))
+		res, err = a.sendBatch(ctx, a.deadLetterSender, body)
+		if err != nil {
+			return false, fmt.Errorf("failed to send the batch to the dead letter sink: %w", err) // Don't commit offsets
+		}
+	}
+
+	for range events {
+		_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
+	}
+	return true, nil
+}
+
+// sendBatch sends the JSON encoded batch of events with the configured retries.
+func (a *Adapter) sendBatch(ctx context.Context, sender *kncloudevents.HTTPMessageSender, body []byte) (*http.Response, error) {
+	req, err := sender.NewCloudEventRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsBatchJSON)
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+
+	return a.send(ctx, sender, req)
+}
+
+// batchEvent returns the event, with its data encoded in base64 when it
+// cannot be embedded in the JSON batch as is.
+func batchEvent(e *cloudevents.Event) *cloudevents.Event {
+	if len(e.DataEncoded) == 0 || e.DataBase64 {
+		return e
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(e.DataContentType())
+	switch mediaType {
+	case "", event.ApplicationJSON, event.TextJSON:
+		// JSON data is embedded as is
+		e.DataBase64 = !json.Valid(e.DataEncoded)
+	default:
+		// Other data is embedded as a string
+		e.DataBase64 = !utf8.Valid(e.DataEncoded)
+	}
+	return e
+}
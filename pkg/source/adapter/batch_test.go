@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestAdapter_HandleBatch(t *testing.T) {
+	messages := []*sarama.ConsumerMessage{{
+		Key:       []byte("1"),
+		Topic:     "topic1",
+		Value:     []byte(`{"key":"value"}`),
+		Partition: 1,
+		Offset:    2,
+	}, {
+		Key:       []byte("2"),
+		Topic:     "topic1",
+		Partition: 1,
+		Offset:    3,
+	}, {
+		Topic:     "topic1",
+		Value:     []byte{0xff, 0x00},
+		Partition: 1,
+		Offset:    4,
+	}, {
+		Topic: "topic1",
+		Value: []byte("plain text"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("content-type"), Value: []byte("text/plain")},
+		},
+		Partition: 1,
+		Offset:    5,
+	}}
+
+	sinkHandler := &fakeHandler{handler: sinkAccepted}
+	sinkServer := httptest.NewServer(sinkHandler)
+	defer sinkServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+	a.tombstones = &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicySkip}
+
+	commit, err := a.HandleBatch(context.TODO(), messages)
+	require.Nil(t, err)
+	assert.True(t, commit)
+
+	assert.Equal(t, "application/cloudevents-batch+json", sinkHandler.header.Get("Content-Type"))
+
+	var events []map[string]interface{}
+	require.Nil(t, json.Unmarshal(sinkHandler.body, &events))
+	require.Len(t, events, 3)
+
+	assert.Equal(t, makeEventId(1, 2), events[0]["id"])
+	assert.Equal(t, "1", events[0]["key"])
+	assert.Equal(t, map[string]interface{}{"key": "value"}, events[0]["data"])
+	assert.Equal(t, makeEventId(1, 4), events[1]["id"])
+	assert.Equal(t, "/wA=", events[1]["data_base64"])
+	assert.Equal(t, makeEventId(1, 5), events[2]["id"])
+	assert.Equal(t, "text/plain", events[2]["datacontenttype"])
+	assert.Equal(t, "cGxhaW4gdGV4dA==", events[2]["data_base64"])
+}
+
+func TestAdapter_HandleBatchDeadLetterSink(t *testing.T) {
+	messages := []*sarama.ConsumerMessage{{
+		Topic:     "topic1",
+		Value:     []byte(`{"key":"value"}`),
+		Partition: 1,
+		Offset:    2,
+	}}
+
+	sinkServer := httptest.NewServer(&fakeHandler{handler: sinkRejected})
+	defer sinkServer.Close()
+
+	dlsHandler := &fakeHandler{handler: sinkAccepted}
+	dlsServer := httptest.NewServer(dlsHandler)
+	defer dlsServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+
+	commit, err := a.HandleBatch(context.TODO(), messages)
+	assert.NotNil(t, err)
+	assert.False(t, commit, "the batch should not be committed without dead letter sink")
+
+	dls, err := kncloudevents.NewHTTPMessageSenderWithTarget(dlsServer.URL)
+	require.Nil(t, err)
+	a.deadLetterSender = dls
+
+	commit, err = a.HandleBatch(context.TODO(), messages)
+	require.Nil(t, err)
+	assert.True(t, commit)
+
+	var events []map[string]interface{}
+	require.Nil(t, json.Unmarshal(dlsHandler.body, &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, makeEventId(1, 2), events[0]["id"])
+}
+
+func TestAdapter_HandleBatchDeserializer(t *testing.T) {
+	registryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/schemas/ids/1" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"schema":"{\"type\":\"record\",\"name\":\"Event\",\"fields\":[{\"name\":\"a\",\"type\":\"string\"}]}"}`))
+	}))
+	defer registryServer.Close()
+
+	messages := []*sarama.ConsumerMessage{{
+		Topic:     "topic1",
+		Value:     []byte{0, 0, 0, 0, 1, 2, 'b'},
+		Partition: 1,
+		Offset:    2,
+	}, {
+		Topic:     "topic1",
+		Value:     []byte(`{"a":"b"}`),
+		Partition: 1,
+		Offset:    3,
+	}}
+
+	sinkHandler := &fakeHandler{handler: sinkAccepted}
+	sinkServer := httptest.NewServer(sinkHandler)
+	defer sinkServer.Close()
+
+	dlsHandler := &fakeHandler{handler: sinkAccepted}
+	dlsServer := httptest.NewServer(dlsHandler)
+	defer dlsServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+	a.config.Deserializer = `{"schemaRegistryUrl":"` + registryServer.URL + `","value":"avro"}`
+	require.Nil(t, a.initDeserializers())
+	dls, err := kncloudevents.NewHTTPMessageSenderWithTarget(dlsServer.URL)
+	require.Nil(t, err)
+	a.deadLetterSender = dls
+
+	// The Undecodable Message Is Sent To The Dead Letter Sink As Is
+	commit, err := a.HandleBatch(context.TODO(), messages)
+	require.Nil(t, err)
+	assert.True(t, commit)
+
+	var events []map[string]interface{}
+	require.Nil(t, json.Unmarshal(sinkHandler.body, &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, makeEventId(1, 2), events[0]["id"])
+	assert.Equal(t, map[string]interface{}{"a": "b"}, events[0]["data"])
+
+	var deadLettered []map[string]interface{}
+	require.Nil(t, json.Unmarshal(dlsHandler.body, &deadLettered))
+	require.Len(t, deadLettered, 1)
+	assert.Equal(t, makeEventId(1, 3), deadLettered[0]["id"])
+	assert.Equal(t, "application/octet-stream", deadLettered[0]["datacontenttype"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(`{"a":"b"}`)), deadLettered[0]["data_base64"])
+
+	// The Batch Is Not Committed While The Schema Registry Is Unavailable
+	sinkHandler.body, dlsHandler.body = nil, nil
+	commit, err = a.HandleBatch(context.TODO(), []*sarama.ConsumerMessage{{
+		Topic:     "topic1",
+		Value:     []byte{0, 0, 0, 0, 2, 2, 'b'},
+		Partition: 1,
+		Offset:    4,
+	}})
+	assert.NotNil(t, err)
+	assert.False(t, commit)
+	assert.Nil(t, sinkHandler.body)
+	assert.Nil(t, dlsHandler.body)
+}
+
+func TestAdapter_HandleBatchTombstones(t *testing.T) {
+	sinkHandler := &fakeHandler{handler: sinkAccepted}
+	sinkServer := httptest.NewServer(sinkHandler)
+	defer sinkServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+	a.tombstones = &sourcesv1beta1.TombstoneSpec{Policy: sourcesv1beta1.TombstonePolicySkip}
+
+	commit, err := a.HandleBatch(context.TODO(), []*sarama.ConsumerMessage{{Topic: "topic1", Partition: 1, Offset: 2}})
+	require.Nil(t, err)
+	assert.True(t, commit)
+	assert.Nil(t, sinkHandler.header, "a batch of skipped records should not be sent")
+}
+
+func newBatchAdapter(t *testing.T, sinkURL string) *Adapter {
+	statsReporter, _ := source.NewStatsReporter()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sinkURL)
+	require.Nil(t, err)
+
+	return &Adapter{
+		config: &AdapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Sink:      sinkURL,
+				Namespace: "test",
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Name:          "test",
+		},
+		httpMessageSender: s,
+		logger:            zap.NewNop().Sugar(),
+		reporter:          statsReporter,
+		keyTypeMapper:     getKeyTypeMapper(""),
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// headerMapping converts the Kafka record headers to CloudEvent extensions.
+// A nil headerMapping sets every header as the kafkaheader<name> string extension.
+type headerMapping struct {
+	drop   bool
+	allow  map[string]bool
+	deny   map[string]bool
+	rename map[string]string
+	base64 bool
+}
+
+func newHeaderMapping(spec *v1beta1.HeaderMappingSpec) *headerMapping {
+	hm := &headerMapping{
+		drop:   spec.Drop,
+		allow:  make(map[string]bool, len(spec.Allow)),
+		deny:   make(map[string]bool, len(spec.Deny)),
+		rename: make(map[string]string, len(spec.Rename)),
+		base64: spec.Base64,
+	}
+	// The header names of the messages are lower-cased by the protocol binding.
+	for _, header := range spec.Allow {
+		hm.allow[strings.ToLower(header)] = true
+	}
+	for _, header := range spec.Deny {
+		hm.deny[strings.ToLower(header)] = true
+	}
+	for header, extension := range spec.Rename {
+		hm.rename[strings.ToLower(header)] = extension
+	}
+	return hm
+}
+
+// extension returns the name and value of the extension of the header,
+// or false when the header is not converted.
+func (hm *headerMapping) extension(header string, value []byte) (string, interface{}, bool) {
+	if hm == nil {
+		return "kafkaheader" + replaceBadCharacters(header, ""), string(value), true
+	}
+	if hm.drop || hm.deny[header] || (len(hm.allow) > 0 && !hm.allow[header]) {
+		return "", nil, false
+	}
+
+	name, ok := hm.rename[header]
+	if !ok {
+		name = "kafkaheader" + replaceBadCharacters(header, "")
+	}
+	if hm.base64 && !utf8.Valid(value) {
+		// Binary extensions are encoded in base64.
+		return name, value, true
+	}
+	return name, string(value), true
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestAdapter_HeaderMapping(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Topic:     "topic1",
+		Value:     []byte(`{"key":"value"}`),
+		Partition: 1,
+		Offset:    2,
+		Timestamp: time.Now(),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("X-Tenant-Id"), Value: []byte("tenant-a")},
+			{Key: []byte("Authorization"), Value: []byte("Bearer token")},
+			{Key: []byte("trace"), Value: []byte{0xff, 0x00, 0x17}},
+		},
+	}
+
+	testCases := map[string]struct {
+		mapping     *sourcesv1beta1.HeaderMappingSpec
+		wantHeaders map[string]string
+	}{
+		"default": {
+			wantHeaders: map[string]string{
+				"ce-kafkaheaderxtenantid":     "tenant-a",
+				"ce-kafkaheaderauthorization": "Bearer token",
+				"ce-kafkaheadertrace":         string([]byte{0xff, 0x00, 0x17}),
+			},
+		},
+		"drop": {
+			mapping:     &sourcesv1beta1.HeaderMappingSpec{Drop: true},
+			wantHeaders: map[string]string{},
+		},
+		"allow": {
+			mapping: &sourcesv1beta1.HeaderMappingSpec{Allow: []string{"x-tenant-id"}},
+			wantHeaders: map[string]string{
+				"ce-kafkaheaderxtenantid": "tenant-a",
+			},
+		},
+		"deny and base64": {
+			mapping: &sourcesv1beta1.HeaderMappingSpec{Deny: []string{"AUTHORIZATION"}, Base64: true},
+			wantHeaders: map[string]string{
+				"ce-kafkaheaderxtenantid": "tenant-a",
+				"ce-kafkaheadertrace":     "/wAX",
+			},
+		},
+		"rename": {
+			mapping: &sourcesv1beta1.HeaderMappingSpec{
+				Deny:   []string{"trace"},
+				Rename: map[string]string{"X-Tenant-Id": "tenant"},
+			},
+			wantHeaders: map[string]string{
+				"ce-tenant":                   "tenant-a",
+				"ce-kafkaheaderauthorization": "Bearer token",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Namespace: "test",
+					},
+					Name: "test",
+				},
+				logger:        zap.NewNop().Sugar(),
+				keyTypeMapper: getKeyTypeMapper(""),
+			}
+			if tc.mapping != nil {
+				a.headerMapping = newHeaderMapping(tc.mapping)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://sink", nil)
+			require.Nil(t, err)
+			require.Nil(t, a.ConsumerMessageToHttpRequest(context.TODO(), message, req))
+
+			gotHeaders := map[string]string{}
+			for k := range req.Header {
+				k = strings.ToLower(k)
+				if strings.HasPrefix(k, "ce-kafkaheader") || k == "ce-tenant" {
+					gotHeaders[k] = req.Header.Get(k)
+				}
+			}
+			assert.Equal(t, tc.wantHeaders, gotHeaders)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"text/template"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// cloudEventMapping holds the parsed templates of the CloudEvent attributes
+// (nil when the default attribute is used).
+type cloudEventMapping struct {
+	eventType *template.Template
+	id        *template.Template
+	subject   *template.Template
+	source    *template.Template
+}
+
+func newCloudEventMapping(spec *v1beta1.CloudEventMappingSpec) (mapping *cloudEventMapping, err error) {
+	mapping = &cloudEventMapping{}
+	for _, attribute := range []struct {
+		name     string
+		text     string
+		template **template.Template
+	}{
+		{"type", spec.Type, &mapping.eventType},
+		{"id", spec.ID, &mapping.id},
+		{"subject", spec.Subject, &mapping.subject},
+		{"source", spec.Source, &mapping.source},
+	} {
+		if attribute.text == "" {
+			continue
+		}
+		if *attribute.template, err = v1beta1.ParseCloudEventTemplate(attribute.name, attribute.text); err != nil {
+			return nil, fmt.Errorf("failed to parse the %s template: %w", attribute.name, err)
+		}
+	}
+	return mapping, nil
+}
+
+// recordTemplateData is the data the CloudEvent attribute templates are executed against.
+type recordTemplateData struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       string
+	Headers   map[string]string
+
+	data  []byte
+	value interface{}
+	err   error
+}
+
+func newRecordTemplateData(cm *sarama.ConsumerMessage, data []byte) *recordTemplateData {
+	headers := make(map[string]string, len(cm.Headers))
+	for _, header := range cm.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	return &recordTemplateData{
+		Topic:     cm.Topic,
+		Partition: cm.Partition,
+		Offset:    cm.Offset,
+		Key:       string(cm.Key),
+		Headers:   headers,
+		data:      data,
+	}
+}
+
+// Value returns the event data parsed as JSON. It is only parsed once, when first
+// referenced by a template.
+func (d *recordTemplateData) Value() (interface{}, error) {
+	if d.value == nil && d.err == nil {
+		if d.err = json.Unmarshal(d.data, &d.value); d.err != nil {
+			d.err = fmt.Errorf("the value is not valid JSON: %w", d.err)
+		}
+	}
+	return d.value, d.err
+}
+
+// applyCloudEventMapping sets the attributes of the event rendered by the templates,
+// keeping the default attributes when a template fails or renders an empty string.
+func (a *Adapter) applyCloudEventMapping(event *cloudevents.Event, cm *sarama.ConsumerMessage) {
+	data := newRecordTemplateData(cm, event.Data())
+
+	if value, ok := a.executeTemplate(a.ceMapping.eventType, data); ok {
+		event.SetType(value)
+	}
+	if value, ok := a.executeTemplate(a.ceMapping.id, data); ok {
+		event.SetID(value)
+	}
+	if value, ok := a.executeTemplate(a.ceMapping.subject, data); ok {
+		event.SetSubject(value)
+	}
+	if value, ok := a.executeTemplate(a.ceMapping.source, data); ok {
+		if _, err := url.Parse(value); err != nil {
+			a.logger.Debugw("Invalid CloudEvent source, using the default one", zap.String("source", value), zap.Error(err))
+		} else {
+			event.SetSource(value)
+		}
+	}
+}
+
+func (a *Adapter) executeTemplate(tmpl *template.Template, data *recordTemplateData) (string, bool) {
+	if tmpl == nil {
+		return "", false
+	}
+	var value bytes.Buffer
+	if err := tmpl.Execute(&value, data); err != nil {
+		a.logger.Debugw("Failed to execute the CloudEvent attribute template, using the default attribute", zap.String("attribute", tmpl.Name()), zap.Error(err))
+		return "", false
+	}
+	return value.String(), value.Len() > 0
+}
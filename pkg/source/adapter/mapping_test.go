@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestAdapter_CloudEventMapping(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Key:       []byte("tenant-a"),
+		Topic:     "topic1",
+		Value:     []byte(`{"kind":"order.created","order":{"id":"42"}}`),
+		Partition: 1,
+		Offset:    2,
+		Timestamp: time.Now(),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("Region"), Value: []byte("eu-west")},
+		},
+	}
+
+	testCases := map[string]struct {
+		mapping     sourcesv1beta1.CloudEventMappingSpec
+		message     *sarama.ConsumerMessage
+		wantType    string
+		wantID      string
+		wantSubject string
+		wantSource  string
+	}{
+		"all attributes": {
+			mapping: sourcesv1beta1.CloudEventMappingSpec{
+				Type:    "com.example.{{ .Value.kind }}",
+				ID:      "{{ .Topic }}/{{ .Partition }}/{{ .Offset }}",
+				Subject: "orders/{{ .Value.order.id }}",
+				Source:  `/regions/{{ index .Headers "Region" }}/tenants/{{ .Key }}`,
+			},
+			message:     message,
+			wantType:    "com.example.order.created",
+			wantID:      "topic1/1/2",
+			wantSubject: "orders/42",
+			wantSource:  "/regions/eu-west/tenants/tenant-a",
+		},
+		"missing fields keep the default attributes": {
+			mapping: sourcesv1beta1.CloudEventMappingSpec{
+				Type:    "{{ .Value.type }}",
+				Subject: `{{ index .Headers "Subject" }}`,
+			},
+			message:     message,
+			wantType:    sourcesv1beta1.KafkaEventType,
+			wantID:      makeEventId(1, 2),
+			wantSubject: makeEventSubject(1, 2),
+			wantSource:  sourcesv1beta1.KafkaEventSource("test", "test", "topic1"),
+		},
+		"non JSON value keeps the default attributes": {
+			mapping: sourcesv1beta1.CloudEventMappingSpec{
+				Type: "com.example.{{ .Value.kind }}",
+				ID:   "{{ .Topic }}-{{ .Offset }}",
+			},
+			message: &sarama.ConsumerMessage{
+				Topic:     "topic1",
+				Value:     []byte("plain text"),
+				Partition: 1,
+				Offset:    2,
+			},
+			wantType:    sourcesv1beta1.KafkaEventType,
+			wantID:      "topic1-2",
+			wantSubject: makeEventSubject(1, 2),
+			wantSource:  sourcesv1beta1.KafkaEventSource("test", "test", "topic1"),
+		},
+		"invalid source keeps the default source": {
+			mapping: sourcesv1beta1.CloudEventMappingSpec{
+				Source: "%{{ .Topic }}",
+			},
+			message:     message,
+			wantType:    sourcesv1beta1.KafkaEventType,
+			wantID:      makeEventId(1, 2),
+			wantSubject: makeEventSubject(1, 2),
+			wantSource:  sourcesv1beta1.KafkaEventSource("test", "test", "topic1"),
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			mapping, err := newCloudEventMapping(&tc.mapping)
+			require.Nil(t, err)
+
+			a := &Adapter{
+				config: &AdapterConfig{
+					EnvConfig: adapter.EnvConfig{
+						Namespace: "test",
+					},
+					Name: "test",
+				},
+				logger:        zap.NewNop().Sugar(),
+				keyTypeMapper: getKeyTypeMapper(""),
+				ceMapping:     mapping,
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "http://sink", nil)
+			require.Nil(t, err)
+			require.Nil(t, a.ConsumerMessageToHttpRequest(context.TODO(), tc.message, req))
+
+			assert.Equal(t, tc.wantType, req.Header.Get("ce-type"))
+			assert.Equal(t, tc.wantID, req.Header.Get("ce-id"))
+			assert.Equal(t, tc.wantSubject, req.Header.Get("ce-subject"))
+			assert.Equal(t, tc.wantSource, req.Header.Get("ce-source"))
+		})
+	}
+}
+
+func TestNewCloudEventMapping_InvalidTemplate(t *testing.T) {
+	mapping, err := newCloudEventMapping(&sourcesv1beta1.CloudEventMappingSpec{Subject: "{{ .Topic"})
+	assert.Nil(t, mapping)
+	assert.NotNil(t, err)
+}
@@ -16,8 +16,11 @@ limitations under the License.
 package kafka
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/json"
+	"fmt"
 	"math"
 	nethttp "net/http"
 	"regexp"
@@ -48,9 +51,62 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 		return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), msg, req, extensionAsTransformer(a.extensions))
 	}
 
+	if a.config.DetectStructuredCloudEvents {
+		if event, ok := structuredCloudEvent(msg.Value); ok {
+			a.logger.Debug("Message is a structured CloudEvent without content-type -> Encode directly to HTTP")
+			return http.WriteRequest(cloudevents.WithEncodingBinary(ctx), binding.ToMessage(event), req, extensionAsTransformer(a.extensions))
+		}
+	}
+
 	a.logger.Debug("Message is not a CloudEvent -> We need to translate it to a valid CloudEvent")
-	kafkaMsg := msg
+	event, err := a.makeEvent(ctx, cm, msg)
+	if err != nil {
+		return err
+	}
+
+	return http.WriteRequest(ctx, binding.ToMessage(event), req, extensionAsTransformer(a.extensions))
+}
+
+// rawConsumerMessageToHttpRequest writes the CloudEvent of the message with its
+// value as is to the request, see makeRawEvent.
+func (a *Adapter) rawConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.ConsumerMessage, req *nethttp.Request) error {
+	return http.WriteRequest(ctx, binding.ToMessage(a.makeRawEvent(cm)), req)
+}
+
+// consumerMessageToEvent returns the CloudEvent of the message, with the
+// CloudEvent overrides extensions.
+func (a *Adapter) consumerMessageToEvent(ctx context.Context, cm *sarama.ConsumerMessage) (*cloudevents.Event, error) {
+	msg := protocolkafka.NewMessageFromConsumerMessage(cm)
+
+	defer func() {
+		err := msg.Finish(nil)
+		if err != nil {
+			a.logger.Warnw("Something went wrong while trying to finalizing the message", zap.Error(err))
+		}
+	}()
+
+	var event *cloudevents.Event
+	var err error
+	if msg.ReadEncoding() != binding.EncodingUnknown {
+		event, err = binding.ToEvent(ctx, msg)
+	} else if a.config.DetectStructuredCloudEvents {
+		event, _ = structuredCloudEvent(msg.Value)
+	}
+	if event == nil && err == nil {
+		event, err = a.makeEvent(ctx, cm, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range a.extensions {
+		event.SetExtension(k, v)
+	}
+	return event, nil
+}
 
+// makeEvent translates the message, which is not a CloudEvent, to a CloudEvent.
+func (a *Adapter) makeEvent(ctx context.Context, cm *sarama.ConsumerMessage, kafkaMsg *protocolkafka.Message) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 
 	event.SetID(makeEventId(cm.Partition, cm.Offset))
@@ -59,19 +115,65 @@ func (a *Adapter) ConsumerMessageToHttpRequest(ctx context.Context, cm *sarama.C
 	event.SetSource(sourcesv1beta1.KafkaEventSource(a.config.Namespace, a.config.Name, cm.Topic))
 	event.SetSubject(makeEventSubject(cm.Partition, cm.Offset))
 
-	dumpKafkaMetaToEvent(&event, a.keyTypeMapper, cm.Key, kafkaMsg)
+	dumpKafkaMetaToEvent(&event, a.keyTypeMapper, a.headerMapping, cm.Key, kafkaMsg)
+
+	if a.keyDeserializer != nil && len(cm.Key) > 0 {
+		key, _, err := a.keyDeserializer.Deserialize(ctx, cm.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize the key: %w", err)
+		}
+		event.SetExtension("key", string(key))
+	}
 
-	if kafkaMsg.ContentType == "" {
+	if a.valueDeserializer != nil && len(kafkaMsg.Value) > 0 {
+		data, dataSchema, err := a.valueDeserializer.Deserialize(ctx, kafkaMsg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize the value: %w", err)
+		}
+		event.SetDataSchema(dataSchema)
+		event.SetDataContentType(cloudevents.ApplicationJSON)
+		event.DataEncoded = data
+	} else if kafkaMsg.ContentType == "" {
 		// This avoids base64 encoding when sending as json structured
 		event.DataEncoded = kafkaMsg.Value
 	} else {
 		err := event.SetData(kafkaMsg.ContentType, kafkaMsg.Value)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return http.WriteRequest(ctx, binding.ToMessage(&event), req, extensionAsTransformer(a.extensions))
+	if a.ceMapping != nil {
+		a.applyCloudEventMapping(&event, cm)
+	}
+
+	if cm.Value == nil && a.tombstones != nil && a.tombstones.Policy == sourcesv1beta1.TombstonePolicyEmit {
+		event.SetType(a.tombstones.EventType())
+	}
+
+	return &event, nil
+}
+
+// makeRawEvent returns a CloudEvent of the message with its value as is, for
+// the messages which cannot be translated to a CloudEvent (e.g. undecodable
+// values) to be sent to the dead letter sink rather than being lost.
+func (a *Adapter) makeRawEvent(cm *sarama.ConsumerMessage) *cloudevents.Event {
+	event := cloudevents.NewEvent()
+
+	event.SetID(makeEventId(cm.Partition, cm.Offset))
+	event.SetTime(cm.Timestamp)
+	event.SetType(sourcesv1beta1.KafkaEventType)
+	event.SetSource(sourcesv1beta1.KafkaEventSource(a.config.Namespace, a.config.Name, cm.Topic))
+	event.SetSubject(makeEventSubject(cm.Partition, cm.Offset))
+
+	kafkaMsg := protocolkafka.NewMessageFromConsumerMessage(cm)
+	dumpKafkaMetaToEvent(&event, a.keyTypeMapper, a.headerMapping, cm.Key, kafkaMsg)
+	_ = event.SetData("application/octet-stream", cm.Value)
+
+	for k, v := range a.extensions {
+		event.SetExtension(k, v)
+	}
+	return &event
 }
 
 func makeEventId(partition int32, offset int64) string {
@@ -93,16 +195,38 @@ func makeEventSubject(partition int32, offset int64) string {
 	return str.String()
 }
 
+// structuredCloudEvent returns the CloudEvent of the value when it is a valid
+// structured CloudEvent in the JSON format.
+func structuredCloudEvent(value []byte) (*cloudevents.Event, bool) {
+	// Cheap checks first, most values are not CloudEvents.
+	trimmed := bytes.TrimLeft(value, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' || !bytes.Contains(trimmed, []byte(`"specversion"`)) {
+		return nil, false
+	}
+
+	event := cloudevents.NewEvent()
+	if err := json.Unmarshal(trimmed, &event); err != nil {
+		return nil, false
+	}
+	if err := event.Validate(); err != nil {
+		return nil, false
+	}
+	return &event, true
+}
+
 var replaceBadCharacters = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString
 
-func dumpKafkaMetaToEvent(event *cloudevents.Event, keyTypeMapper func([]byte) interface{}, key []byte, msg *protocolkafka.Message) {
+func dumpKafkaMetaToEvent(event *cloudevents.Event, keyTypeMapper func([]byte) interface{}, headerMapping *headerMapping, key []byte, msg *protocolkafka.Message) {
 	if len(key) > 0 {
 		event.SetExtension("key", keyTypeMapper(key))
 	}
 	for k, v := range msg.Headers {
 		// Let's skip the content-type, we already transport it with datacontenttype field
-		if k != "content-type" {
-			event.SetExtension("kafkaheader"+replaceBadCharacters(k, ""), string(v))
+		if k == "content-type" {
+			continue
+		}
+		if name, value, ok := headerMapping.extension(k, v); ok {
+			event.SetExtension(name, value)
 		}
 	}
 }
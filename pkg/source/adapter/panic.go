@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// PanicError is returned by Start when handling messages panicked, so that
+// the adapter can be restarted without taking down the whole process.
+type PanicError struct {
+	// Value is the value the handler panicked with.
+	Value interface{}
+
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic while handling messages: %v", e.Value)
+}
+
+// recoverPanic recovers from a panic while handling messages. The messages
+// are not marked, and Start returns the PanicError to stop the adapter.
+func (a *Adapter) recoverPanic(mustMark *bool, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	panicErr := &PanicError{Value: r, Stack: debug.Stack()}
+	a.logger.Errorw("Recovered from a panic while handling messages",
+		zap.Any("panic", r),
+		zap.ByteString("stack", panicErr.Stack))
+
+	*mustMark, *err = false, panicErr
+
+	// Only the first panic stops the adapter
+	select {
+	case a.panics <- panicErr:
+	default:
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/Shopify/sarama"
+	protocolkafka "github.com/cloudevents/sdk-go/protocol/kafka_sarama/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+)
+
+// newReplyProducer creates the producer of the replies, with the client
+// configuration of the consumer group.
+func newReplyProducer(addrs []string, config *sarama.Config) (sarama.SyncProducer, error) {
+	producerConfig := *config
+	producerConfig.Producer.Return.Successes = true
+	return sarama.NewSyncProducer(addrs, &producerConfig)
+}
+
+func (a *Adapter) closeReplyProducer() {
+	if err := a.replyProducer.Close(); err != nil {
+		a.logger.Errorw("Failed to close the reply producer", zap.Error(err))
+	}
+}
+
+// forwardReplies forwards the CloudEvents of the response of the sink (if any)
+// to the reply sink or topic. The event was delivered to the sink regardless
+// of the outcome, so the replies which cannot be forwarded are sent to the
+// dead letter sink (if any) instead of failing the delivery of the event.
+func (a *Adapter) forwardReplies(ctx context.Context, res *http.Response) {
+	events, err := replyEvents(ctx, res)
+	if err != nil {
+		a.logger.Errorw("Failed to read the reply of the sink, dropping it", zap.Error(err))
+		return
+	}
+
+	for _, event := range events {
+		if err := a.forwardReply(ctx, event); err != nil {
+			a.sendReplyToDeadLetterSink(ctx, event, err)
+		}
+	}
+}
+
+// sendReplyToDeadLetterSink sends a reply which could not be forwarded to the
+// dead letter sink, or drops it when there is none.
+func (a *Adapter) sendReplyToDeadLetterSink(ctx context.Context, event *cloudevents.Event, replyErr error) {
+	logger := a.logger.With(zap.String("id", event.ID()), zap.Error(replyErr))
+	if a.deadLetterSender == nil {
+		logger.Error("Failed to forward the reply, dropping it")
+		return
+	}
+	logger.Warn("Failed to forward the reply, sending it to the dead letter sink")
+
+	req, err := a.deadLetterSender.NewCloudEventRequest(ctx)
+	if err == nil {
+		err = cehttp.WriteRequest(ctx, binding.ToMessage(event), req)
+	}
+	if err == nil {
+		_, err = a.send(ctx, a.deadLetterSender, req)
+	}
+	if err != nil {
+		logger.Errorw("Failed to send the reply to the dead letter sink, dropping it", zap.NamedError("deadLetterError", err))
+	}
+}
+
+func (a *Adapter) forwardReply(ctx context.Context, event *cloudevents.Event) error {
+	if a.replySender != nil {
+		req, err := a.replySender.NewCloudEventRequest(ctx)
+		if err != nil {
+			return err
+		}
+		if err := cehttp.WriteRequest(ctx, binding.ToMessage(event), req); err != nil {
+			return err
+		}
+		_, err = a.send(ctx, a.replySender, req)
+		return err
+	}
+
+	msg := &sarama.ProducerMessage{Topic: a.config.ReplyTopic}
+	if err := protocolkafka.WriteProducerMessage(ctx, binding.ToMessage(event), msg); err != nil {
+		return err
+	}
+	_, _, err := a.replyProducer.SendMessage(msg)
+	return err
+}
+
+// replyEvents returns the CloudEvents of the response, which can be a single
+// CloudEvent (in the binary or structured mode) or a batch of CloudEvents.
+func replyEvents(ctx context.Context, res *http.Response) ([]*cloudevents.Event, error) {
+	if res.Body == nil || res.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType == cloudevents.ApplicationCloudEventsBatchJSON {
+		var events []*cloudevents.Event
+		if err := json.NewDecoder(res.Body).Decode(&events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+
+	msg := cehttp.NewMessageFromHttpResponse(res)
+	if msg.ReadEncoding() == binding.EncodingUnknown {
+		// The response is not a CloudEvent
+		return nil, nil
+	}
+	event, err := binding.ToEvent(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return []*cloudevents.Event{event}, nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+type fakeSyncProducer struct {
+	lock     sync.Mutex
+	messages []*sarama.ProducerMessage
+	err      error
+}
+
+func (p *fakeSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return 0, 0, p.err
+	}
+	p.messages = append(p.messages, msg)
+	return 0, int64(len(p.messages)), nil
+}
+
+func (p *fakeSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		_, _, _ = p.SendMessage(msg)
+	}
+	return nil
+}
+
+func (p *fakeSyncProducer) Close() error {
+	return nil
+}
+
+func sinkReplying(header map[string]string, body string) func(http.ResponseWriter, *http.Request) {
+	return func(writer http.ResponseWriter, _ *http.Request) {
+		for k, v := range header {
+			writer.Header().Set(k, v)
+		}
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(body))
+	}
+}
+
+var binaryReply = sinkReplying(map[string]string{
+	"ce-specversion": "1.0",
+	"ce-id":          "reply-1",
+	"ce-source":      "/replies",
+	"ce-type":        "order.processed",
+	"content-type":   "application/json",
+}, `{"status":"ok"}`)
+
+func TestAdapter_ReplySink(t *testing.T) {
+	testCases := map[string]struct {
+		sink      func(http.ResponseWriter, *http.Request)
+		wantReply bool
+	}{
+		"binary reply": {
+			sink:      binaryReply,
+			wantReply: true,
+		},
+		"structured reply": {
+			sink: sinkReplying(map[string]string{"content-type": "application/cloudevents+json"},
+				`{"specversion":"1.0","id":"reply-1","source":"/replies","type":"order.processed","data":{"status":"ok"}}`),
+			wantReply: true,
+		},
+		"no reply": {
+			sink: sinkReplying(map[string]string{"content-type": "application/json"}, `{"status":"ok"}`),
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sinkServer := httptest.NewServer(&fakeHandler{handler: tc.sink})
+			defer sinkServer.Close()
+
+			replyHandler := &fakeHandler{handler: sinkAccepted}
+			replyServer := httptest.NewServer(replyHandler)
+			defer replyServer.Close()
+
+			a := newBatchAdapter(t, sinkServer.URL)
+			var err error
+			a.replySender, err = kncloudevents.NewHTTPMessageSenderWithTarget(replyServer.URL)
+			require.Nil(t, err)
+
+			commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+				Topic:     "topic1",
+				Value:     []byte(`{"order":"42"}`),
+				Partition: 1,
+				Offset:    2,
+			})
+			require.Nil(t, err)
+			assert.True(t, commit)
+
+			if !tc.wantReply {
+				assert.Nil(t, replyHandler.header, "no reply should be forwarded")
+				return
+			}
+			require.NotNil(t, replyHandler.header, "the reply should be forwarded")
+			assert.Equal(t, "reply-1", replyHandler.header.Get("ce-id"))
+			assert.Equal(t, "order.processed", replyHandler.header.Get("ce-type"))
+			assert.JSONEq(t, `{"status":"ok"}`, string(replyHandler.body))
+		})
+	}
+}
+
+func TestAdapter_ReplySinkFailure(t *testing.T) {
+	sinkServer := httptest.NewServer(&fakeHandler{handler: binaryReply})
+	defer sinkServer.Close()
+
+	replyServer := httptest.NewServer(&fakeHandler{handler: sinkRejected})
+	defer replyServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+	var err error
+	a.replySender, err = kncloudevents.NewHTTPMessageSenderWithTarget(replyServer.URL)
+	require.Nil(t, err)
+
+	commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Topic:     "topic1",
+		Value:     []byte(`{"order":"42"}`),
+		Partition: 1,
+		Offset:    2,
+	})
+	assert.Nil(t, err)
+	assert.True(t, commit, "the message should be committed even when its reply cannot be forwarded")
+}
+
+func TestAdapter_ReplyTopicFailure(t *testing.T) {
+	sinkHandler := &fakeHandler{handler: binaryReply}
+	sinkServer := httptest.NewServer(sinkHandler)
+	defer sinkServer.Close()
+
+	deadLetterHandler := &fakeHandler{handler: sinkAccepted}
+	deadLetterServer := httptest.NewServer(deadLetterHandler)
+	defer deadLetterServer.Close()
+
+	a := newBatchAdapter(t, sinkServer.URL)
+	a.config.ReplyTopic = "replies"
+	a.replyProducer = &fakeSyncProducer{err: sarama.ErrOutOfBrokers}
+	var err error
+	a.deadLetterSender, err = kncloudevents.NewHTTPMessageSenderWithTarget(deadLetterServer.URL)
+	require.Nil(t, err)
+
+	commit, err := a.Handle(context.TODO(), &sarama.ConsumerMessage{
+		Topic:     "topic1",
+		Value:     []byte(`{"order":"42"}`),
+		Partition: 1,
+		Offset:    2,
+	})
+	require.Nil(t, err)
+	assert.True(t, commit, "the message accepted by the sink should be committed")
+
+	// The reply is dead lettered, not the original event
+	require.NotNil(t, deadLetterHandler.header, "the reply should be sent to the dead letter sink")
+	assert.Equal(t, "reply-1", deadLetterHandler.header.Get("ce-id"))
+	assert.Equal(t, "order.processed", deadLetterHandler.header.Get("ce-type"))
+	assert.JSONEq(t, `{"status":"ok"}`, string(deadLetterHandler.body))
+}
+
+func TestAdapter_ReplyTopic(t *testing.T) {
+	sinkServer := httptest.NewServer(&fakeHandler{handler: sinkReplying(
+		map[string]string{"content-type": "application/cloudevents-batch+json"},
+		`[{"specversion":"1.0","id":"reply-1","source":"/replies","type":"order.processed","partitionkey":"42"},
+		  {"specversion":"1.0","id":"reply-2","source":"/replies","type":"order.processed"}]`,
+	)})
+	defer sinkServer.Close()
+
+	producer := &fakeSyncProducer{}
+	a := newBatchAdapter(t, sinkServer.URL)
+	a.config.ReplyTopic = "replies"
+	a.replyProducer = producer
+
+	commit, err := a.HandleBatch(context.TODO(), []*sarama.ConsumerMessage{{
+		Topic:     "topic1",
+		Value:     []byte(`{"order":"42"}`),
+		Partition: 1,
+		Offset:    2,
+	}})
+	require.Nil(t, err)
+	assert.True(t, commit)
+
+	require.Len(t, producer.messages, 2)
+	for i, msg := range producer.messages {
+		assert.Equal(t, "replies", msg.Topic)
+		headers := map[string]string{}
+		for _, header := range msg.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		assert.Equal(t, []string{"reply-1", "reply-2"}[i], headers["ce_id"])
+	}
+	assert.Equal(t, sarama.StringEncoder("42"), producer.messages[0].Key)
+}
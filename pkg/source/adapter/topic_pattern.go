@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/consumer"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// topicPatternRefreshInterval is the interval at which the topics matching the
+// topic pattern are resolved again.
+var topicPatternRefreshInterval = time.Minute
+
+// consumeTopicPattern consumes the topics matching the topic pattern, and re-joins the
+// consumer group whenever the matching topics change. The offsets of the newly
+// discovered topics are initialized before they are consumed.
+func (a *Adapter) consumeTopicPattern(ctx context.Context, consumerGroupFactory consumer.KafkaConsumerGroupFactory, addrs []string) error {
+	pattern, err := v1beta1.CompileTopicPattern(a.config.TopicPattern)
+	if err != nil {
+		return fmt.Errorf("failed to compile the topic pattern: %w", err)
+	}
+
+	// InitOffsets manually commits offsets if needed
+	_, config, err := client.NewConfigWithEnv(context.Background(), &a.config.KafkaEnvConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create the config: %w", err)
+	}
+	config.Consumer.Offsets.AutoCommit.Enable = false
+
+	kafkaClient, err := sarama.NewClient(addrs, config)
+	if err != nil {
+		return fmt.Errorf("failed to create the Kafka client: %w", err)
+	}
+	kafkaAdminClient, err := sarama.NewClusterAdminFromClient(kafkaClient)
+	if err != nil {
+		kafkaClient.Close()
+		return fmt.Errorf("failed to create the Kafka admin client: %w", err)
+	}
+	// Closing the admin client closes the Kafka client as well
+	defer kafkaAdminClient.Close()
+
+	var group sarama.ConsumerGroup
+	var topics []string
+	defer func() {
+		if group != nil {
+			a.closeConsumerGroup(group)
+		}
+	}()
+
+	ticker := time.NewTicker(topicPatternRefreshInterval)
+	defer ticker.Stop()
+	for {
+		matched, err := client.MatchTopics(kafkaClient, pattern)
+		if err != nil {
+			a.logger.Errorw("Failed to resolve the topic pattern", zap.Error(err))
+		} else if !equalTopics(topics, matched) {
+			// The new topics are only consumed once their offsets are initialized
+			if err := a.initTopicOffsets(ctx, kafkaClient, kafkaAdminClient, newTopics(topics, matched)); err != nil {
+				a.logger.Errorw("Failed to initialize the offsets of the new topics", zap.Error(err))
+			} else {
+				a.logger.Infow("Topics matching the topic pattern changed", zap.Strings("topics", matched))
+				if group != nil {
+					a.closeConsumerGroup(group)
+					group = nil
+				}
+				if len(matched) > 0 {
+					if group, err = a.startConsumerGroup(ctx, consumerGroupFactory, matched); err != nil {
+						return err
+					}
+				}
+				topics = matched
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			a.logger.Info("Shutting down...")
+			return nil
+		case panicErr := <-a.panics:
+			return panicErr
+		case <-ticker.C:
+		}
+	}
+}
+
+// initTopicOffsets initializes the consumer group offsets of the topics to the initial offset.
+func (a *Adapter) initTopicOffsets(ctx context.Context, kafkaClient sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string) error {
+	initialOffset, err := a.config.InitialOffset.ParseSaramaOffsetTime(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to parse the initial offset: %w", err)
+	}
+	_, err = offset.InitOffsets(ctx, kafkaClient, kafkaAdminClient, topics, a.config.ConsumerGroup, initialOffset)
+	return err
+}
+
+// equalTopics returns whether the sorted topic lists are equal.
+func equalTopics(topics, matched []string) bool {
+	if len(topics) != len(matched) {
+		return false
+	}
+	for i := range topics {
+		if topics[i] != matched[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newTopics returns the matched topics which are not part of the sorted topic list.
+func newTopics(topics, matched []string) []string {
+	known := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		known[topic] = true
+	}
+	added := make([]string, 0, len(matched))
+	for _, topic := range matched {
+		if !known[topic] {
+			added = append(added, topic)
+		}
+	}
+	return added
+}
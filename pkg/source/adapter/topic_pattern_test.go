@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+
+	consumertesting "knative.dev/eventing-kafka/pkg/common/consumer/testing"
+	kafkatesting "knative.dev/eventing-kafka/pkg/common/kafka/testing"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// topicPatternBroker configures the broker to list the topics, each with a single initialized partition.
+func topicPatternBroker(t *testing.T, broker *sarama.MockBroker, group string, topics ...string) {
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetController(broker.BrokerID()).
+		SetBroker(broker.Addr(), broker.BrokerID())
+	offsetResponse := sarama.NewMockOffsetResponse(t).SetVersion(1)
+	offsetFetchResponse := sarama.NewMockOffsetFetchResponse(t).SetError(sarama.ErrNoError)
+	for _, topic := range topics {
+		metadataResponse = metadataResponse.SetLeader(topic, 0, broker.BrokerID())
+		offsetResponse = offsetResponse.SetOffset(topic, 0, -1, 5)
+		offsetFetchResponse = offsetFetchResponse.SetOffset(group, topic, 0, 2, "", sarama.ErrNoError)
+	}
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"OffsetRequest":      offsetResponse,
+		"OffsetFetchRequest": offsetFetchResponse,
+
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+
+		"MetadataRequest": metadataResponse,
+	})
+}
+
+func TestAdapter_ConsumeTopicPattern(t *testing.T) {
+	refreshInterval := topicPatternRefreshInterval
+	topicPatternRefreshInterval = 10 * time.Millisecond
+	defer func() { topicPatternRefreshInterval = refreshInterval }()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "group"
+	topicPatternBroker(t, broker, group, "tenant-a", "other")
+
+	a := &Adapter{
+		config: &AdapterConfig{
+			EnvConfig: adapter.EnvConfig{
+				Namespace: "test",
+			},
+			KafkaEnvConfig: client.KafkaEnvConfig{
+				BootstrapServers: []string{broker.Addr()},
+			},
+			TopicPattern:  "tenant-.*",
+			ConsumerGroup: group,
+			Name:          "test",
+		},
+		logger: zap.NewNop().Sugar(),
+	}
+
+	firstGroup := kafkatesting.NewMockConsumerGroup()
+	firstGroup.On("Errors").Return(firstGroup.ErrorChan)
+	firstGroup.On("Close").Return(nil)
+	secondGroup := kafkatesting.NewMockConsumerGroup()
+	secondGroup.On("Errors").Return(secondGroup.ErrorChan)
+	secondGroup.On("Close").Return(nil)
+
+	started := make(chan []string, 2)
+	factory := &consumertesting.MockKafkaConsumerGroupFactory{}
+	factory.On("StartConsumerGroup", mock.Anything, group, []string{"tenant-a"}, a, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { started <- args.Get(2).([]string) }).
+		Return(firstGroup, nil).Once()
+	factory.On("StartConsumerGroup", mock.Anything, group, []string{"tenant-a", "tenant-b"}, a, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { started <- args.Get(2).([]string) }).
+		Return(secondGroup, nil).Once()
+
+	ctx, cancel := context.WithCancel(logtesting.TestContextWithLogger(t))
+	stopped := make(chan error)
+	go func() {
+		stopped <- a.consumeTopicPattern(ctx, factory, []string{broker.Addr()})
+	}()
+
+	// The matching topics are consumed
+	assert.Equal(t, []string{"tenant-a"}, <-started)
+
+	// The consumer group is re-joined when a new topic matches
+	topicPatternBroker(t, broker, group, "tenant-a", "tenant-b", "other")
+	assert.Equal(t, []string{"tenant-a", "tenant-b"}, <-started)
+
+	cancel()
+	assert.Nil(t, <-stopped)
+
+	factory.AssertExpectations(t)
+	firstGroup.AssertCalled(t, "Close")
+	secondGroup.AssertCalled(t, "Close")
+}
+
+func TestNewTopics(t *testing.T) {
+	assert.Equal(t, []string{"a", "c"}, newTopics(nil, []string{"a", "c"}))
+	assert.Equal(t, []string{"c"}, newTopics([]string{"a", "b"}, []string{"a", "c"}))
+	assert.Equal(t, []string{}, newTopics([]string{"a", "b"}, []string{"a"}))
+	assert.True(t, equalTopics([]string{"a", "b"}, []string{"a", "b"}))
+	assert.False(t, equalTopics([]string{"a", "b"}, []string{"a"}))
+	assert.False(t, equalTopics([]string{"a", "b"}, []string{"a", "c"}))
+}
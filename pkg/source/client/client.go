@@ -55,12 +55,26 @@ type KafkaConfig struct {
 	SaramaYamlString string
 }
 
+// ConsumerConfig holds the consumer settings of a source overriding the ones
+// of the Kafka configmap. It is passed to the adapter as JSON.
+type ConsumerConfig struct {
+	*sourcesv1beta1.ConsumerConfigSpec
+}
+
+// Decode implements envconfig.Decoder
+func (cc *ConsumerConfig) Decode(value string) error {
+	cc.ConsumerConfigSpec = &sourcesv1beta1.ConsumerConfigSpec{}
+	return json.Unmarshal([]byte(value), cc.ConsumerConfigSpec)
+}
+
 type KafkaEnvConfig struct {
 	// KafkaConfigJson is the environment variable that's passed to adapter by the controller.
 	// It contains configuration from the Kafka configmap.
-	KafkaConfigJson  string                `envconfig:"K_KAFKA_CONFIG"`
-	BootstrapServers []string              `envconfig:"KAFKA_BOOTSTRAP_SERVERS" required:"true"`
-	InitialOffset    sourcesv1beta1.Offset `envconfig:"KAFKA_INITIAL_OFFSET" `
+	KafkaConfigJson  string                        `envconfig:"K_KAFKA_CONFIG"`
+	BootstrapServers []string                      `envconfig:"KAFKA_BOOTSTRAP_SERVERS" required:"true"`
+	InitialOffset    sourcesv1beta1.Offset         `envconfig:"KAFKA_INITIAL_OFFSET" `
+	IsolationLevel   sourcesv1beta1.IsolationLevel `envconfig:"KAFKA_ISOLATION_LEVEL"`
+	ConsumerConfig   ConsumerConfig                `envconfig:"KAFKA_CONSUMER_CONFIG"`
 	Net              AdapterNet
 }
 
@@ -97,7 +111,8 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 	configBuilder := client.NewConfigBuilder().
 		WithDefaults().
 		WithAuth(kafkaAuthConfig).
-		WithInitialOffset(env.InitialOffset)
+		WithInitialOffset(env.InitialOffset).
+		WithIsolationLevel(env.IsolationLevel)
 
 	if env.KafkaConfigJson != "" {
 		kafkaCfg := &KafkaConfig{}
@@ -109,6 +124,9 @@ func NewConfigWithEnv(ctx context.Context, env *KafkaEnvConfig) ([]string, *sara
 		configBuilder = configBuilder.FromYaml(kafkaCfg.SaramaYamlString)
 	}
 
+	// The consumer settings of the source take precedence over the Kafka configmap
+	configBuilder = configBuilder.WithConsumerConfig(env.ConsumerConfig.ConsumerConfigSpec)
+
 	cfg, err := configBuilder.Build(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating Sarama config: %w", err)
@@ -161,6 +179,8 @@ func NewEnvConfigFromSpec(ctx context.Context, kc kubernetes.Interface, obj *sou
 	config := KafkaEnvConfig{
 		BootstrapServers: obj.Spec.BootstrapServers,
 		InitialOffset:    obj.Spec.InitialOffset,
+		IsolationLevel:   obj.Spec.IsolationLevel,
+		ConsumerConfig:   ConsumerConfig{obj.Spec.ConsumerConfig.DeepCopy()},
 		Net: AdapterNet{
 			SASL: AdapterSASL{
 				Enable:   obj.Spec.Net.SASL.Enable,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,7 @@ import (
 	"knative.dev/eventing-kafka/pkg/common/client"
 	"knative.dev/pkg/logging"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
 
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +50,7 @@ func TestNewConfig(t *testing.T) {
 		saslMechanism   string
 		bootstrapServer string
 		initialOffset   v1beta1.Offset
+		isolationLevel  sarama.IsolationLevel
 		saslUser        string
 		saslPassword    string
 	}{
@@ -92,6 +95,22 @@ func TestNewConfig(t *testing.T) {
 			bootstrapServer: defaultBootstrapServer,
 			initialOffset:   v1beta1.OffsetEarliest,
 		},
+		"Read committed isolation level": {
+			env: map[string]string{
+				"KAFKA_BOOTSTRAP_SERVERS": defaultBootstrapServer,
+				"KAFKA_ISOLATION_LEVEL":   string(v1beta1.IsolationLevelReadCommitted),
+			},
+			bootstrapServer: defaultBootstrapServer,
+			isolationLevel:  sarama.ReadCommitted,
+		},
+		"Invalid isolation level": {
+			env: map[string]string{
+				"KAFKA_BOOTSTRAP_SERVERS": defaultBootstrapServer,
+				"KAFKA_ISOLATION_LEVEL":   "serializable",
+			},
+			bootstrapServer: defaultBootstrapServer,
+			wantErr:         true,
+		},
 		"Defaulting to SASL-Plain Auth (none specified)": {
 			env: map[string]string{
 				"KAFKA_BOOTSTRAP_SERVERS": defaultBootstrapServer,
@@ -174,13 +193,24 @@ func TestNewConfig(t *testing.T) {
 			for k, v := range tc.env {
 				_ = os.Setenv(k, v)
 			}
+			defer func() {
+				for k := range tc.env {
+					_ = os.Unsetenv(k)
+				}
+			}()
 			servers, config, err := NewConfigFromEnv(context.Background())
 			if err != nil && tc.wantErr != true {
 				t.Fatal(err)
 			}
+			if err != nil {
+				return
+			}
 			if servers[0] != tc.bootstrapServer && tc.wantErr != true {
 				t.Fatalf("Incorrect bootstrapServers, got: %s vs want: %s", servers[0], tc.bootstrapServer)
 			}
+			if config.Consumer.IsolationLevel != tc.isolationLevel {
+				t.Fatalf("Incorrect isolation level, got: %d vs want: %d", config.Consumer.IsolationLevel, tc.isolationLevel)
+			}
 			if tc.enabledSASL {
 				if tc.saslMechanism != string(config.Net.SASL.Mechanism) {
 					t.Fatalf("Incorrect SASL mechanism, got: %s vs want: %s", string(config.Net.SASL.Mechanism), tc.saslMechanism)
@@ -198,13 +228,39 @@ func TestNewConfig(t *testing.T) {
 				}
 			}
 			require.NotNil(t, config)
-			for k := range tc.env {
-				_ = os.Unsetenv(k)
-			}
 		})
 	}
 }
 
+func TestNewConfigConsumerConfig(t *testing.T) {
+	env := map[string]string{
+		"KAFKA_BOOTSTRAP_SERVERS": "my-cluster-kafka-bootstrap.my-kafka-namespace:9092",
+		"K_KAFKA_CONFIG":          `{"SaramaYamlString": "Consumer:\n  Fetch:\n    Min: 16\n    Default: 2048\n"}`,
+		"KAFKA_CONSUMER_CONFIG":   `{"fetch":{"default":4096},"sessionTimeout":"PT20S","rebalanceStrategy":"sticky"}`,
+	}
+	for k, v := range env {
+		_ = os.Setenv(k, v)
+	}
+	defer func() {
+		for k := range env {
+			_ = os.Unsetenv(k)
+		}
+	}()
+
+	_, config, err := NewConfigFromEnv(context.Background())
+	require.NoError(t, err)
+
+	// The consumer config overrides the Kafka configmap
+	require.Equal(t, int32(16), config.Consumer.Fetch.Min)
+	require.Equal(t, int32(4096), config.Consumer.Fetch.Default)
+	require.Equal(t, 20*time.Second, config.Consumer.Group.Session.Timeout)
+	require.Equal(t, sarama.BalanceStrategySticky, config.Consumer.Group.Rebalance.Strategy)
+
+	_ = os.Setenv("KAFKA_CONSUMER_CONFIG", `{"fetch":`)
+	_, _, err = NewConfigFromEnv(context.Background())
+	require.Error(t, err)
+}
+
 func TestAdminClient(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	ctx := logging.WithLogger(context.TODO(), logger)
@@ -310,6 +366,41 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 				},
 			},
 		},
+		"read committed source": {
+			src: &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-name",
+					Namespace: "source-namespace",
+				},
+				Spec: v1beta1.KafkaSourceSpec{
+					Topics: []string{"topic1,topic2"},
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{"server1"},
+					},
+					ConsumerGroup:  "group",
+					IsolationLevel: v1beta1.IsolationLevelReadCommitted,
+				},
+			},
+		},
+		"source with consumer config": {
+			src: &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-name",
+					Namespace: "source-namespace",
+				},
+				Spec: v1beta1.KafkaSourceSpec{
+					Topics: []string{"topic1,topic2"},
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{"server1"},
+					},
+					ConsumerGroup: "group",
+					ConsumerConfig: &v1beta1.ConsumerConfigSpec{
+						Fetch:             &v1beta1.FetchSpec{Default: 4096},
+						MaxProcessingTime: ptr.String("PT1S"),
+					},
+				},
+			},
+		},
 		"source with all auth options": {
 			runtimeObjects: []runtime.Object{
 				constructSecret("the-user-secret", "user", defaultSASLUser),
@@ -412,6 +503,21 @@ sny569QyyWHk2+FZoWDfjxFZ7CvIdgLJBHc3qUXLsg==
 					t.Fatalf("Incorrect initial offset, got: %d vs want: %d", config.Consumer.Offsets.Initial, offset)
 				}
 			}
+			isolationLevel := sarama.ReadUncommitted
+			if tc.src.Spec.IsolationLevel == v1beta1.IsolationLevelReadCommitted {
+				isolationLevel = sarama.ReadCommitted
+			}
+			if config.Consumer.IsolationLevel != isolationLevel {
+				t.Fatalf("Incorrect isolation level, got: %d vs want: %d", config.Consumer.IsolationLevel, isolationLevel)
+			}
+			if tc.src.Spec.ConsumerConfig != nil {
+				if config.Consumer.Fetch.Default != tc.src.Spec.ConsumerConfig.Fetch.Default {
+					t.Fatalf("Incorrect default fetch size, got: %d vs want: %d", config.Consumer.Fetch.Default, tc.src.Spec.ConsumerConfig.Fetch.Default)
+				}
+				if config.Consumer.MaxProcessingTime != time.Second {
+					t.Fatalf("Incorrect max processing time, got: %v vs want: %v", config.Consumer.MaxProcessingTime, time.Second)
+				}
+			}
 			if tc.src.Spec.KafkaAuthSpec.Net.SASL.Enable {
 				if config.Net.SASL.User != defaultSASLUser {
 					t.Fatalf("Incorrect SASL User, got: %s vs want: %s", config.Net.SASL.User, defaultSASLUser)
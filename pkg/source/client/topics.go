@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Shopify/sarama"
+)
+
+// internalTopicPrefix is the name prefix of the Kafka internal topics
+// (e.g. __consumer_offsets), which are never matched by a topic pattern.
+const internalTopicPrefix = "__"
+
+// MatchTopics refreshes the cluster metadata and returns the sorted names of the
+// topics matching the pattern.
+func MatchTopics(kafkaClient sarama.Client, pattern *regexp.Regexp) ([]string, error) {
+	if err := kafkaClient.RefreshMetadata(); err != nil {
+		return nil, fmt.Errorf("failed to refresh the cluster metadata: %w", err)
+	}
+	topics, err := kafkaClient.Topics()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the topics: %w", err)
+	}
+
+	matched := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if !strings.HasPrefix(topic, internalTopicPrefix) && pattern.MatchString(topic) {
+			matched = append(matched, topic)
+		}
+	}
+	sort.Strings(matched)
+	return matched, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestMatchTopics(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	metadataResponse := sarama.NewMockMetadataResponse(t).SetBroker(broker.Addr(), broker.BrokerID())
+	for _, topic := range []string{"tenant-b", "tenant-a", "tenant-a-dlq", "other", "__consumer_offsets"} {
+		metadataResponse.SetLeader(topic, 0, broker.BrokerID())
+	}
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": metadataResponse,
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	kafkaClient, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.Nil(t, err)
+	defer kafkaClient.Close()
+
+	testCases := map[string]struct {
+		pattern string
+		want    []string
+	}{
+		"prefix": {
+			pattern: "tenant-.*",
+			want:    []string{"tenant-a", "tenant-a-dlq", "tenant-b"},
+		},
+		"full match": {
+			pattern: "tenant-[a-z]",
+			want:    []string{"tenant-a", "tenant-b"},
+		},
+		"internal topics are ignored": {
+			pattern: ".*offsets",
+			want:    []string{},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			pattern, err := sourcesv1beta1.CompileTopicPattern(tc.pattern)
+			require.Nil(t, err)
+
+			got, err := MatchTopics(kafkaClient, pattern)
+			require.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
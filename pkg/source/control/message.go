@@ -14,6 +14,13 @@ import (
 const (
 	NotifySetupClaimsOpCode   ctrl.OpCode = 1
 	NotifyCleanupClaimsOpCode ctrl.OpCode = 2
+
+	// The multi-tenant adapters notify the claims of each of their sources
+	NotifySetupSourceClaimsOpCode   ctrl.OpCode = 3
+	NotifyCleanupSourceClaimsOpCode ctrl.OpCode = 4
+
+	// The multi-tenant adapters notify the health of each of their sources
+	NotifySourceHealthOpCode ctrl.OpCode = 5
 )
 
 type Claims map[string][]int32
@@ -89,3 +96,58 @@ func (c Claims) copy() Claims {
 
 	return res
 }
+
+// SourceClaims are the claims of one of the sources of a multi-tenant adapter.
+type SourceClaims struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Claims    Claims `json:"claims"`
+}
+
+// SourceClaimsParser parses the SourceClaims payload, and returns its Claims.
+func SourceClaimsParser(payload []byte) (interface{}, error) {
+	var sourceClaims SourceClaims
+	err := (&sourceClaims).UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	if sourceClaims.Claims == nil {
+		sourceClaims.Claims = Claims{}
+	}
+	return sourceClaims.Claims, nil
+}
+
+func (sc SourceClaims) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(sc)
+}
+
+func (sc *SourceClaims) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, sc)
+}
+
+// SourceHealth is the health of one of the sources of a multi-tenant adapter.
+type SourceHealth struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Dispatching bool   `json:"dispatching"`
+	Restarts    int32  `json:"restarts,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// SourceHealthParser parses the SourceHealth payload.
+func SourceHealthParser(payload []byte) (interface{}, error) {
+	var sourceHealth SourceHealth
+	err := (&sourceHealth).UnmarshalBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	return sourceHealth, nil
+}
+
+func (sh SourceHealth) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(sh)
+}
+
+func (sh *SourceHealth) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, sh)
+}
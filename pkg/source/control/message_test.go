@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSourceClaimsParser(t *testing.T) {
+	payload, err := SourceClaims{
+		Namespace: "ns",
+		Name:      "name",
+		Claims:    Claims{"topic1": {0, 1}},
+	}.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SourceClaimsParser(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Claims{"topic1": {0, 1}}, got); diff != "" {
+		t.Error("unexpected claims (-want, +got):", diff)
+	}
+
+	if _, err := SourceClaimsParser([]byte("{")); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestSourceHealthParser(t *testing.T) {
+	want := SourceHealth{
+		Namespace:   "ns",
+		Name:        "name",
+		Dispatching: false,
+		Restarts:    2,
+		Message:     "panic while handling messages",
+	}
+	payload, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SourceHealthParser(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected health (-want, +got):", diff)
+	}
+
+	if _, err := SourceHealthParser([]byte("{")); err == nil {
+		t.Error("expected an error")
+	}
+}
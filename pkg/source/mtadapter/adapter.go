@@ -18,14 +18,18 @@ package mtadapter
 
 import (
 	"context"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"math"
-	"strconv"
+	"runtime/debug"
 	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/kubernetes"
 
@@ -35,18 +39,48 @@ import (
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/logging"
 
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlnetwork "knative.dev/control-protocol/pkg/network"
+
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 	stadapter "knative.dev/eventing-kafka/pkg/source/adapter"
 	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	"knative.dev/eventing/pkg/scheduler"
 )
 
+// restartPolicy configures the restarts of the failed source adapters.
+type restartPolicy struct {
+	// The delay before restarting a failed source adapter, doubled after
+	// each consecutive failure up to maxDelay.
+	initialDelay time.Duration
+	maxDelay     time.Duration
+
+	// The time a restarted source adapter must run for to be considered
+	// healthy again.
+	healthyPeriod time.Duration
+}
+
+var defaultRestartPolicy = restartPolicy{
+	initialDelay:  time.Second,
+	maxDelay:      5 * time.Minute,
+	healthyPeriod: time.Minute,
+}
+
 type AdapterConfig struct {
 	adapter.EnvConfig
 
 	PodName     string `envconfig:"POD_NAME" required:"true"`
 	MPSLimit    int    `envconfig:"VREPLICA_LIMITS_MPS" required:"true"`
 	MemoryLimit string `envconfig:"VREPLICA_LIMITS_MEMORY" required:"true"`
+
+	// The period at which the memory limits of the sources are re-evaluated
+	// as the number of partitions of their topics can change.
+	MemoryLimitCheckInterval time.Duration `envconfig:"VREPLICA_LIMITS_MEMORY_CHECK_INTERVAL" default:"1m"`
+
+	// Turn off the control server.
+	DisableControlServer bool
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -56,6 +90,21 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 type cancelContext struct {
 	fn      context.CancelFunc
 	stopped chan bool
+
+	// The source the adapter was last updated with
+	source *v1beta1.KafkaSource
+
+	// The rate limiter of the adapter, when it supports it
+	limiter rateLimiter
+
+	// The fetch sizes the consumer group was started with
+	fetch *v1beta1.FetchSpec
+}
+
+// rateLimiter is implemented by the adapters whose rate limits can be
+// changed while they are running.
+type rateLimiter interface {
+	SetRateLimits(r rate.Limit, b int)
 }
 
 type Adapter struct {
@@ -65,9 +114,16 @@ type Adapter struct {
 	adapterCtor adapter.MessageAdapterConstructor
 	kubeClient  kubernetes.Interface
 	memLimit    int32
+	reporter    StatsReporter
+	restarts    restartPolicy
 
 	sourcesMu sync.RWMutex
 	sources   map[string]cancelContext
+
+	// The control server is shared by the sources, whose claims are
+	// notified to the control plane along with their key.
+	controlServerMu sync.RWMutex
+	controlServer   *ctrlnetwork.ControlServer
 }
 
 var _ adapter.Adapter = (*Adapter)(nil)
@@ -88,12 +144,25 @@ func newAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 		adapterCtor: adapterCtor,
 		kubeClient:  kubeclient.Get(ctx),
 		memLimit:    int32(ml.Value()),
+		reporter:    NewStatsReporter(),
+		restarts:    defaultRestartPolicy,
 		sourcesMu:   sync.RWMutex{},
 		sources:     make(map[string]cancelContext),
 	}
 }
 
 func (a *Adapter) Start(ctx context.Context) error {
+	if !a.config.DisableControlServer {
+		controlServer, err := ctrlnetwork.StartInsecureControlServer(ctx)
+		if err != nil {
+			return err
+		}
+
+		a.controlServerMu.Lock()
+		a.controlServer = controlServer
+		a.controlServerMu.Unlock()
+	}
+
 	<-ctx.Done()
 	a.logger.Info("Shutting down...")
 	return nil
@@ -105,59 +174,108 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 	a.sourcesMu.Lock()
 	defer a.sourcesMu.Unlock()
 
-	key := obj.Namespace + "/" + obj.Name
+	a.logger.Infow("updating source", "key", obj.Namespace+"/"+obj.Name)
+	return a.update(ctx, obj)
+}
 
+// update starts, reconfigures or stops the adapter of the source.
+// Must be called with sourcesMu held.
+func (a *Adapter) update(ctx context.Context, obj *v1beta1.KafkaSource) error {
+	key := obj.Namespace + "/" + obj.Name
 	logger := a.logger.With("key", key)
-	logger.Info("updating source")
 
 	cancel, ok := a.sources[key]
 
-	if ok {
-		// TODO: do not stop if the only thing that changes is the number of vreplicas
-		logger.Info("stopping adapter")
-		cancel.fn()
-
-		// Wait for the adapter to stop
-		<-cancel.stopped
-
-		// Nothing to stop anymore
-		delete(a.sources, key)
-	}
-
 	placement := scheduler.GetPlacementForPod(obj.GetPlacements(), a.config.PodName)
 	if placement == nil || placement.VReplicas == 0 {
+		if ok {
+			a.stopSource(logger, key, cancel)
+		}
+
 		// this pod does not handle this source. Skipping
 		logger.Info("no replicas assigned to this source. skipping")
 		return nil
 	}
 
-	kafkaEnvConfig, err := client.NewEnvConfigFromSpec(ctx, a.kubeClient, obj)
+	kafkaEnvConfig, fetch, handledPartitions, err := a.limitedEnvConfig(ctx, logger, obj, placement.VReplicas)
 	if err != nil {
 		return err
 	}
 
+	return a.apply(ctx, logger, key, obj, placement.VReplicas, kafkaEnvConfig, fetch, handledPartitions)
+}
+
+// limitedEnvConfig returns the Kafka configuration of the source, with the
+// fetch sizes allowed by the memory limit of its vreplicas, along with the
+// number of partitions the pod might have to handle.
+func (a *Adapter) limitedEnvConfig(ctx context.Context,
+	logger *zap.SugaredLogger,
+	obj *v1beta1.KafkaSource,
+	vreplicas int32) (client.KafkaEnvConfig, *v1beta1.FetchSpec, int, error) {
+
+	kafkaEnvConfig, err := client.NewEnvConfigFromSpec(ctx, a.kubeClient, obj)
+	if err != nil {
+		return kafkaEnvConfig, nil, 0, err
+	}
+
 	// Enforce memory limits
+	var fetch *v1beta1.FetchSpec
+	handledPartitions := 0
 	if a.memLimit > 0 {
-		// TODO: periodically enforce limits as the number of partitions can dynamically change
-		fetchSizePerVReplica, err := a.partitionFetchSize(ctx, logger, &kafkaEnvConfig, obj.Spec.Topics, scheduler.GetPodCount(obj.Status.Placements))
+		fetch, handledPartitions, err = a.limitedFetchSizes(ctx, logger, obj, &kafkaEnvConfig, vreplicas)
 		if err != nil {
-			return err
+			return kafkaEnvConfig, nil, 0, err
 		}
-		fetchSize := fetchSizePerVReplica * int(placement.VReplicas)
 
-		// Must handle at least 64k messages to the compliant with the CloudEvent spec
-		maxFetchSize := fetchSize
-		if fetchSize < 64*1024 {
-			maxFetchSize = 64 * 1024
+		consumerConfig := kafkaEnvConfig.ConsumerConfig.ConsumerConfigSpec
+		if consumerConfig == nil {
+			consumerConfig = &v1beta1.ConsumerConfigSpec{}
 		}
-		a.logger.Infow("setting partition fetch sizes", zap.Int("min", fetchSize), zap.Int("default", fetchSize), zap.Int("max", maxFetchSize))
+		consumerConfig.Fetch = fetch
+		kafkaEnvConfig.ConsumerConfig.ConsumerConfigSpec = consumerConfig
+	}
+	return kafkaEnvConfig, fetch, handledPartitions, nil
+}
+
+// apply reconfigures the running adapter of the source, or (re)starts it
+// with the given Kafka configuration.
+// Must be called with sourcesMu held.
+func (a *Adapter) apply(ctx context.Context,
+	logger *zap.SugaredLogger,
+	key string,
+	obj *v1beta1.KafkaSource,
+	vreplicas int32,
+	kafkaEnvConfig client.KafkaEnvConfig,
+	fetch *v1beta1.FetchSpec,
+	handledPartitions int) error {
+
+	cancel, ok := a.sources[key]
+
+	rateLimit := rate.Limit(a.config.MPSLimit * int(vreplicas))
+	burst := 2 * a.config.MPSLimit * int(vreplicas)
+
+	if ok {
+		if cancel.limiter != nil && !requiresRestart(cancel.source, obj) && fitsFetchSizes(cancel.fetch, fetch) {
+			// Only the vreplicas of the source changed: adjust the limits of
+			// the running consumer group instead of restarting it, which
+			// would trigger a rebalance.
+			logger.Infow("updating the limits of the running adapter",
+				zap.Int32("vreplicas", vreplicas))
+			cancel.limiter.SetRateLimits(rateLimit, burst)
+			cancel.source = obj.DeepCopy()
+			a.sources[key] = cancel
+			a.reportBufferMemory(logger, key, cancel.fetch, handledPartitions, vreplicas)
+			return nil
+		}
+
+		a.stopSource(logger, key, cancel)
+	}
 
-		// TODO: find a better way to interact with the ST adapter.
-		bufferSizeStr := strconv.Itoa(fetchSize)
-		min := `\n    Min: ` + bufferSizeStr
-		def := `\n    Default: ` + bufferSizeStr
-		max := `\n    Max: ` + strconv.Itoa(maxFetchSize)
-		kafkaEnvConfig.KafkaConfigJson = `{"SaramaYamlString": "Consumer:\n  Fetch:` + min + def + max + `"}`
+	if fetch != nil {
+		logger.Infow("setting partition fetch sizes",
+			zap.Int32("min", fetch.Min),
+			zap.Int32("default", fetch.Default),
+			zap.Int32("max", fetch.Max))
 	}
 
 	config := stadapter.AdapterConfig{
@@ -167,9 +285,12 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		},
 		KafkaEnvConfig:       kafkaEnvConfig,
 		Topics:               obj.Spec.Topics,
+		TopicPattern:         obj.Spec.TopicPattern,
 		ConsumerGroup:        obj.Spec.ConsumerGroup,
 		Name:                 obj.Name,
 		DisableControlServer: true,
+
+		DetectStructuredCloudEvents: obj.Spec.DetectStructuredCloudEvents,
 	}
 
 	if val, ok := obj.GetLabels()[v1beta1.KafkaKeyTypeLabel]; ok {
@@ -182,6 +303,57 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		config.CEOverrides = string(ceJson)
 	}
 
+	if obj.Spec.Deserializer != nil {
+		// Cannot fail here.
+		deserializerJson, _ := json.Marshal(obj.Spec.Deserializer)
+		config.Deserializer = string(deserializerJson)
+	}
+
+	if obj.Spec.CloudEventMapping != nil {
+		// Cannot fail here.
+		mappingJson, _ := json.Marshal(obj.Spec.CloudEventMapping)
+		config.CloudEventMapping = string(mappingJson)
+	}
+
+	if obj.Spec.HeaderMapping != nil {
+		// Cannot fail here.
+		headerMappingJson, _ := json.Marshal(obj.Spec.HeaderMapping)
+		config.HeaderMapping = string(headerMappingJson)
+	}
+
+	if obj.Spec.Tombstones != nil {
+		// Cannot fail here.
+		tombstonesJson, _ := json.Marshal(obj.Spec.Tombstones)
+		config.Tombstones = string(tombstonesJson)
+	}
+
+	if obj.Spec.Batching != nil {
+		// Cannot fail here.
+		batchingJson, _ := json.Marshal(obj.Spec.Batching)
+		config.Batching = string(batchingJson)
+	}
+
+	if obj.Spec.Delivery != nil {
+		// The dead letter sink is resolved by the controller.
+		delivery := obj.Spec.Delivery.DeepCopy()
+		delivery.DeadLetterSink = nil
+		// Cannot fail here.
+		deliveryJson, _ := json.Marshal(delivery)
+		config.Delivery = string(deliveryJson)
+	}
+
+	if obj.Status.DeadLetterSinkURI != nil {
+		config.DeadLetterSink = obj.Status.DeadLetterSinkURI.String()
+	}
+
+	if obj.Status.ReplyURI != nil {
+		config.ReplySink = obj.Status.ReplyURI.String()
+	}
+
+	if obj.Spec.Reply != nil {
+		config.ReplyTopic = obj.Spec.Reply.Topic
+	}
+
 	reporter, err := source.NewStatsReporter()
 	if err != nil {
 		a.logger.Error("error building statsreporter", zap.Error(err))
@@ -194,29 +366,53 @@ func (a *Adapter) Update(ctx context.Context, obj *v1beta1.KafkaSource) error {
 		return err
 	}
 
+	if obj.Spec.SinkAuth != nil {
+		creds, err := sinkauth.FromSpec(ctx, a.kubeClient, obj.Namespace, obj.Spec.SinkAuth)
+		if err != nil {
+			a.logger.Errorw("error loading the sink credentials", zap.Error(err))
+			return err
+		}
+		if httpBindingsSender.Client, err = sinkauth.NewClient(httpBindingsSender.Client, httpBindingsSender.Target, creds); err != nil {
+			a.logger.Errorw("error building the sink client", zap.Error(err))
+			return err
+		}
+	}
+
 	adapter := a.adapterCtor(ctx, &config, httpBindingsSender, reporter)
 
-	// TODO: define Limit interface.
+	limiter, _ := adapter.(rateLimiter)
+	if limiter != nil {
+		limiter.SetRateLimits(rateLimit, burst)
+	}
+
 	if sta, ok := adapter.(*stadapter.Adapter); ok {
-		sta.SetRateLimits(rate.Limit(a.config.MPSLimit*int(placement.VReplicas)), 2*a.config.MPSLimit*int(placement.VReplicas))
+		sta.SetClaimsNotifier(a.sourceClaimsNotifier(obj.Namespace, obj.Name))
 	}
 
+	parentCtx := ctx
 	ctx, cancelFn := context.WithCancel(ctx)
 
 	cancel = cancelContext{
 		fn:      cancelFn,
 		stopped: make(chan bool),
+		source:  obj.DeepCopy(),
+		limiter: limiter,
+		fetch:   fetch,
 	}
 
 	a.sources[key] = cancel
 
-	go func(ctx context.Context) {
-		err := adapter.Start(ctx)
-		if err != nil {
-			a.logger.Errorw("adapter failed to start", zap.Error(err))
-		}
+	go func(ctx context.Context, namespace, name string) {
+		a.runSource(ctx, logger, namespace, name, adapter)
 		cancel.stopped <- true
-	}(ctx)
+	}(ctx, obj.Namespace, obj.Name)
+
+	if a.memLimit > 0 {
+		a.reportBufferMemory(logger, key, fetch, handledPartitions, vreplicas)
+		if a.config.MemoryLimitCheckInterval > 0 {
+			go a.watchMemoryLimit(parentCtx, ctx, key)
+		}
+	}
 
 	a.logger.Infow("source added", "name", obj.Name)
 	return nil
@@ -244,6 +440,186 @@ func (a *Adapter) Remove(name, namespace string) {
 	a.logger.Infow("source removed", "name", name, "remaining", len(a.sources))
 }
 
+// stopSource stops the adapter of the source and waits for it to be stopped.
+func (a *Adapter) stopSource(logger *zap.SugaredLogger, key string, cancel cancelContext) {
+	logger.Info("stopping adapter")
+	cancel.fn()
+
+	// Wait for the adapter to stop
+	<-cancel.stopped
+
+	// Nothing to stop anymore
+	delete(a.sources, key)
+}
+
+// requiresRestart tells whether the consumer group of the source must be
+// restarted to apply the changes between the two versions of the source.
+// Changing the number of consumers (vreplicas) only changes the limits of
+// the source, which are applied in place.
+func requiresRestart(old, new *v1beta1.KafkaSource) bool {
+	oldSpec, newSpec := old.Spec.DeepCopy(), new.Spec.DeepCopy()
+	oldSpec.Consumers, newSpec.Consumers = nil, nil
+
+	return !equality.Semantic.DeepEqual(oldSpec, newSpec) ||
+		old.GetLabels()[v1beta1.KafkaKeyTypeLabel] != new.GetLabels()[v1beta1.KafkaKeyTypeLabel] ||
+		!equality.Semantic.DeepEqual(old.Status.Topics, new.Status.Topics) ||
+		!equality.Semantic.DeepEqual(old.Status.SinkURI, new.Status.SinkURI) ||
+		!equality.Semantic.DeepEqual(old.Status.DeadLetterSinkURI, new.Status.DeadLetterSinkURI) ||
+		!equality.Semantic.DeepEqual(old.Status.ReplyURI, new.Status.ReplyURI)
+}
+
+// fitsFetchSizes tells whether the fetch sizes of a running consumer group
+// are within the new limited fetch sizes. Sarama does not support changing
+// the fetch sizes of a running consumer group, so larger limits are applied
+// the next time the consumer group is started whereas lower limits require a
+// restart to keep the memory of the adapter bounded.
+func fitsFetchSizes(running, limited *v1beta1.FetchSpec) bool {
+	if running == nil || limited == nil {
+		return running == limited
+	}
+	return running.Default <= limited.Default && running.Max <= limited.Max
+}
+
+// runSource runs the adapter of the source until the context is done. A
+// failing or panicking adapter is restarted after a delay, doubled after each
+// consecutive failure, without affecting the other sources of the pod.
+func (a *Adapter) runSource(ctx context.Context, logger *zap.SugaredLogger, namespace, name string, sourceAdapter adapter.MessageAdapter) {
+	key := namespace + "/" + name
+	health := kafkasourcecontrol.SourceHealth{Namespace: namespace, Name: name, Dispatching: true}
+	delay := a.restarts.initialDelay
+
+	// The health is notified asynchronously, only the latest one is kept
+	// while the previous one is being sent
+	notifications := make(chan kafkasourcecontrol.SourceHealth, 1)
+	go a.notifyHealth(ctx, logger, notifications)
+	notify := func(health kafkasourcecontrol.SourceHealth) {
+		select {
+		case <-notifications:
+		default:
+		}
+		notifications <- health
+	}
+
+	for {
+		done := make(chan error, 1)
+		go func() {
+			done <- startAdapter(ctx, sourceAdapter)
+		}()
+
+		if health.Restarts == 0 {
+			notify(health)
+		}
+
+		// The adapter is healthy again once it ran long enough
+		healthy := time.NewTimer(a.restarts.healthyPeriod)
+		var err error
+	running:
+		for {
+			select {
+			case err = <-done:
+				break running
+			case <-healthy.C:
+				delay = a.restarts.initialDelay
+				if !health.Dispatching {
+					health.Dispatching, health.Message = true, ""
+					notify(health)
+				}
+			}
+		}
+		healthy.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("the adapter stopped unexpectedly")
+		}
+
+		reason := "error"
+		var panicErr *stadapter.PanicError
+		if errors.As(err, &panicErr) {
+			reason = "panic"
+		}
+
+		health.Restarts++
+		health.Dispatching, health.Message = false, err.Error()
+		logger.Errorw("source adapter failed, restarting it",
+			zap.Error(err),
+			zap.Int32("restarts", health.Restarts),
+			zap.Duration("delay", delay))
+		if err := a.reporter.ReportRestart(key, reason); err != nil {
+			logger.Errorw("failed to report the restart", zap.Error(err))
+		}
+		notify(health)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > a.restarts.maxDelay {
+			delay = a.restarts.maxDelay
+		}
+	}
+}
+
+// startAdapter starts the adapter, recovering from its panics.
+func startAdapter(ctx context.Context, sourceAdapter adapter.MessageAdapter) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &stadapter.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return sourceAdapter.Start(ctx)
+}
+
+// notifyHealth sends the health notifications of the source through the
+// shared control server until the context is done.
+func (a *Adapter) notifyHealth(ctx context.Context, logger *zap.SugaredLogger, notifications <-chan kafkasourcecontrol.SourceHealth) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case health := <-notifications:
+			if err := a.sendToControlPlane(kafkasourcecontrol.NotifySourceHealthOpCode, health); err != nil {
+				logger.Warnw("failed to notify the health of the source", zap.Error(err))
+			}
+		}
+	}
+}
+
+// sourceClaimsNotifier returns the notifier sending the claims of the source
+// through the shared control server.
+func (a *Adapter) sourceClaimsNotifier(namespace, name string) stadapter.ClaimsNotifier {
+	return func(opCode ctrl.OpCode, claims kafkasourcecontrol.Claims) error {
+		sourceOpCode := kafkasourcecontrol.NotifySetupSourceClaimsOpCode
+		if opCode == kafkasourcecontrol.NotifyCleanupClaimsOpCode {
+			sourceOpCode = kafkasourcecontrol.NotifyCleanupSourceClaimsOpCode
+		}
+		return a.sendToControlPlane(sourceOpCode, kafkasourcecontrol.SourceClaims{
+			Namespace: namespace,
+			Name:      name,
+			Claims:    claims,
+		})
+	}
+}
+
+// sendToControlPlane sends the message through the shared control server,
+// once started.
+func (a *Adapter) sendToControlPlane(opCode ctrl.OpCode, payload encoding.BinaryMarshaler) error {
+	a.controlServerMu.RLock()
+	controlServer := a.controlServer
+	a.controlServerMu.RUnlock()
+
+	if controlServer == nil {
+		// Not started yet
+		return nil
+	}
+	return controlServer.SendAndWaitForAck(opCode, payload)
+}
+
 // partitionFetchSize determines what should be the default fetch size (in bytes)
 // so that the st adapter memory consumption does not exceed
 // the allocated memory per vreplica (see MemoryLimit).
@@ -253,20 +629,24 @@ func (a *Adapter) partitionFetchSize(ctx context.Context,
 	logger *zap.SugaredLogger,
 	kafkaEnvConfig *client.KafkaEnvConfig,
 	topics []string,
-	podCount int) (int, error) {
+	podCount int) (int, int, error) {
+
+	if len(topics) == 0 {
+		// e.g. a topic pattern which does not match any topic yet
+		return 0, 0, nil
+	}
 
 	// Compute the number of partitions handled by this source
-	// TODO: periodically check for # of resources. Need control-protocol.
 	adminClient, err := client.MakeAdminClient(ctx, kafkaEnvConfig)
 	if err != nil {
 		logger.Errorw("cannot create admin client", zap.Error(err))
-		return 0, err
+		return 0, 0, err
 	}
 
 	metas, err := adminClient.DescribeTopics(topics)
 	if err != nil {
 		logger.Errorw("cannot describe topics", zap.Error(err))
-		return 0, err
+		return 0, 0, err
 	}
 
 	totalPartitions := 0
@@ -275,6 +655,10 @@ func (a *Adapter) partitionFetchSize(ctx context.Context,
 	}
 	adminClient.Close()
 
+	if totalPartitions == 0 || podCount == 0 {
+		return 0, 0, nil
+	}
+
 	partitionsPerPod := int(math.Ceil(float64(totalPartitions) / float64(podCount)))
 
 	// Ideally, partitions are evenly spread across Kafka consumers.
@@ -294,5 +678,150 @@ func (a *Adapter) partitionFetchSize(ctx context.Context,
 	// A partition consumes about 2 * fetch partition size
 	// Once by FetchResponse blocks and a second time when those blocks are converted to messages
 	// see https://github.com/Shopify/sarama/blob/83d633e6e4f71b402df5e9c53ad5c1c334b7065d/consumer.go#L649
-	return int(math.Floor(float64(a.memLimit) / float64(handledPartitions) / 2.0)), nil
+	return int(math.Floor(float64(a.memLimit) / float64(handledPartitions) / 2.0)), handledPartitions, nil
+}
+
+// limitedFetchSizes returns the fetch sizes of the source allowed by the
+// memory limit of its vreplicas, along with the number of partitions the
+// pod might have to handle.
+func (a *Adapter) limitedFetchSizes(ctx context.Context,
+	logger *zap.SugaredLogger,
+	obj *v1beta1.KafkaSource,
+	kafkaEnvConfig *client.KafkaEnvConfig,
+	vreplicas int32) (*v1beta1.FetchSpec, int, error) {
+
+	// The topics matched by the topic pattern are resolved by the controller
+	topics := obj.Spec.Topics
+	if obj.Spec.TopicPattern != "" {
+		topics = obj.Status.Topics
+	}
+	fetchSizePerVReplica, handledPartitions, err := a.partitionFetchSize(ctx, logger, kafkaEnvConfig, topics, scheduler.GetPodCount(obj.Status.Placements))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The memory limit does not constrain a source without any partition
+	var fetch *v1beta1.FetchSpec
+	if consumerConfig := kafkaEnvConfig.ConsumerConfig.ConsumerConfigSpec; consumerConfig != nil {
+		fetch = consumerConfig.Fetch
+	}
+	if handledPartitions == 0 {
+		logger.Info("no partitions to consume, not limiting the fetch sizes")
+		return fetch, 0, nil
+	}
+
+	fetchSize := fetchSizePerVReplica * int(vreplicas)
+
+	// Must handle at least 64k messages to the compliant with the CloudEvent spec
+	maxFetchSize := fetchSize
+	if fetchSize < 64*1024 {
+		maxFetchSize = 64 * 1024
+	}
+
+	// The memory limit caps the fetch sizes of the source
+	return limitFetchSizes(fetch, int32(fetchSize), int32(maxFetchSize)), handledPartitions, nil
+}
+
+// watchMemoryLimit periodically re-evaluates the memory limit of the source
+// until its adapter is stopped, as partitions can be added to its topics.
+func (a *Adapter) watchMemoryLimit(ctx, sourceCtx context.Context, key string) {
+	ticker := time.NewTicker(a.config.MemoryLimitCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sourceCtx.Done():
+			return
+		case <-ticker.C:
+			a.enforceMemoryLimit(ctx, sourceCtx, key)
+		}
+	}
+}
+
+// enforceMemoryLimit recomputes the fetch sizes of the running source and
+// restarts its consumer group when they must be lowered. They are computed
+// without holding sourcesMu, as describing the topics of the source requires
+// round-trips to the brokers.
+func (a *Adapter) enforceMemoryLimit(ctx, sourceCtx context.Context, key string) {
+	a.sourcesMu.RLock()
+	cancel, ok := a.sources[key]
+	a.sourcesMu.RUnlock()
+
+	if !ok || sourceCtx.Err() != nil {
+		// The adapter was stopped in the meantime
+		return
+	}
+
+	obj := cancel.source
+	logger := a.logger.With("key", key)
+	placement := scheduler.GetPlacementForPod(obj.GetPlacements(), a.config.PodName)
+	if placement == nil || placement.VReplicas == 0 {
+		return
+	}
+
+	kafkaEnvConfig, fetch, handledPartitions, err := a.limitedEnvConfig(ctx, logger, obj, placement.VReplicas)
+	if err != nil {
+		logger.Errorw("failed to enforce the memory limit", zap.Error(err))
+		return
+	}
+	if handledPartitions == 0 || fitsFetchSizes(cancel.fetch, fetch) {
+		a.reportBufferMemory(logger, key, cancel.fetch, handledPartitions, placement.VReplicas)
+		return
+	}
+
+	a.sourcesMu.Lock()
+	defer a.sourcesMu.Unlock()
+
+	if current, ok := a.sources[key]; !ok || current.stopped != cancel.stopped || current.source != cancel.source {
+		// The adapter was stopped or updated in the meantime
+		return
+	}
+
+	if err := a.apply(ctx, logger, key, obj, placement.VReplicas, kafkaEnvConfig, fetch, handledPartitions); err != nil {
+		logger.Errorw("failed to enforce the memory limit", zap.Error(err))
+	}
+}
+
+// reportBufferMemory reports the estimated memory of the fetch buffers of
+// the source versus the memory limit of its vreplicas.
+func (a *Adapter) reportBufferMemory(logger *zap.SugaredLogger, key string, fetch *v1beta1.FetchSpec, handledPartitions int, vreplicas int32) {
+	if fetch == nil {
+		return
+	}
+
+	// A partition consumes about 2 * fetch partition size (see partitionFetchSize)
+	estimated := 2 * int64(handledPartitions) * int64(fetch.Default)
+	limit := int64(a.memLimit) * int64(vreplicas)
+	if estimated > limit {
+		logger.Warnw("the estimated buffer memory exceeds the memory limit",
+			zap.Int64("estimated", estimated),
+			zap.Int64("limit", limit))
+	}
+
+	if err := a.reporter.ReportBufferMemory(key, estimated, limit); err != nil {
+		logger.Errorw("failed to report the buffer memory", zap.Error(err))
+	}
+}
+
+// limitFetchSizes returns the fetch sizes of the source, lowered to the
+// ones allowed by the memory limit. The sizes which are not specified
+// are set to the limits.
+func limitFetchSizes(fetch *v1beta1.FetchSpec, fetchSize, maxFetchSize int32) *v1beta1.FetchSpec {
+	limited := &v1beta1.FetchSpec{Min: fetchSize, Default: fetchSize, Max: maxFetchSize}
+	if fetch == nil {
+		return limited
+	}
+	if fetch.Min > 0 && fetch.Min < limited.Min {
+		limited.Min = fetch.Min
+	}
+	if fetch.Default > 0 && fetch.Default < limited.Default {
+		limited.Default = fetch.Default
+	}
+	if fetch.Max > 0 && fetch.Max < limited.Max {
+		limited.Max = fetch.Max
+	}
+	if limited.Min > limited.Default {
+		limited.Min = limited.Default
+	}
+	return limited
 }
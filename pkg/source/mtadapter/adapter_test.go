@@ -18,9 +18,20 @@ package mtadapter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,9 +44,19 @@ import (
 	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
 	"knative.dev/eventing/pkg/kncloudevents"
 
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlnetwork "knative.dev/control-protocol/pkg/network"
+
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	stadapter "knative.dev/eventing-kafka/pkg/source/adapter"
+	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -51,7 +72,7 @@ func TestUpdateRemoveSources(t *testing.T) {
 	ctx, _ := pkgtesting.SetupFakeContext(t)
 	ctx, cancelAdapter := context.WithCancel(ctx)
 
-	env := &AdapterConfig{PodName: podName, MemoryLimit: "0"}
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0", DisableControlServer: true}
 	ceClient := adaptertest.NewTestClient()
 
 	mtadapter := newAdapter(ctx, env, ceClient, newSampleAdapter).(*Adapter)
@@ -338,7 +359,7 @@ func TestSourceMTAdapter(t *testing.T) {
 
 			ctx, cancelAdapter := context.WithCancel(ctx)
 
-			env := &AdapterConfig{PodName: podName, MemoryLimit: "0"}
+			env := &AdapterConfig{PodName: podName, MemoryLimit: "0", DisableControlServer: true}
 			ceClient := adaptertest.NewTestClient()
 
 			adapter := newAdapter(ctx, env, ceClient, newSampleAdapter).(*Adapter)
@@ -372,6 +393,768 @@ func TestSourceMTAdapter(t *testing.T) {
 	}
 }
 
+func TestUpdateDelivery(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0", DisableControlServer: true}
+	ceClient := adaptertest.NewTestClient()
+
+	configs := make(chan *stadapter.AdapterConfig, 1)
+	adapterCtor := func(ctx context.Context, env adapter.EnvConfigAccessor, sender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
+		configs <- env.(*stadapter.AdapterConfig)
+		return &idleAdapter{}
+	}
+	mtadapter := newAdapter(ctx, env, ceClient, adapterCtor).(*Adapter)
+
+	err := mtadapter.Update(ctx, &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			Delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dead-letter-sink")},
+				Retry:          ptr.Int32(3),
+			},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+			DeliveryStatus: eventingduckv1.DeliveryStatus{
+				DeadLetterSinkURI: apis.HTTP("dead-letter-sink"),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	select {
+	case config := <-configs:
+		if config.Delivery != `{"retry":3}` {
+			t.Errorf("Expected delivery %q, got %q", `{"retry":3}`, config.Delivery)
+		}
+		if config.DeadLetterSink != "http://dead-letter-sink" {
+			t.Errorf("Expected dead letter sink %q, got %q", "http://dead-letter-sink", config.DeadLetterSink)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("sub-adapter was not created after 100 ms")
+	}
+
+	mtadapter.Remove("test-name", "test-ns")
+}
+
+func TestUpdateSinkAuth(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	_, err := fakekubeclient.Get(ctx).CoreV1().Secrets("test-ns").Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink-credentials", Namespace: "test-ns"},
+		Data:       map[string][]byte{"token": []byte("sink-token")},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorization := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+	}))
+	defer sink.Close()
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0", DisableControlServer: true}
+	ceClient := adaptertest.NewTestClient()
+
+	senders := make(chan *kncloudevents.HTTPMessageSender, 1)
+	adapterCtor := func(ctx context.Context, env adapter.EnvConfigAccessor, sender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
+		senders <- sender
+		return &idleAdapter{}
+	}
+	mtadapter := newAdapter(ctx, env, ceClient, adapterCtor).(*Adapter)
+
+	sinkURI, _ := apis.ParseURL(sink.URL)
+	err = mtadapter.Update(ctx, &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			SinkAuth: &sourcesv1beta1.SinkAuthSpec{
+				BearerToken: &bindingsv1beta1.SecretValueFromSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "sink-credentials"},
+						Key:                  "token",
+					},
+				},
+			},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+			SourceStatus: duckv1.SourceStatus{SinkURI: sinkURI},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	select {
+	case sender := <-senders:
+		req, err := sender.NewCloudEventRequest(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := sender.Send(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if got := <-authorization; got != "Bearer sink-token" {
+			t.Errorf("Expected authorization %q, got %q", "Bearer sink-token", got)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("sub-adapter was not created after 100 ms")
+	}
+
+	mtadapter.Remove("test-name", "test-ns")
+}
+
+func TestUpdateSinkAuthServiceAccountNotAllowed(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	// The service account does not opt in to tokens for the audience
+	_, err := fakekubeclient.Get(ctx).CoreV1().ServiceAccounts("test-ns").Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "test-ns"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := &AdapterConfig{PodName: podName, MemoryLimit: "0", DisableControlServer: true}
+	adapterCtor := func(ctx context.Context, env adapter.EnvConfigAccessor, sender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
+		t.Error("unexpected sub-adapter presenting a service account token not allowed")
+		return &idleAdapter{}
+	}
+	mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), adapterCtor).(*Adapter)
+
+	err = mtadapter.Update(ctx, &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			SinkAuth: &sourcesv1beta1.SinkAuthSpec{
+				ServiceAccountToken: &sourcesv1beta1.ServiceAccountTokenSpec{Audience: "attacker.example.com"},
+			},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+			SourceStatus: duckv1.SourceStatus{SinkURI: apis.HTTP("attacker.example.com")},
+		},
+	})
+	if err == nil {
+		t.Error("Expected an error for a service account not allowing the audience")
+	}
+}
+
+func TestUpdateInPlace(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	env := &AdapterConfig{PodName: podName, MPSLimit: 10, MemoryLimit: "0", DisableControlServer: true}
+	ceClient := adaptertest.NewTestClient()
+
+	limiters := make(chan *limitedAdapter, 1)
+	adapterCtor := func(ctx context.Context, env adapter.EnvConfigAccessor, sender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
+		limiter := &limitedAdapter{limits: make(chan rate.Limit, 2)}
+		limiters <- limiter
+		return limiter
+	}
+	mtadapter := newAdapter(ctx, env, ceClient, adapterCtor).(*Adapter)
+
+	src := &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			Topics:    []string{"topic1"},
+			Consumers: ptr.Int32(1),
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+		},
+	}
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	limiter := <-limiters
+	if limit := <-limiter.limits; limit != 10 {
+		t.Errorf("Expected rate limit 10, got %v", limit)
+	}
+
+	// Changing the vreplicas adjusts the limits of the running adapter
+	src = src.DeepCopy()
+	src.Spec.Consumers = ptr.Int32(3)
+	src.Status.Placements[0].VReplicas = 3
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if limit := <-limiter.limits; limit != 30 {
+		t.Errorf("Expected rate limit 30, got %v", limit)
+	}
+	select {
+	case <-limiters:
+		t.Error("Expected the adapter not to be restarted")
+	default:
+	}
+
+	// Changing the topics restarts the adapter
+	src = src.DeepCopy()
+	src.Spec.Topics = []string{"topic1", "topic2"}
+	if err := mtadapter.Update(ctx, src); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	select {
+	case <-limiters:
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Expected the adapter to be restarted")
+	}
+
+	mtadapter.Remove("test-name", "test-ns")
+}
+
+func TestEnforceMemoryLimit(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancelAdapter := context.WithCancel(ctx)
+	defer cancelAdapter()
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	setPartitions := func(count int32) {
+		metadataResponse := sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID())
+		for partition := int32(0); partition < count; partition++ {
+			metadataResponse.SetLeader("topic1", partition, broker.BrokerID())
+		}
+		broker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": metadataResponse,
+		})
+	}
+	setPartitions(2)
+
+	env := &AdapterConfig{
+		PodName:                  podName,
+		MPSLimit:                 10,
+		MemoryLimit:              "1Mi",
+		MemoryLimitCheckInterval: 10 * time.Millisecond,
+		DisableControlServer:     true,
+	}
+	ceClient := adaptertest.NewTestClient()
+
+	configs := make(chan *stadapter.AdapterConfig, 1)
+	adapterCtor := func(ctx context.Context, env adapter.EnvConfigAccessor, sender *kncloudevents.HTTPMessageSender, reporter source.StatsReporter) adapter.MessageAdapter {
+		configs <- env.(*stadapter.AdapterConfig)
+		return &limitedAdapter{limits: make(chan rate.Limit, 1000)}
+	}
+	mtadapter := newAdapter(ctx, env, ceClient, adapterCtor).(*Adapter)
+	reporter := &fakeStatsReporter{}
+	mtadapter.reporter = reporter
+
+	err := mtadapter.Update(ctx, &sourcesv1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{broker.Addr()},
+			},
+			Topics: []string{"topic1"},
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			Placeable: duckv1alpha1.Placeable{
+				Placements: []duckv1alpha1.Placement{
+					{PodName: podName, VReplicas: int32(1)},
+				}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// 1Mi shared by 2 partitions, each buffering twice the fetch size
+	config := <-configs
+	if fetch := config.ConsumerConfig.Fetch; fetch.Default != 262144 {
+		t.Errorf("Expected default fetch size 262144, got %d", fetch.Default)
+	}
+	if estimated, limit := reporter.bufferMemory(); estimated != 1048576 || limit != 1048576 {
+		t.Errorf("Expected estimated buffer memory 1048576 and limit 1048576, got %d and %d", estimated, limit)
+	}
+
+	// The fetch sizes still fit
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-configs:
+		t.Fatal("Expected the adapter not to be restarted")
+	default:
+	}
+
+	// The fetch sizes are lowered when partitions are added
+	setPartitions(4)
+	select {
+	case config := <-configs:
+		if fetch := config.ConsumerConfig.Fetch; fetch.Default != 131072 {
+			t.Errorf("Expected default fetch size 131072, got %d", fetch.Default)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the adapter to be restarted")
+	}
+
+	mtadapter.Remove("test-name", "test-ns")
+}
+
+func TestRequiresRestart(t *testing.T) {
+	src := &sourcesv1beta1.KafkaSource{
+		Spec: sourcesv1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"kafka:9092"},
+			},
+			Topics:        []string{"topic1"},
+			ConsumerGroup: "group",
+			Consumers:     ptr.Int32(1),
+		},
+		Status: sourcesv1beta1.KafkaSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: apis.HTTP("sink"),
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		update func(src *sourcesv1beta1.KafkaSource)
+		want   bool
+	}{
+		"unchanged": {
+			update: func(src *sourcesv1beta1.KafkaSource) {},
+		},
+		"consumers": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Spec.Consumers = ptr.Int32(5)
+			},
+		},
+		"placements": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Status.Placements = []duckv1alpha1.Placement{{PodName: podName, VReplicas: 2}}
+			},
+		},
+		"topics": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Spec.Topics = []string{"topic2"}
+			},
+			want: true,
+		},
+		"resolved topics": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Status.Topics = []string{"topic2"}
+			},
+			want: true,
+		},
+		"bootstrap servers": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Spec.BootstrapServers = []string{"other-kafka:9092"}
+			},
+			want: true,
+		},
+		"auth": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Spec.Net.TLS.Enable = true
+			},
+			want: true,
+		},
+		"consumer group": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Spec.ConsumerGroup = "other-group"
+			},
+			want: true,
+		},
+		"sink": {
+			update: func(src *sourcesv1beta1.KafkaSource) {
+				src.Status.SinkURI = apis.HTTP("other-sink")
+			},
+			want: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			updated := src.DeepCopy()
+			tc.update(updated)
+			if got := requiresRestart(src, updated); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFitsFetchSizes(t *testing.T) {
+	testCases := map[string]struct {
+		running *sourcesv1beta1.FetchSpec
+		limited *sourcesv1beta1.FetchSpec
+		want    bool
+	}{
+		"no memory limit": {
+			want: true,
+		},
+		"same fetch sizes": {
+			running: &sourcesv1beta1.FetchSpec{Min: 1, Default: 32768, Max: 65536},
+			limited: &sourcesv1beta1.FetchSpec{Min: 1, Default: 32768, Max: 65536},
+			want:    true,
+		},
+		"higher limits": {
+			running: &sourcesv1beta1.FetchSpec{Min: 1, Default: 32768, Max: 65536},
+			limited: &sourcesv1beta1.FetchSpec{Min: 1, Default: 65536, Max: 131072},
+			want:    true,
+		},
+		"lower limits": {
+			running: &sourcesv1beta1.FetchSpec{Min: 1, Default: 65536, Max: 131072},
+			limited: &sourcesv1beta1.FetchSpec{Min: 1, Default: 32768, Max: 65536},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if got := fitsFetchSizes(tc.running, tc.limited); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRunSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controlServer, err := ctrlnetwork.StartInsecureControlServer(ctx, ctrlnetwork.WithPort(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifications := make(chan kafkasourcecontrol.SourceHealth, 10)
+	controlClient, err := ctrlnetwork.StartControlClient(ctx, &net.Dialer{}, fmt.Sprintf("127.0.0.1:%d", controlServer.ListeningPort()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	controlClient.MessageHandler(ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		var health kafkasourcecontrol.SourceHealth
+		if err := health.UnmarshalBinary(message.Payload()); err != nil {
+			t.Error("unexpected payload: ", err)
+		}
+		if ctrl.OpCode(message.Headers().OpCode()) != kafkasourcecontrol.NotifySourceHealthOpCode {
+			t.Error("unexpected opcode: ", message.Headers().OpCode())
+		}
+		notifications <- health
+		message.Ack()
+	}))
+
+	reporter := &fakeStatsReporter{}
+	a := &Adapter{
+		reporter:      reporter,
+		controlServer: controlServer,
+		restarts: restartPolicy{
+			initialDelay:  time.Millisecond,
+			maxDelay:      time.Second,
+			healthyPeriod: 50 * time.Millisecond,
+		},
+	}
+
+	sourceAdapter := &failingAdapter{failures: []func() error{
+		func() error { panic("boom") },
+		func() error { return errors.New("failed") },
+	}}
+	stopped := make(chan struct{})
+	go func() {
+		a.runSource(ctx, zap.NewNop().Sugar(), "test-ns", "test-name", sourceAdapter)
+		close(stopped)
+	}()
+
+	// The adapter is dispatching again once it ran long enough
+	want := kafkasourcecontrol.SourceHealth{Namespace: "test-ns", Name: "test-name", Dispatching: true, Restarts: 2}
+	timeout := time.After(5 * time.Second)
+	for got := (kafkasourcecontrol.SourceHealth{}); got != want; {
+		select {
+		case got = <-notifications:
+		case <-timeout:
+			t.Fatalf("Expected the health %+v, last got %+v", want, got)
+		}
+	}
+
+	wantRestarts := map[string]int{"test-ns/test-name panic": 1, "test-ns/test-name error": 1}
+	if got := reporter.restartCounts(); !reflect.DeepEqual(got, wantRestarts) {
+		t.Errorf("Expected the restarts %v, got %v", wantRestarts, got)
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Expected the source to be stopped")
+	}
+}
+
+func TestLimitFetchSizes(t *testing.T) {
+	testCases := map[string]struct {
+		fetch *sourcesv1beta1.FetchSpec
+		want  *sourcesv1beta1.FetchSpec
+	}{
+		"no fetch sizes": {
+			want: &sourcesv1beta1.FetchSpec{Min: 32768, Default: 32768, Max: 65536},
+		},
+		"lower fetch sizes": {
+			fetch: &sourcesv1beta1.FetchSpec{Min: 1, Default: 16384},
+			want:  &sourcesv1beta1.FetchSpec{Min: 1, Default: 16384, Max: 65536},
+		},
+		"higher fetch sizes": {
+			fetch: &sourcesv1beta1.FetchSpec{Default: 1048576, Max: 10485760},
+			want:  &sourcesv1beta1.FetchSpec{Min: 32768, Default: 32768, Max: 65536},
+		},
+		"default lower than the limited min": {
+			fetch: &sourcesv1beta1.FetchSpec{Default: 1024},
+			want:  &sourcesv1beta1.FetchSpec{Min: 1024, Default: 1024, Max: 65536},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := limitFetchSizes(tc.fetch, 32768, 65536)
+			if *got != *tc.want {
+				t.Errorf("unexpected fetch sizes, want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLimitedFetchSizesWithoutPartitions(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	// The topic exists but does not have any partition
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	fetch := &sourcesv1beta1.FetchSpec{Default: 1048576}
+	testCases := map[string]struct {
+		topics       []string
+		topicPattern string
+		wantRequests bool
+	}{
+		"topic pattern not matching any topic": {
+			topicPattern: "topic.*",
+		},
+		"topic without partitions": {
+			topics:       []string{"topic1"},
+			wantRequests: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			env := &AdapterConfig{PodName: podName, MemoryLimit: "1Mi", DisableControlServer: true}
+			mtadapter := newAdapter(ctx, env, adaptertest.NewTestClient(), stadapter.NewAdapter).(*Adapter)
+
+			obj := &sourcesv1beta1.KafkaSource{
+				Spec: sourcesv1beta1.KafkaSourceSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{broker.Addr()},
+					},
+					Topics:       tc.topics,
+					TopicPattern: tc.topicPattern,
+				},
+				Status: sourcesv1beta1.KafkaSourceStatus{
+					Placeable: duckv1alpha1.Placeable{
+						Placements: []duckv1alpha1.Placement{
+							{PodName: podName, VReplicas: int32(1)},
+						}},
+				},
+			}
+			kafkaEnvConfig := &client.KafkaEnvConfig{
+				BootstrapServers: obj.Spec.BootstrapServers,
+				ConsumerConfig:   client.ConsumerConfig{ConsumerConfigSpec: &sourcesv1beta1.ConsumerConfigSpec{Fetch: fetch}},
+			}
+
+			requests := len(broker.History())
+			got, handledPartitions, err := mtadapter.limitedFetchSizes(ctx, mtadapter.logger, obj, kafkaEnvConfig, 1)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if handledPartitions != 0 {
+				t.Errorf("Expected no handled partitions, got %d", handledPartitions)
+			}
+			if got != fetch {
+				t.Errorf("Expected the fetch sizes not to be limited, got %+v", got)
+			}
+			if sent := len(broker.History()) > requests; sent != tc.wantRequests {
+				t.Errorf("Expected requests to the broker %v, got %v", tc.wantRequests, sent)
+			}
+		})
+	}
+}
+
+func TestSourceClaimsNotifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := &Adapter{}
+
+	// Nothing is sent until the control server is started
+	notifier := a.sourceClaimsNotifier("test-ns", "test-name")
+	if err := notifier(kafkasourcecontrol.NotifySetupClaimsOpCode, kafkasourcecontrol.Claims{"topic1": {0}}); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	controlServer, err := ctrlnetwork.StartInsecureControlServer(ctx, ctrlnetwork.WithPort(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.controlServer = controlServer
+
+	type notification struct {
+		opCode       ctrl.OpCode
+		sourceClaims kafkasourcecontrol.SourceClaims
+	}
+	notifications := make(chan notification, 2)
+
+	controlClient, err := ctrlnetwork.StartControlClient(ctx, &net.Dialer{}, fmt.Sprintf("127.0.0.1:%d", controlServer.ListeningPort()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	controlClient.MessageHandler(ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		var sourceClaims kafkasourcecontrol.SourceClaims
+		if err := sourceClaims.UnmarshalBinary(message.Payload()); err != nil {
+			t.Error("unexpected payload: ", err)
+		}
+		notifications <- notification{opCode: ctrl.OpCode(message.Headers().OpCode()), sourceClaims: sourceClaims}
+		message.Ack()
+	}))
+
+	for opCode, want := range map[ctrl.OpCode]ctrl.OpCode{
+		kafkasourcecontrol.NotifySetupClaimsOpCode:   kafkasourcecontrol.NotifySetupSourceClaimsOpCode,
+		kafkasourcecontrol.NotifyCleanupClaimsOpCode: kafkasourcecontrol.NotifyCleanupSourceClaimsOpCode,
+	} {
+		if err := notifier(opCode, kafkasourcecontrol.Claims{"topic1": {0, 1}}); err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		got := <-notifications
+		wantSourceClaims := kafkasourcecontrol.SourceClaims{
+			Namespace: "test-ns",
+			Name:      "test-name",
+			Claims:    kafkasourcecontrol.Claims{"topic1": {0, 1}},
+		}
+		if got.opCode != want || !reflect.DeepEqual(got.sourceClaims, wantSourceClaims) {
+			t.Errorf("unexpected notification, want %d %+v, got %d %+v", want, wantSourceClaims, got.opCode, got.sourceClaims)
+		}
+	}
+}
+
+// failingAdapter fails each time it is started with the next failure,
+// until there is none left.
+type failingAdapter struct {
+	failures []func() error
+	starts   int32
+}
+
+func (d *failingAdapter) Start(ctx context.Context) error {
+	starts := atomic.AddInt32(&d.starts, 1)
+	if int(starts) <= len(d.failures) {
+		return d.failures[starts-1]()
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// idleAdapter does nothing until stopped.
+type idleAdapter struct{}
+
+func (d *idleAdapter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// limitedAdapter records its rate limits until stopped.
+type limitedAdapter struct {
+	limits chan rate.Limit
+}
+
+func (d *limitedAdapter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (d *limitedAdapter) SetRateLimits(r rate.Limit, b int) {
+	d.limits <- r
+}
+
+// fakeStatsReporter records the last reported buffer memory and the
+// restarts.
+type fakeStatsReporter struct {
+	mu        sync.Mutex
+	estimated int64
+	limit     int64
+	restarts  map[string]int
+}
+
+func (r *fakeStatsReporter) ReportBufferMemory(key string, estimated, limit int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.estimated, r.limit = estimated, limit
+	return nil
+}
+
+func (r *fakeStatsReporter) ReportRestart(key, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.restarts == nil {
+		r.restarts = make(map[string]int)
+	}
+	r.restarts[key+" "+reason]++
+	return nil
+}
+
+func (r *fakeStatsReporter) restartCounts() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]int, len(r.restarts))
+	for k, v := range r.restarts {
+		counts[k] = v
+	}
+	return counts
+}
+
+func (r *fakeStatsReporter) bufferMemory() (int64, int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.estimated, r.limit
+}
+
 type sampleAdapter struct {
 	running bool
 }
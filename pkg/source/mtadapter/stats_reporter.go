@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// BufferMemoryEstimatedN is the estimated memory of the fetch buffers of a source.
+	BufferMemoryEstimatedN = "kafkasource_buffer_memory_estimated_bytes"
+	// BufferMemoryLimitN is the memory limit of the vreplicas of a source.
+	BufferMemoryLimitN = "kafkasource_buffer_memory_limit_bytes"
+	// AdapterRestartCountN is the number of times the adapter of a source was restarted.
+	AdapterRestartCountN = "kafkasource_adapter_restart_count"
+)
+
+var (
+	bufferMemoryEstimatedStat = stats.Int64(
+		BufferMemoryEstimatedN,
+		"Estimated memory of the fetch buffers of a source",
+		stats.UnitBytes)
+
+	bufferMemoryLimitStat = stats.Int64(
+		BufferMemoryLimitN,
+		"Memory limit of the vreplicas of a source",
+		stats.UnitBytes)
+
+	adapterRestartCountStat = stats.Int64(
+		AdapterRestartCountN,
+		"Number of times the adapter of a source was restarted",
+		stats.UnitDimensionless)
+
+	keyTagKey    = mustNewTagKey("key")
+	reasonTagKey = mustNewTagKey("reason")
+)
+
+func init() {
+	// Create views to see our measurements. This can return an error if
+	// a previously-registered view has the same name with a different value.
+	// View name defaults to the measure name if unspecified.
+	err := view.Register(
+		&view.View{
+			Description: bufferMemoryEstimatedStat.Description(),
+			Measure:     bufferMemoryEstimatedStat,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyTagKey},
+		},
+		&view.View{
+			Description: bufferMemoryLimitStat.Description(),
+			Measure:     bufferMemoryLimitStat,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyTagKey},
+		},
+		&view.View{
+			Description: adapterRestartCountStat.Description(),
+			Measure:     adapterRestartCountStat,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{keyTagKey, reasonTagKey},
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// StatsReporter reports the metrics of the multi-tenant adapter.
+type StatsReporter interface {
+	// ReportBufferMemory reports the estimated buffer memory of a source
+	// versus its memory limit.
+	ReportBufferMemory(key string, estimated, limit int64) error
+
+	// ReportRestart reports that the adapter of a source was restarted
+	// because of the given reason.
+	ReportRestart(key, reason string) error
+}
+
+type reporter struct{}
+
+// NewStatsReporter creates a reporter for the metrics of the multi-tenant adapter.
+func NewStatsReporter() StatsReporter {
+	return &reporter{}
+}
+
+// ReportBufferMemory reports the estimated buffer memory of a source
+// versus its memory limit.
+func (r *reporter) ReportBufferMemory(key string, estimated, limit int64) error {
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(keyTagKey, key))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, bufferMemoryEstimatedStat.M(estimated))
+	metrics.Record(ctx, bufferMemoryLimitStat.M(limit))
+	return nil
+}
+
+// ReportRestart reports that the adapter of a source was restarted because
+// of the given reason.
+func (r *reporter) ReportRestart(key, reason string) error {
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(keyTagKey, key),
+		tag.Insert(reasonTagKey, reason))
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, adapterRestartCountStat.M(1))
+	return nil
+}
+
+func mustNewTagKey(s string) tag.Key {
+	tagKey, err := tag.NewKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return tagKey
+}
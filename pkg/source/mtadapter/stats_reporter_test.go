@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mtadapter
+
+import (
+	"testing"
+
+	"go.opencensus.io/stats/view"
+
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+func TestReportBufferMemory(t *testing.T) {
+	r := NewStatsReporter()
+	if err := r.ReportBufferMemory("test-ns/test-name", 1024, 2048); err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	for name, want := range map[string]float64{
+		BufferMemoryEstimatedN: 1024,
+		BufferMemoryLimitN:     2048,
+	} {
+		rows, err := view.RetrieveData(name)
+		if err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+		if len(rows) != 1 {
+			t.Fatalf("expected 1 row for %s, got %d", name, len(rows))
+		}
+		if got := rows[0].Data.(*view.LastValueData).Value; got != want {
+			t.Errorf("unexpected %s, want %v, got %v", name, want, got)
+		}
+		if tag := rows[0].Tags[0]; tag.Key != keyTagKey || tag.Value != "test-ns/test-name" {
+			t.Errorf("unexpected tag %v", tag)
+		}
+	}
+}
+
+func TestReportRestart(t *testing.T) {
+	r := NewStatsReporter()
+	for _, reason := range []string{"panic", "error", "panic"} {
+		if err := r.ReportRestart("test-ns/test-restarts", reason); err != nil {
+			t.Fatal("unexpected error: ", err)
+		}
+	}
+
+	rows, err := view.RetrieveData(AdapterRestartCountN)
+	if err != nil {
+		t.Fatal("unexpected error: ", err)
+	}
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		tags := make(map[string]string)
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		if tags["key"] == "test-ns/test-restarts" {
+			counts[tags["reason"]] = row.Data.(*view.CountData).Value
+		}
+	}
+	if counts["panic"] != 2 || counts["error"] != 1 {
+		t.Errorf("unexpected restart counts %v", counts)
+	}
+}
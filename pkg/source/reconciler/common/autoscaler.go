@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+// Autoscaler computes the number of replicas of the autoscaled sources from the
+// lag of their consumer group and, when the throughput of a replica is known,
+// from the throughput of their topics.
+type Autoscaler struct {
+	// replicaMPS is the number of messages per second a replica can process,
+	// or -1 when unknown.
+	replicaMPS int32
+	now        func() time.Time
+
+	mu sync.Mutex
+	// samples are the newest offsets of the topics of each source, as last
+	// measured, to compute their throughput.
+	samples map[types.NamespacedName]offsetsSample
+}
+
+type offsetsSample struct {
+	time          time.Time
+	newestOffsets int64
+}
+
+// NewAutoscaler returns an autoscaler whose replicas can process replicaMPS
+// messages per second, or an unknown number of messages per second when -1.
+func NewAutoscaler(replicaMPS int32) *Autoscaler {
+	return &Autoscaler{
+		replicaMPS: replicaMPS,
+		now:        time.Now,
+		samples:    make(map[types.NamespacedName]offsetsSample),
+	}
+}
+
+// Reconcile sets the autoscaling status of the source from the lag of its
+// consumer group when the source is autoscaled, and returns the delay after which
+// the lag must be measured again, or 0 when the source is not autoscaled.
+func (a *Autoscaler) Reconcile(ctx context.Context, c sarama.Client, kafkaAdminClient sarama.ClusterAdmin, topics []string, src *v1beta1.KafkaSource) time.Duration {
+	settings, err := src.GetAutoscalingSettings()
+	if err != nil || settings == nil {
+		if err != nil {
+			logging.FromContext(ctx).Errorw("invalid autoscaling settings", zap.Error(err))
+		}
+		src.Status.Autoscaling = nil
+		a.Forget(src.GetKey())
+		return 0
+	}
+
+	lag, err := offset.ConsumerGroupLag(c, kafkaAdminClient, topics, src.Spec.ConsumerGroup)
+	if err != nil {
+		// Keep the current replicas until the lag can be measured again
+		logging.FromContext(ctx).Errorw("unable to measure the consumer group lag", zap.Error(err))
+		return settings.PollingInterval
+	}
+
+	before := int32(-1)
+	if src.Status.Autoscaling != nil {
+		before = src.Status.Autoscaling.Replicas
+	}
+	requeueAfter := a.Autoscale(src, settings, lag)
+	if after := src.Status.Autoscaling.Replicas; after != before {
+		logging.FromContext(ctx).Infow("autoscaling the source",
+			zap.Int64("lag", lag.Total), zap.Int32("replicas", before), zap.Int32("new replicas", after))
+	}
+	return requeueAfter
+}
+
+// Autoscale updates the autoscaling status of the source with the number of
+// replicas required by its lag, and returns the delay after which the lag must
+// be measured again. The source scales up immediately, but only scales down
+// once the cooldown period following the last scale change has elapsed.
+func (a *Autoscaler) Autoscale(src *v1beta1.KafkaSource, settings *v1beta1.AutoscalingSettings, lag *offset.GroupLag) time.Duration {
+	now := a.now()
+
+	desired := divideRoundUp(lag.Total, settings.LagThreshold)
+	if mps := a.throughput(src.GetKey(), lag.NewestOffsets, now); a.replicaMPS > 0 && mps > 0 {
+		if replicas := divideRoundUp(mps, int64(a.replicaMPS)); replicas > desired {
+			desired = replicas
+		}
+	}
+	if desired < int64(settings.MinScale) {
+		desired = int64(settings.MinScale)
+	}
+	if desired > int64(settings.MaxScale) {
+		desired = int64(settings.MaxScale)
+	}
+
+	status := src.Status.Autoscaling
+	if status == nil {
+		status = &v1beta1.AutoscalingStatus{Replicas: -1}
+	}
+	cooledDown := status.LastScaleTime == nil || now.Sub(status.LastScaleTime.Time) >= settings.CooldownPeriod
+	if int32(desired) > status.Replicas || status.Replicas > settings.MaxScale || (int32(desired) < status.Replicas && cooledDown) {
+		status.Replicas = int32(desired)
+		status.LastScaleTime = &metav1.Time{Time: now}
+	}
+	status.Lag = lag.Total
+	src.Status.Autoscaling = status
+
+	return settings.PollingInterval
+}
+
+// Forget drops the measures of the source.
+func (a *Autoscaler) Forget(key types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.samples, key)
+}
+
+// throughput returns the number of messages per second produced to the topics
+// of the source since the last measure, or -1 when unknown.
+func (a *Autoscaler) throughput(key types.NamespacedName, newestOffsets int64, now time.Time) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	last, ok := a.samples[key]
+	a.samples[key] = offsetsSample{time: now, newestOffsets: newestOffsets}
+
+	elapsed := now.Sub(last.time).Seconds()
+	// The newest offsets decrease when the topics change
+	if !ok || elapsed <= 0 || newestOffsets < last.newestOffsets {
+		return -1
+	}
+	return int64(float64(newestOffsets-last.newestOffsets) / elapsed)
+}
+
+func divideRoundUp(a, b int64) int64 {
+	return (a + b - 1) / b
+}
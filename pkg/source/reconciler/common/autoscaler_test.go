@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
+)
+
+func TestAutoscale(t *testing.T) {
+	settings := &v1beta1.AutoscalingSettings{
+		MinScale:        1,
+		MaxScale:        10,
+		PollingInterval: 30 * time.Second,
+		CooldownPeriod:  5 * time.Minute,
+		LagThreshold:    100,
+	}
+	start := time.Now()
+	lastScale := start.Add(-time.Minute)
+
+	testCases := map[string]struct {
+		replicaMPS int32
+		status     *v1beta1.AutoscalingStatus
+		lags       []offset.GroupLag
+		now        time.Time
+		want       *v1beta1.AutoscalingStatus
+	}{
+		"first measure": {
+			replicaMPS: -1,
+			lags:       []offset.GroupLag{{Total: 250}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 3, Lag: 250, LastScaleTime: &metav1.Time{Time: start}},
+		},
+		"no lag": {
+			replicaMPS: -1,
+			lags:       []offset.GroupLag{{Total: 0}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 1, Lag: 0, LastScaleTime: &metav1.Time{Time: start}},
+		},
+		"max scale": {
+			replicaMPS: -1,
+			lags:       []offset.GroupLag{{Total: 5000}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 10, Lag: 5000, LastScaleTime: &metav1.Time{Time: start}},
+		},
+		"scale up during cooldown": {
+			replicaMPS: -1,
+			status:     &v1beta1.AutoscalingStatus{Replicas: 2, LastScaleTime: &metav1.Time{Time: lastScale}},
+			lags:       []offset.GroupLag{{Total: 401}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 5, Lag: 401, LastScaleTime: &metav1.Time{Time: start}},
+		},
+		"no scale down during cooldown": {
+			replicaMPS: -1,
+			status:     &v1beta1.AutoscalingStatus{Replicas: 4, LastScaleTime: &metav1.Time{Time: lastScale}},
+			lags:       []offset.GroupLag{{Total: 10}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 4, Lag: 10, LastScaleTime: &metav1.Time{Time: lastScale}},
+		},
+		"scale down after cooldown": {
+			replicaMPS: -1,
+			status:     &v1beta1.AutoscalingStatus{Replicas: 4, LastScaleTime: &metav1.Time{Time: lastScale}},
+			lags:       []offset.GroupLag{{Total: 10}},
+			now:        lastScale.Add(5 * time.Minute),
+			want:       &v1beta1.AutoscalingStatus{Replicas: 1, Lag: 10, LastScaleTime: &metav1.Time{Time: lastScale.Add(5 * time.Minute)}},
+		},
+		"max scale lowered during cooldown": {
+			replicaMPS: -1,
+			status:     &v1beta1.AutoscalingStatus{Replicas: 12, LastScaleTime: &metav1.Time{Time: lastScale}},
+			lags:       []offset.GroupLag{{Total: 5000}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 10, Lag: 5000, LastScaleTime: &metav1.Time{Time: start}},
+		},
+		"throughput": {
+			// 3000 messages in 10s at 100 messages per second per replica
+			replicaMPS: 100,
+			lags:       []offset.GroupLag{{Total: 0, NewestOffsets: 1000}, {Total: 150, NewestOffsets: 4000}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 3, Lag: 150, LastScaleTime: &metav1.Time{Time: start.Add(10 * time.Second)}},
+		},
+		"throughput after topics change": {
+			replicaMPS: 100,
+			lags:       []offset.GroupLag{{Total: 0, NewestOffsets: 4000}, {Total: 150, NewestOffsets: 1000}},
+			now:        start,
+			want:       &v1beta1.AutoscalingStatus{Replicas: 2, Lag: 150, LastScaleTime: &metav1.Time{Time: start.Add(10 * time.Second)}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{}
+			src.Status.Autoscaling = tc.status
+
+			now := tc.now
+			a := NewAutoscaler(tc.replicaMPS)
+			a.now = func() time.Time { return now }
+
+			for i := range tc.lags {
+				src.Status.Autoscaling = nil
+				if i == len(tc.lags)-1 {
+					src.Status.Autoscaling = tc.status
+				}
+				assert.Equal(t, settings.PollingInterval, a.Autoscale(src, settings, &tc.lags[i]))
+				now = now.Add(10 * time.Second)
+			}
+			assert.Equal(t, tc.want, src.Status.Autoscaling)
+
+			a.Forget(src.GetKey())
+			assert.Empty(t, a.samples)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+)
+
+// UpdateClaimsStatus sets the claims of the source from the claims notified
+// by its pods, indexed by pod IP.
+func UpdateClaimsStatus(src *v1beta1.KafkaSource, podsClaims map[string]interface{}, pods []*corev1.Pod) {
+	src.Status.UpdateConsumerGroupStatus(stringifyClaimsStatus(podsClaims))
+	src.Status.UpdateClaimedPartitions(ClaimedPartitions(podsClaims, pods))
+}
+
+// ClaimedPartitions returns the partitions of each topic claimed by the pods,
+// sorted by topic and partition. The pods are identified by name when they
+// are listed, by IP otherwise.
+func ClaimedPartitions(podsClaims map[string]interface{}, pods []*corev1.Pod) []v1beta1.TopicClaims {
+	podNames := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" {
+			podNames[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	partitions := make(map[string][]v1beta1.PartitionClaim)
+	for podIP, claims := range podsClaims {
+		pod, ok := podNames[podIP]
+		if !ok {
+			pod = podIP
+		}
+		for topic, ids := range claims.(kafkasourcecontrol.Claims) {
+			for _, id := range ids {
+				partitions[topic] = append(partitions[topic], v1beta1.PartitionClaim{Partition: id, Pod: pod})
+			}
+		}
+	}
+
+	topics := make([]v1beta1.TopicClaims, 0, len(partitions))
+	for topic, claims := range partitions {
+		sort.Slice(claims, func(i, j int) bool {
+			if claims[i].Partition != claims[j].Partition {
+				return claims[i].Partition < claims[j].Partition
+			}
+			return claims[i].Pod < claims[j].Pod
+		})
+		topics = append(topics, v1beta1.TopicClaims{Topic: topic, Partitions: claims})
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Topic < topics[j].Topic
+	})
+
+	if len(topics) == 0 {
+		return nil
+	}
+	return topics
+}
+
+func stringifyClaimsStatus(status map[string]interface{}) string {
+	strs := make([]string, 0, len(status))
+	for podIp, claims := range status {
+		strs = append(strs, fmt.Sprintf("Pod %s: %v", podIp, claims))
+	}
+	return strings.Join(strs, "\n")
+}
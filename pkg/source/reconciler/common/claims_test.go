@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+)
+
+func TestClaimedPartitions(t *testing.T) {
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "adapter-0"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "adapter-1"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "adapter-2"}},
+	}
+
+	testCases := map[string]struct {
+		podsClaims map[string]interface{}
+		want       []v1beta1.TopicClaims
+	}{
+		"no claims": {
+			podsClaims: map[string]interface{}{"10.0.0.1": kafkasourcecontrol.Claims{}},
+		},
+		"claims of several pods": {
+			podsClaims: map[string]interface{}{
+				"10.0.0.1": kafkasourcecontrol.Claims{"topic2": {1}, "topic1": {2, 0}},
+				"10.0.0.2": kafkasourcecontrol.Claims{"topic1": {1}},
+				"10.0.0.3": kafkasourcecontrol.Claims{"topic2": {0}},
+			},
+			want: []v1beta1.TopicClaims{{
+				Topic: "topic1",
+				Partitions: []v1beta1.PartitionClaim{
+					{Partition: 0, Pod: "adapter-0"},
+					{Partition: 1, Pod: "adapter-1"},
+					{Partition: 2, Pod: "adapter-0"},
+				},
+			}, {
+				Topic: "topic2",
+				Partitions: []v1beta1.PartitionClaim{
+					{Partition: 0, Pod: "10.0.0.3"},
+					{Partition: 1, Pod: "adapter-0"},
+				},
+			}},
+		},
+		"partition claimed by two pods during a rebalance": {
+			podsClaims: map[string]interface{}{
+				"10.0.0.2": kafkasourcecontrol.Claims{"topic1": {0}},
+				"10.0.0.1": kafkasourcecontrol.Claims{"topic1": {0}},
+			},
+			want: []v1beta1.TopicClaims{{
+				Topic: "topic1",
+				Partitions: []v1beta1.PartitionClaim{
+					{Partition: 0, Pod: "adapter-0"},
+					{Partition: 0, Pod: "adapter-1"},
+				},
+			}},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := ClaimedPartitions(tc.podsClaims, pods)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected claimed partitions (-want, +got):", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+// ResolveDeadLetterSink resolves the Spec.Delivery dead letter sink (if any) of the
+// KafkaSource into its Status.DeadLetterSinkURI for use by the receive adapters.
+func ResolveDeadLetterSink(ctx context.Context, sinkResolver *resolver.URIResolver, src *v1beta1.KafkaSource) error {
+	if src.Spec.Delivery == nil || src.Spec.Delivery.DeadLetterSink == nil {
+		src.Status.MarkDeadLetterSinkNotConfigured()
+		return nil
+	}
+
+	dest := src.Spec.Delivery.DeadLetterSink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = src.GetNamespace()
+	}
+	deadLetterSinkURI, err := sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	if err != nil {
+		src.Status.MarkDeadLetterSinkNotResolved("DeadLetterSinkNotFound",
+			"Failed to resolve the dead letter sink %s: %v", destinationName(dest), err)
+		return err
+	}
+	src.Status.MarkDeadLetterSinkResolved(deadLetterSinkURI)
+	return nil
+}
+
+// ResolveReplySink resolves the Spec.Reply sink (if any) of the KafkaSource
+// into its Status.ReplyURI for use by the receive adapters.
+func ResolveReplySink(ctx context.Context, sinkResolver *resolver.URIResolver, src *v1beta1.KafkaSource) error {
+	if src.Spec.Reply == nil || src.Spec.Reply.Sink == nil {
+		src.Status.MarkReplySinkNotConfigured()
+		return nil
+	}
+
+	dest := src.Spec.Reply.Sink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = src.GetNamespace()
+	}
+	replyURI, err := sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+	if err != nil {
+		src.Status.MarkReplySinkNotResolved("ReplySinkNotFound",
+			"Failed to resolve the reply sink %s: %v", destinationName(dest), err)
+		return err
+	}
+	src.Status.MarkReplySinkResolved(replyURI)
+	return nil
+}
+
+// destinationName returns a description of the destination for the status
+// messages: its reference, or else its URI.
+func destinationName(dest *duckv1.Destination) string {
+	if dest.Ref != nil {
+		return fmt.Sprintf("%s %s/%s", dest.Ref.Kind, dest.Ref.Namespace, dest.Ref.Name)
+	}
+	return dest.URI.String()
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestResolveDeliverySinks(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+	ctx = addressable.WithDuck(ctx)
+	sinkResolver := resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0))
+
+	resolvable := &duckv1.Destination{URI: apis.HTTP("example.com")}
+	unresolvable := &duckv1.Destination{URI: &apis.URL{Path: "/relative"}}
+
+	testCases := map[string]struct {
+		dls          *duckv1.Destination
+		reply        *duckv1.Destination
+		wantDLSURI   *apis.URL
+		wantReplyURI *apis.URL
+		wantStatus   map[apis.ConditionType]corev1.ConditionStatus
+		wantMessage  string
+	}{
+		"not configured": {
+			wantStatus: map[apis.ConditionType]corev1.ConditionStatus{
+				v1beta1.KafkaConditionDeadLetterSinkResolved: corev1.ConditionTrue,
+				v1beta1.KafkaConditionReplySinkResolved:      corev1.ConditionTrue,
+			},
+		},
+		"resolved": {
+			dls:          resolvable,
+			reply:        resolvable,
+			wantDLSURI:   resolvable.URI,
+			wantReplyURI: resolvable.URI,
+			wantStatus: map[apis.ConditionType]corev1.ConditionStatus{
+				v1beta1.KafkaConditionDeadLetterSinkResolved: corev1.ConditionTrue,
+				v1beta1.KafkaConditionReplySinkResolved:      corev1.ConditionTrue,
+			},
+		},
+		"dead letter sink not resolved": {
+			dls:          unresolvable,
+			reply:        resolvable,
+			wantReplyURI: resolvable.URI,
+			wantStatus: map[apis.ConditionType]corev1.ConditionStatus{
+				v1beta1.KafkaConditionDeadLetterSinkResolved: corev1.ConditionFalse,
+				v1beta1.KafkaConditionReplySinkResolved:      corev1.ConditionTrue,
+				v1beta1.KafkaConditionSinkProvided:           corev1.ConditionTrue,
+			},
+			wantMessage: "Failed to resolve the dead letter sink /relative: ",
+		},
+		"reply sink not resolved": {
+			dls:        resolvable,
+			reply:      unresolvable,
+			wantDLSURI: resolvable.URI,
+			wantStatus: map[apis.ConditionType]corev1.ConditionStatus{
+				v1beta1.KafkaConditionDeadLetterSinkResolved: corev1.ConditionTrue,
+				v1beta1.KafkaConditionReplySinkResolved:      corev1.ConditionFalse,
+				v1beta1.KafkaConditionSinkProvided:           corev1.ConditionTrue,
+			},
+			wantMessage: "Failed to resolve the reply sink /relative: ",
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{}
+			src.Namespace = "ns"
+			if tc.dls != nil {
+				src.Spec.Delivery = &eventingduckv1.DeliverySpec{DeadLetterSink: tc.dls}
+			}
+			if tc.reply != nil {
+				src.Spec.Reply = &v1beta1.ReplySpec{Sink: tc.reply}
+			}
+			src.Status.InitializeConditions()
+			src.Status.MarkSink(apis.HTTP("sink"))
+
+			dlsErr := ResolveDeadLetterSink(ctx, sinkResolver, src)
+			replyErr := ResolveReplySink(ctx, sinkResolver, src)
+			assert.Equal(t, tc.wantDLSURI == nil && tc.dls != nil, dlsErr != nil)
+			assert.Equal(t, tc.wantReplyURI == nil && tc.reply != nil, replyErr != nil)
+
+			assert.Equal(t, tc.wantDLSURI, src.Status.DeadLetterSinkURI)
+			assert.Equal(t, tc.wantReplyURI, src.Status.ReplyURI)
+			for condType, status := range tc.wantStatus {
+				cond := src.Status.GetCondition(condType)
+				assert.Equal(t, status, cond.Status, condType)
+				if status == corev1.ConditionFalse {
+					assert.True(t, strings.HasPrefix(cond.Message, tc.wantMessage), cond.Message)
+				}
+			}
+		})
+	}
+}
@@ -19,11 +19,14 @@ package common
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 
@@ -31,7 +34,25 @@ import (
 	"knative.dev/eventing-kafka/pkg/source/client"
 )
 
+// ConsumerGroupDeletionRetryPeriod is the period at which the deletion of a
+// consumer group which still has active members is retried.
+const ConsumerGroupDeletionRetryPeriod = 5 * time.Second
+
+// ConsumerGroupDeletionTimeout is the time since the deletion of the source
+// after which a consumer group which still has active members (e.g. shared
+// with another source) is given up on, so that the source can be deleted.
+const ConsumerGroupDeletionTimeout = 2 * time.Minute
+
+// consumerGroupNotDeleted is the reason of the event emitted when the consumer
+// group of a deleted source is given up on.
+const consumerGroupNotDeleted = "ConsumerGroupNotDeleted"
+
 func FinalizeKind(ctx context.Context, kubeClient kubernetes.Interface, src *v1beta1.KafkaSource) reconciler.Event {
+	if src.Spec.ConsumerGroupCleanup == v1beta1.ConsumerGroupCleanupRetain {
+		logging.FromContext(ctx).Infow("consumer group retained", zap.String("id", src.Spec.ConsumerGroup))
+		return nil
+	}
+
 	bs, config, err := client.NewConfigFromSpec(ctx, kubeClient, src)
 	if err != nil {
 		// Secrets are no longer valid. Not automatically recoverable.
@@ -50,7 +71,23 @@ func FinalizeKind(ctx context.Context, kubeClient kubernetes.Interface, src *v1b
 	}
 	defer c.Close()
 
-	if err := c.DeleteConsumerGroup(src.Spec.ConsumerGroup); err != nil && !errors.Is(sarama.ErrGroupIDNotFound, err) {
+	err = c.DeleteConsumerGroup(src.Spec.ConsumerGroup)
+	if errors.Is(err, sarama.ErrNonEmptyGroup) {
+		if src.DeletionTimestamp == nil || time.Since(src.DeletionTimestamp.Time) < ConsumerGroupDeletionTimeout {
+			// The receive adapters have not left the consumer group yet
+			logging.FromContext(ctx).Infow("consumer group still has active members, retrying", zap.String("id", src.Spec.ConsumerGroup))
+			return controller.NewRequeueAfter(ConsumerGroupDeletionRetryPeriod)
+		}
+
+		// The consumer group is still in use, most likely by another source
+		logging.FromContext(ctx).Warnw("consumer group still has active members, not deleting it", zap.String("id", src.Spec.ConsumerGroup))
+		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+			recorder.Eventf(src, corev1.EventTypeWarning, consumerGroupNotDeleted,
+				"Consumer group %q still has active members after %v, it was not deleted", src.Spec.ConsumerGroup, ConsumerGroupDeletionTimeout)
+		}
+		return nil
+	}
+	if err != nil && !errors.Is(sarama.ErrGroupIDNotFound, err) {
 		logging.FromContext(ctx).Errorw("unable to delete the consumer group", zap.Error(err))
 		return err
 	}
@@ -17,11 +17,16 @@ limitations under the License.
 package common
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
@@ -66,3 +71,116 @@ func TestFinalizer(t *testing.T) {
 	}
 
 }
+
+func TestFinalizerActiveMembers(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	deleteGroupsResponse := &sarama.DeleteGroupsResponse{
+		GroupErrorCodes: map[string]sarama.KError{group: sarama.ErrNonEmptyGroup},
+	}
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DeleteGroupsRequest": sarama.NewMockWrapper(deleteGroupsResponse),
+
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	testCases := map[string]struct {
+		deletedSince time.Duration
+		wantRetry    bool
+	}{
+		"recently deleted": {
+			deletedSince: time.Second,
+			wantRetry:    true,
+		},
+		"deleted for longer than the timeout": {
+			deletedSince: ConsumerGroupDeletionTimeout + time.Second,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := logtesting.TestContextWithLogger(t)
+			ctx, _ = fakekubeclient.With(ctx)
+			recorder := record.NewFakeRecorder(1)
+			ctx = controller.WithEventRecorder(ctx, recorder)
+
+			deletionTimestamp := metav1.NewTime(time.Now().Add(-tc.deletedSince))
+			src := &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deletionTimestamp},
+				Spec: v1beta1.KafkaSourceSpec{
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{broker.Addr()},
+					},
+					ConsumerGroup: group,
+				},
+			}
+			err := FinalizeKind(ctx, fakekubeclient.Get(ctx), src)
+			if tc.wantRetry {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != ConsumerGroupDeletionRetryPeriod {
+					t.Errorf("expected the deletion to be retried after %v, got: %v", ConsumerGroupDeletionRetryPeriod, err)
+				}
+				if len(recorder.Events) != 0 {
+					t.Errorf("unexpected event: %s", <-recorder.Events)
+				}
+				return
+			}
+			if err != nil {
+				t.Error("unexpected error: ", err)
+			}
+			if len(recorder.Events) != 1 {
+				t.Fatal("expected a warning event")
+			}
+			if event := <-recorder.Events; !strings.HasPrefix(event, "Warning "+consumerGroupNotDeleted) {
+				t.Errorf("expected a %s warning event, got: %s", consumerGroupNotDeleted, event)
+			}
+		})
+	}
+}
+
+func TestFinalizerRetain(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DeleteGroupsRequest": sarama.NewMockDeleteGroupsRequest(t).SetDeletedGroups([]string{group}),
+
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	ctx := logtesting.TestContextWithLogger(t)
+	ctx, _ = fakekubeclient.With(ctx)
+
+	src := &v1beta1.KafkaSource{
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{broker.Addr()},
+			},
+			ConsumerGroup:        group,
+			ConsumerGroupCleanup: v1beta1.ConsumerGroupCleanupRetain,
+		},
+	}
+	err := FinalizeKind(ctx, fakekubeclient.Get(ctx), src)
+	if err != nil {
+		t.Error("unexpected error: ", err)
+	}
+
+	for _, rr := range broker.History() {
+		if _, ok := rr.Request.(*sarama.DeleteGroupsRequest); ok {
+			t.Error("unexpected deletion of the retained consumer group")
+		}
+	}
+}
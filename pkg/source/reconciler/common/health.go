@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+// UpdatePlacementHealth sets the health of the adapter on the pods of the
+// placements of the source from the health notified by its pods, indexed by
+// pod IP.
+func UpdatePlacementHealth(src *v1beta1.KafkaSource, podsHealth map[string]interface{}, pods []*corev1.Pod) {
+	src.Status.UpdatePlacementHealth(PlacementHealth(podsHealth, pods, src.GetPlacements()))
+}
+
+// PlacementHealth returns the health notified by the pods of the placements,
+// sorted by pod name. The health notified by the pods without placement is
+// stale and ignored.
+func PlacementHealth(podsHealth map[string]interface{}, pods []*corev1.Pod, placements []duckv1alpha1.Placement) []v1beta1.PlacementHealth {
+	podNames := make(map[string]string, len(pods))
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" {
+			podNames[pod.Status.PodIP] = pod.Name
+		}
+	}
+
+	placed := make(map[string]bool, len(placements))
+	for _, placement := range placements {
+		if placement.VReplicas > 0 {
+			placed[placement.PodName] = true
+		}
+	}
+
+	health := make([]v1beta1.PlacementHealth, 0, len(podsHealth))
+	for podIP, h := range podsHealth {
+		pod, ok := podNames[podIP]
+		if !ok || !placed[pod] {
+			continue
+		}
+		sourceHealth := h.(kafkasourcecontrol.SourceHealth)
+		health = append(health, v1beta1.PlacementHealth{
+			PodName:     pod,
+			Dispatching: sourceHealth.Dispatching,
+			Restarts:    sourceHealth.Restarts,
+			Message:     sourceHealth.Message,
+		})
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].PodName < health[j].PodName
+	})
+
+	if len(health) == 0 {
+		return nil
+	}
+	return health
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
+)
+
+func TestPlacementHealth(t *testing.T) {
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-0"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}, Status: corev1.PodStatus{PodIP: "10.0.0.3"}},
+	}
+	placements := []duckv1alpha1.Placement{
+		{PodName: "pod-0", VReplicas: 1},
+		{PodName: "pod-1", VReplicas: 2},
+		{PodName: "pod-2", VReplicas: 0},
+	}
+
+	testCases := map[string]struct {
+		podsHealth map[string]interface{}
+		want       []v1beta1.PlacementHealth
+	}{
+		"no health": {},
+		"placements": {
+			podsHealth: map[string]interface{}{
+				"10.0.0.2": kafkasourcecontrol.SourceHealth{Restarts: 3, Message: "panic while handling messages"},
+				"10.0.0.1": kafkasourcecontrol.SourceHealth{Dispatching: true, Restarts: 1},
+			},
+			want: []v1beta1.PlacementHealth{
+				{PodName: "pod-0", Dispatching: true, Restarts: 1},
+				{PodName: "pod-1", Restarts: 3, Message: "panic while handling messages"},
+			},
+		},
+		"stale health": {
+			podsHealth: map[string]interface{}{
+				"10.0.0.1": kafkasourcecontrol.SourceHealth{Dispatching: true},
+				"10.0.0.3": kafkasourcecontrol.SourceHealth{Message: "stale"},
+				"10.0.0.4": kafkasourcecontrol.SourceHealth{Message: "unknown pod"},
+			},
+			want: []v1beta1.PlacementHealth{
+				{PodName: "pod-0", Dispatching: true},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got := PlacementHealth(tc.podsHealth, pods, placements)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected placement health (-want, +got):", diff)
+			}
+		})
+	}
+}
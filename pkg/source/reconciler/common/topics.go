@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/source/client"
+)
+
+// TopicPatternResyncPeriod is the period at which the topics matched by a
+// KafkaSource Spec.TopicPattern are resolved again.
+const TopicPatternResyncPeriod = time.Minute
+
+// ResolveTopics returns the topics consumed by the KafkaSource. The topics matching
+// the Spec.TopicPattern (if any) are resolved against the cluster metadata and
+// listed in its Status.Topics.
+func ResolveTopics(kafkaClient sarama.Client, src *v1beta1.KafkaSource) ([]string, error) {
+	if src.Spec.TopicPattern == "" {
+		src.Status.Topics = nil
+		return src.Spec.Topics, nil
+	}
+
+	pattern, err := v1beta1.CompileTopicPattern(src.Spec.TopicPattern)
+	if err != nil {
+		return nil, err
+	}
+	topics, err := client.MatchTopics(kafkaClient, pattern)
+	if err != nil {
+		return nil, err
+	}
+	src.Status.Topics = topics
+	return topics, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+func TestResolveTopics(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	metadataResponse := sarama.NewMockMetadataResponse(t).
+		SetController(broker.BrokerID()).
+		SetBroker(broker.Addr(), broker.BrokerID()).
+		SetLeader("tenant-a", 0, broker.BrokerID()).
+		SetLeader("tenant-b", 0, broker.BrokerID()).
+		SetLeader("other", 0, broker.BrokerID())
+
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": metadataResponse,
+	})
+
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_0_0_0
+	kafkaClient, err := sarama.NewClient([]string{broker.Addr()}, config)
+	require.Nil(t, err)
+	defer kafkaClient.Close()
+
+	testCases := map[string]struct {
+		spec       v1beta1.KafkaSourceSpec
+		wantTopics []string
+		wantStatus []string
+		wantErr    bool
+	}{
+		"topics": {
+			spec:       v1beta1.KafkaSourceSpec{Topics: []string{"other,tenant-a"}},
+			wantTopics: []string{"other,tenant-a"},
+		},
+		"topic pattern": {
+			spec:       v1beta1.KafkaSourceSpec{TopicPattern: "tenant-.*"},
+			wantTopics: []string{"tenant-a", "tenant-b"},
+			wantStatus: []string{"tenant-a", "tenant-b"},
+		},
+		"invalid topic pattern": {
+			spec:    v1beta1.KafkaSourceSpec{TopicPattern: "tenant-(.*"},
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{
+				Spec: tc.spec,
+				Status: v1beta1.KafkaSourceStatus{
+					Topics: []string{"stale"},
+				},
+			}
+			topics, err := ResolveTopics(kafkaClient, src)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tc.wantTopics, topics)
+			assert.Equal(t, tc.wantStatus, src.Status.Topics)
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"knative.dev/pkg/apis/duck"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	nodeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/node"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -37,10 +38,14 @@ import (
 
 	"knative.dev/eventing/pkg/reconciler/source"
 
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+
 	sourcesv1beta1 "knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	kafkaclient "knative.dev/eventing-kafka/pkg/client/injection/client"
 	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 	duckv1alpha1 "knative.dev/eventing/pkg/apis/duck/v1alpha1"
 	scheduler "knative.dev/eventing/pkg/scheduler"
 	stsscheduler "knative.dev/eventing/pkg/scheduler/statefulset"
@@ -69,6 +74,7 @@ func NewController(
 
 	kafkaInformer := kafkainformer.Get(ctx)
 	nodeInformer := nodeinformer.Get(ctx)
+	podInformer := podinformer.Get(ctx)
 
 	c := &Reconciler{
 		KubeClientSet:                 kubeclient.Get(ctx),
@@ -77,10 +83,16 @@ func NewController(
 		configs:                       source.WatchConfigurations(ctx, component, cmw),
 		VReplicaMPS:                   env.VReplicaMPS,
 		MaxEventPerSecondPerPartition: env.MaxEventPerSecondPerPartition,
+		podLister:                     podInformer.Lister(),
+		connectionPool:                ctrlreconciler.NewInsecureControlPlaneConnectionPool(),
+		autoscaler:                    common.NewAutoscaler(env.VReplicaMPS),
 	}
 
 	impl := kafkasource.NewImpl(ctx, c)
 
+	c.claimsNotificationStore = ctrlreconciler.NewNotificationStore(impl.EnqueueKey, kafkasourcecontrol.SourceClaimsParser)
+	c.healthNotificationStore = ctrlreconciler.NewNotificationStore(impl.EnqueueKey, kafkasourcecontrol.SourceHealthParser)
+
 	c.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
 
 	// Use a different set of conditions
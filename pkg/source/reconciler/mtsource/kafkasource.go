@@ -22,16 +22,21 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
 	pkgreconciler "knative.dev/pkg/reconciler"
@@ -40,12 +45,17 @@ import (
 
 	"knative.dev/eventing/pkg/reconciler/source"
 
+	ctrl "knative.dev/control-protocol/pkg"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	ctrlservice "knative.dev/control-protocol/pkg/service"
+
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/client/clientset/versioned"
 	reconcilerkafkasource "knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
 	listers "knative.dev/eventing-kafka/pkg/client/listers/sources/v1beta1"
 	"knative.dev/eventing-kafka/pkg/common/kafka/offset"
 	"knative.dev/eventing-kafka/pkg/source/client"
+	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 	"knative.dev/eventing/pkg/scheduler"
 )
@@ -66,6 +76,12 @@ type Reconciler struct {
 
 	VReplicaMPS                   int32
 	MaxEventPerSecondPerPartition int32
+
+	podLister               corev1listers.PodLister
+	connectionPool          ctrlreconciler.ControlPlaneConnectionPool
+	claimsNotificationStore *ctrlreconciler.NotificationStore
+	healthNotificationStore *ctrlreconciler.NotificationStore
+	autoscaler              *common.Autoscaler
 }
 
 // Check that our Reconciler implements Interface
@@ -95,6 +111,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	src.Status.MarkSink(sinkURI)
 
+	if err := common.ResolveDeadLetterSink(ctx, r.sinkResolver, src); err != nil {
+		return fmt.Errorf("getting dead letter sink URI: %v", err)
+	}
+
+	if err := common.ResolveReplySink(ctx, r.sinkResolver, src); err != nil {
+		return fmt.Errorf("getting reply sink URI: %v", err)
+	}
+
 	src.Status.Selector = "control-plane=kafkasource-mt-adapter"
 
 	if val, ok := src.GetLabels()[v1beta1.KafkaKeyTypeLabel]; ok {
@@ -140,7 +164,20 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	defer kafkaAdminClient.Close()
 
-	totalPartitions, err := offset.InitOffsets(ctx, c, kafkaAdminClient, src.Spec.Topics, src.Spec.ConsumerGroup)
+	topics, err := common.ResolveTopics(c, src)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve the topic pattern", zap.Error(err))
+		src.Status.MarkInitialOffsetNotCommitted("TopicPatternNotResolved", "Unable to resolve the topic pattern: %v", err)
+		return err
+	}
+
+	initialOffset, err := src.Spec.InitialOffset.ParseSaramaOffsetTime(time.Now())
+	if err != nil {
+		src.Status.MarkInitialOffsetNotCommitted("InvalidInitialOffset", "Unable to parse the initial offset: %v", err)
+		return err
+	}
+
+	totalPartitions, err := offset.InitOffsets(ctx, c, kafkaAdminClient, topics, src.Spec.ConsumerGroup, initialOffset)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to initialize consumergroup offsets", zap.Error(err))
 		src.Status.MarkInitialOffsetNotCommitted("OffsetsNotCommitted", "Unable to initialize consumergroup offsets: %v", err)
@@ -152,12 +189,37 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		src.Status.MaxAllowedVReplicas = &maxVReplicas
 	}
 
+	autoscalingInterval := r.autoscaler.Reconcile(ctx, c, kafkaAdminClient, topics, src)
+
 	// Finally, schedule the source
 	if err := r.reconcileMTReceiveAdapter(src); err != nil {
 		return err
 	}
 
-	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src)
+	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src, topics)
+
+	// Update consumer group status
+	pods, err := r.reconcileControlConnections(ctx)
+	if err != nil {
+		return err
+	}
+	srcNamespacedName := types.NamespacedName{Name: src.Name, Namespace: src.Namespace}
+	if lastClaimStatus, ok := r.claimsNotificationStore.GetPodsNotifications(srcNamespacedName); ok {
+		common.UpdateClaimsStatus(src, lastClaimStatus, pods)
+	}
+	if lastHealth, ok := r.healthNotificationStore.GetPodsNotifications(srcNamespacedName); ok {
+		common.UpdatePlacementHealth(src, lastHealth, pods)
+	}
+
+	// Periodically resolve the topics matched by the topic pattern again,
+	// and measure the lag of autoscaled sources
+	requeueAfter := autoscalingInterval
+	if src.Spec.TopicPattern != "" && (requeueAfter == 0 || common.TopicPatternResyncPeriod < requeueAfter) {
+		requeueAfter = common.TopicPatternResyncPeriod
+	}
+	if requeueAfter != 0 {
+		return controller.NewRequeueAfter(requeueAfter)
+	}
 
 	return nil
 }
@@ -170,6 +232,11 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource)
 		return errors.New("placement list was not empty")
 	}
 
+	srcNamespacedName := types.NamespacedName{Namespace: src.Namespace, Name: src.Name}
+	r.claimsNotificationStore.CleanPodsNotifications(srcNamespacedName)
+	r.healthNotificationStore.CleanPodsNotifications(srcNamespacedName)
+	r.autoscaler.Forget(srcNamespacedName)
+
 	return common.FinalizeKind(ctx, r.KubeClientSet, src)
 }
 
@@ -193,6 +260,85 @@ func (r *Reconciler) reconcileMTReceiveAdapter(src *v1beta1.KafkaSource) error {
 	return nil
 }
 
+// reconcileControlConnections connects to the control servers of the adapter
+// pods, which notify the claims and the health of the sources they run, and
+// returns the pods.
+func (r *Reconciler) reconcileControlConnections(ctx context.Context) ([]*corev1.Pod, error) {
+	pods, err := r.podLister.Pods(system.Namespace()).List(labels.SelectorFromSet(labels.Set{"control-plane": mtadapterName}))
+	if err != nil {
+		return nil, fmt.Errorf("error listing the adapter pods: %w", err)
+	}
+
+	podIPs := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.PodIP != "" {
+			podIPs = append(podIPs, pod.Status.PodIP)
+		}
+	}
+
+	_, err = r.connectionPool.ReconcileConnections(
+		ctx,
+		mtadapterName,
+		podIPs,
+		func(newHost string, service ctrl.Service) {
+			service.MessageHandler(ctrlservice.MessageRouter{
+				kafkasourcecontrol.NotifySetupSourceClaimsOpCode:   r.sourceClaimsHandler(newHost, kafkasourcecontrol.ClaimsMerger),
+				kafkasourcecontrol.NotifyCleanupSourceClaimsOpCode: r.sourceClaimsHandler(newHost, kafkasourcecontrol.ClaimsDifference),
+				kafkasourcecontrol.NotifySourceHealthOpCode:        r.sourceHealthHandler(newHost),
+			})
+		},
+		func(oldHost string) {
+			sources, err := r.kafkaLister.List(labels.Everything())
+			if err != nil {
+				logging.FromContext(ctx).Errorw("unable to list the sources", zap.Error(err))
+				return
+			}
+			for _, src := range sources {
+				srcNamespacedName := types.NamespacedName{Name: src.Name, Namespace: src.Namespace}
+				r.claimsNotificationStore.CleanPodNotification(srcNamespacedName, oldHost)
+				r.healthNotificationStore.CleanPodNotification(srcNamespacedName, oldHost)
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while reconciling connections: %w", err)
+	}
+
+	return pods, nil
+}
+
+// sourceClaimsHandler stores the claims of the source of the notification,
+// notified by the given adapter pod.
+func (r *Reconciler) sourceClaimsHandler(host string, valueMerger ctrlreconciler.ValueMerger) ctrl.MessageHandler {
+	return ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		var sourceClaims kafkasourcecontrol.SourceClaims
+		if err := sourceClaims.UnmarshalBinary(message.Payload()); err != nil {
+			logging.FromContext(ctx).Errorw("cannot parse the source claims", zap.Error(err))
+			message.AckWithError(err)
+			return
+		}
+
+		srcNamespacedName := types.NamespacedName{Name: sourceClaims.Name, Namespace: sourceClaims.Namespace}
+		r.claimsNotificationStore.MessageHandler(srcNamespacedName, host, valueMerger).HandleServiceMessage(ctx, message)
+	})
+}
+
+// sourceHealthHandler stores the health of the source of the notification,
+// notified by the given adapter pod.
+func (r *Reconciler) sourceHealthHandler(host string) ctrl.MessageHandler {
+	return ctrl.MessageHandlerFunc(func(ctx context.Context, message ctrl.ServiceMessage) {
+		var sourceHealth kafkasourcecontrol.SourceHealth
+		if err := sourceHealth.UnmarshalBinary(message.Payload()); err != nil {
+			logging.FromContext(ctx).Errorw("cannot parse the source health", zap.Error(err))
+			message.AckWithError(err)
+			return
+		}
+
+		srcNamespacedName := types.NamespacedName{Name: sourceHealth.Name, Namespace: sourceHealth.Namespace}
+		r.healthNotificationStore.MessageHandler(srcNamespacedName, host, ctrlreconciler.PassNewValue).HandleServiceMessage(ctx, message)
+	})
+}
+
 func (r *Reconciler) vpodLister() ([]scheduler.VPod, error) {
 	sources, err := r.kafkaLister.List(labels.Everything())
 	if err != nil {
@@ -205,15 +351,21 @@ func (r *Reconciler) vpodLister() ([]scheduler.VPod, error) {
 	return vpods, nil
 }
 
-func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource) []duckv1.CloudEventAttributes {
-	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(src.Spec.Topics))
-	for i := range src.Spec.Topics {
-		topics := strings.Split(src.Spec.Topics[i], ",")
+func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource, srcTopics []string) []duckv1.CloudEventAttributes {
+	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(srcTopics))
+	for i := range srcTopics {
+		topics := strings.Split(srcTopics[i], ",")
 		for _, topic := range topics {
 			ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
 				Type:   v1beta1.KafkaEventType,
 				Source: v1beta1.KafkaEventSource(src.Namespace, src.Name, topic),
 			})
+			if src.Spec.Tombstones != nil && src.Spec.Tombstones.Policy == v1beta1.TombstonePolicyEmit {
+				ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
+					Type:   src.Spec.Tombstones.EventType(),
+					Source: v1beta1.KafkaEventSource(src.Namespace, src.Name, topic),
+				})
+			}
 		}
 	}
 	return ceAttributes
@@ -37,6 +37,7 @@ import (
 	kafkainformer "knative.dev/eventing-kafka/pkg/client/injection/informers/sources/v1beta1/kafkasource"
 	"knative.dev/eventing-kafka/pkg/client/injection/reconciler/sources/v1beta1/kafkasource"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
 )
 
 func NewController(
@@ -64,6 +65,7 @@ func NewController(
 		configs:             WatchConfigurations(ctx, component, cmw),
 		podIpGetter:         ctrlreconciler.PodIpGetter{Lister: podInformer.Lister()},
 		connectionPool:      ctrlreconciler.NewInsecureControlPlaneConnectionPool(),
+		autoscaler:          common.NewAutoscaler(-1),
 	}
 
 	impl := kafkasource.NewImpl(ctx, c)
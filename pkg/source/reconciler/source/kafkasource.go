@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"k8s.io/apimachinery/pkg/labels"
@@ -44,6 +45,7 @@ import (
 	ctrlservice "knative.dev/control-protocol/pkg/service"
 
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 	"knative.dev/eventing-kafka/pkg/source/client"
 	kafkasourcecontrol "knative.dev/eventing-kafka/pkg/source/control"
 	"knative.dev/eventing-kafka/pkg/source/reconciler/source/resources"
@@ -113,6 +115,7 @@ type Reconciler struct {
 	podIpGetter             ctrlreconciler.PodIpGetter
 	connectionPool          ctrlreconciler.ControlPlaneConnectionPool
 	claimsNotificationStore *ctrlreconciler.NotificationStore
+	autoscaler              *common.Autoscaler
 }
 
 // Check that our Reconciler implements Interface
@@ -146,6 +149,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	src.Status.MarkSink(sinkURI)
 
+	if err := common.ResolveDeadLetterSink(ctx, r.sinkResolver, src); err != nil {
+		return fmt.Errorf("getting dead letter sink URI: %v", err)
+	}
+
+	if err := common.ResolveReplySink(ctx, r.sinkResolver, src); err != nil {
+		return fmt.Errorf("getting reply sink URI: %v", err)
+	}
+
 	selector, err := resources.GetLabelsAsSelector(src.Name)
 	if err != nil {
 		return fmt.Errorf("getting labels as selector: %v", err)
@@ -168,6 +179,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 
+	// The receive adapter only runs with a service account allowing the
+	// audience of the token presented to the sink
+	if auth := src.Spec.SinkAuth; auth != nil && auth.ServiceAccountToken != nil {
+		if err := sinkauth.CheckServiceAccountTokenSpec(ctx, r.KubeClientSet, src.Namespace, auth.ServiceAccountToken); err != nil {
+			src.Status.MarkNotDeployed("SinkTokenNotAllowed", "%v", err)
+			if err := r.deleteReceiveAdapter(ctx, src); err != nil && !apierrors.IsNotFound(err) {
+				logging.FromContext(ctx).Error("Unable to delete receiver adapter when the sink token is not allowed", zap.Error(err))
+			}
+			return err
+		}
+	}
+
 	// Validate configuration and offsets
 	bs, config, err := client.NewConfigFromSpec(ctx, r.KubeClientSet, src)
 	if err != nil {
@@ -195,7 +218,20 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	defer kafkaAdminClient.Close()
 
-	_, err = offset.InitOffsets(ctx, c, kafkaAdminClient, src.Spec.Topics, src.Spec.ConsumerGroup)
+	topics, err := common.ResolveTopics(c, src)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("unable to resolve the topic pattern", zap.Error(err))
+		src.Status.MarkInitialOffsetNotCommitted("TopicPatternNotResolved", "Unable to resolve the topic pattern: %v", err)
+		return err
+	}
+
+	initialOffset, err := src.Spec.InitialOffset.ParseSaramaOffsetTime(time.Now())
+	if err != nil {
+		src.Status.MarkInitialOffsetNotCommitted("InvalidInitialOffset", "Unable to parse the initial offset: %v", err)
+		return err
+	}
+
+	_, err = offset.InitOffsets(ctx, c, kafkaAdminClient, topics, src.Spec.ConsumerGroup, initialOffset)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("unable to initialize consumergroup offsets", zap.Error(err))
 		src.Status.MarkInitialOffsetNotCommitted("OffsetsNotCommitted", "Unable to initialize consumergroup offsets: %v", err)
@@ -203,6 +239,16 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	}
 	src.Status.MarkInitialOffsetCommitted()
 
+	// Scale the receive adapter from the lag of the consumer group, unless KEDA
+	// scales it
+	autoscalingInterval := time.Duration(0)
+	if src.IsNativelyAutoscaled() {
+		autoscalingInterval = r.autoscaler.Reconcile(ctx, c, kafkaAdminClient, topics, src)
+	} else {
+		src.Status.Autoscaling = nil
+		r.autoscaler.Forget(src.GetKey())
+	}
+
 	// TODO(mattmoor): create KafkaBinding for the receive adapter.
 
 	ra, err := r.createReceiveAdapter(ctx, src, sinkURI)
@@ -218,7 +264,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 		}
 	}
 	src.Status.MarkDeployed(ra)
-	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src)
+	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src, topics)
 
 	logging.FromContext(ctx).Debugf("we have a RA deployment")
 
@@ -266,7 +312,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1beta1.KafkaSource
 	// Update consumer group status
 	lastClaimStatus, ok := r.claimsNotificationStore.GetPodsNotifications(srcNamespacedName)
 	if ok {
-		src.Status.UpdateConsumerGroupStatus(stringifyClaimsStatus(lastClaimStatus))
+		pods, err := r.podIpGetter.Lister.Pods(src.Namespace).List(labels.Set(resources.GetLabels(src.Name)).AsSelector())
+		if err != nil {
+			return fmt.Errorf("error listing receive adapter pods %q: %v", ra.Name, err)
+		}
+		common.UpdateClaimsStatus(src, lastClaimStatus, pods)
+	}
+
+	// Periodically resolve the topics matched by the topic pattern again,
+	// and measure the lag of autoscaled sources
+	requeueAfter := autoscalingInterval
+	if src.Spec.TopicPattern != "" && (requeueAfter == 0 || common.TopicPatternResyncPeriod < requeueAfter) {
+		requeueAfter = common.TopicPatternResyncPeriod
+	}
+	if requeueAfter != 0 {
+		return controller.NewRequeueAfter(requeueAfter)
 	}
 
 	return nil
@@ -280,8 +340,13 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, src *v1beta1.KafkaSource)
 		Namespace: src.Namespace,
 		Name:      src.Name,
 	})
+	r.autoscaler.Forget(src.GetKey())
 
-	if err := r.deleteReceiveAdapter(ctx, src); !apierrors.IsNotFound(err) {
+	// Delete the consumer group on a later pass, once the deleted receive
+	// adapter is gone and its consumers have left the group.
+	if err := r.deleteReceiveAdapter(ctx, src); err == nil {
+		return controller.NewRequeueAfter(common.ConsumerGroupDeletionRetryPeriod)
+	} else if !apierrors.IsNotFound(err) {
 		return err
 	}
 
@@ -296,6 +361,12 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1beta1.Kafk
 		SinkURI:        sinkURI.String(),
 		AdditionalEnvs: r.configs.ToEnvVars(),
 	}
+	if src.Status.DeadLetterSinkURI != nil {
+		raArgs.DeadLetterSinkURI = src.Status.DeadLetterSinkURI.String()
+	}
+	if src.Status.ReplyURI != nil {
+		raArgs.ReplyURI = src.Status.ReplyURI.String()
+	}
 	expected := resources.MakeReceiveAdapter(&raArgs)
 
 	ra, err := r.KubeClientSet.AppsV1().Deployments(src.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
@@ -358,15 +429,21 @@ func podSpecChanged(oldPodSpec corev1.PodSpec, newPodSpec corev1.PodSpec) bool {
 	return false
 }
 
-func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource) []duckv1.CloudEventAttributes {
-	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(src.Spec.Topics))
-	for i := range src.Spec.Topics {
-		topics := strings.Split(src.Spec.Topics[i], ",")
+func (r *Reconciler) createCloudEventAttributes(src *v1beta1.KafkaSource, srcTopics []string) []duckv1.CloudEventAttributes {
+	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(srcTopics))
+	for i := range srcTopics {
+		topics := strings.Split(srcTopics[i], ",")
 		for _, topic := range topics {
 			ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
 				Type:   v1beta1.KafkaEventType,
 				Source: v1beta1.KafkaEventSource(src.Namespace, src.Name, topic),
 			})
+			if src.Spec.Tombstones != nil && src.Spec.Tombstones.Policy == v1beta1.TombstonePolicyEmit {
+				ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
+					Type:   src.Spec.Tombstones.EventType(),
+					Source: v1beta1.KafkaEventSource(src.Namespace, src.Name, topic),
+				})
+			}
 		}
 	}
 	return ceAttributes
@@ -378,11 +455,3 @@ func derefReplicas(i *int32) int32 {
 	}
 	return *i
 }
-
-func stringifyClaimsStatus(status map[string]interface{}) string {
-	strs := make([]string, 0, len(status))
-	for podIp, claims := range status {
-		strs = append(strs, fmt.Sprintf("Pod %s: %v", podIp, claims))
-	}
-	return strings.Join(strs, "\n")
-}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlreconciler "knative.dev/control-protocol/pkg/reconciler"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	"knative.dev/pkg/kmeta"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	controlprotocoltesting "knative.dev/eventing-kafka/pkg/common/controlprotocol/testing"
+	"knative.dev/eventing-kafka/pkg/source/reconciler/common"
+)
+
+func TestFinalizeKind(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+
+	group := "my-group"
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DeleteGroupsRequest": sarama.NewMockDeleteGroupsRequest(t).SetDeletedGroups([]string{group}),
+
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorGroup, group, broker),
+
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+	})
+
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "source",
+			UID:       "1234",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{broker.Addr()},
+			},
+			ConsumerGroup: group,
+		},
+	}
+	deploymentName := kmeta.ChildName("kafkasource-source-", string(src.UID))
+
+	ctx := logtesting.TestContextWithLogger(t)
+	ctx, kubeClient := fakekubeclient.With(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: src.Namespace, Name: deploymentName},
+	})
+
+	connectionPool := &controlprotocoltesting.MockConnectionPool{}
+	connectionPool.On("RemoveAllConnections", mock.Anything, string(src.UID)).Return()
+
+	r := &Reconciler{
+		KubeClientSet:           kubeClient,
+		connectionPool:          connectionPool,
+		claimsNotificationStore: ctrlreconciler.NewNotificationStore(func(types.NamespacedName) {}, nil),
+		autoscaler:              common.NewAutoscaler(0),
+	}
+
+	// The first pass deletes the receive adapter and waits for it to leave the consumer group
+	err := r.FinalizeKind(ctx, src)
+	if ok, delay := controller.IsRequeueKey(err); !ok || delay != common.ConsumerGroupDeletionRetryPeriod {
+		t.Errorf("expected the finalization to be retried after %v, got: %v", common.ConsumerGroupDeletionRetryPeriod, err)
+	}
+	_, err = kubeClient.AppsV1().Deployments(src.Namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the receive adapter to be deleted, got: %v", err)
+	}
+	if deleteGroupsRequests(broker) != 0 {
+		t.Error("unexpected deletion of the consumer group before the receive adapter is gone")
+	}
+
+	// The requeued pass deletes the consumer group
+	if err := r.FinalizeKind(ctx, src); err != nil {
+		t.Error("unexpected error: ", err)
+	}
+	if deleteGroupsRequests(broker) != 1 {
+		t.Error("expected the consumer group to be deleted")
+	}
+}
+
+func deleteGroupsRequests(broker *sarama.MockBroker) int {
+	count := 0
+	for _, rr := range broker.History() {
+		if _, ok := rr.Request.(*sarama.DeleteGroupsRequest); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestReconcileKindSinkTokenNotAllowed(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "source",
+			UID:       "1234",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			SinkAuth: &v1beta1.SinkAuthSpec{
+				ServiceAccountToken: &v1beta1.ServiceAccountTokenSpec{ServiceAccountName: "sa", Audience: "gateway"},
+			},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{URI: apis.HTTP("gateway")},
+			},
+		},
+	}
+	deploymentName := kmeta.ChildName("kafkasource-source-", string(src.UID))
+
+	ctx := logtesting.TestContextWithLogger(t)
+	ctx, _ = fakedynamicclient.With(ctx, runtime.NewScheme())
+	ctx = addressable.WithDuck(ctx)
+	ctx, kubeClient := fakekubeclient.With(ctx,
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: src.Namespace, Name: deploymentName},
+		},
+		// The service account only allows tokens for another audience
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   src.Namespace,
+				Name:        "sa",
+				Annotations: map[string]string{v1beta1.ServiceAccountTokenAudiencesAnnotation: "other"},
+			},
+		},
+	)
+
+	r := &Reconciler{
+		KubeClientSet: kubeClient,
+		sinkResolver:  resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+	}
+
+	if err := r.ReconcileKind(ctx, src); err == nil {
+		t.Error("expected an error for a service account not allowing the audience")
+	}
+	if cond := src.Status.GetCondition(v1beta1.KafkaConditionDeployed); cond == nil || cond.Reason != "SinkTokenNotAllowed" {
+		t.Errorf("expected the receive adapter not to be deployed, got: %v", cond)
+	}
+	_, err := kubeClient.AppsV1().Deployments(src.Namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the receive adapter to be deleted, got: %v", err)
+	}
+}
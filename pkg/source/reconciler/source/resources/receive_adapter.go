@@ -19,6 +19,7 @@ package resources
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -26,16 +27,26 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	"knative.dev/eventing-kafka/pkg/common/sinkauth"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/pkg/kmeta"
 )
 
+const (
+	// The projected service account token sent to the sink.
+	sinkTokenVolumeName = "sink-token"
+	sinkTokenMountPath  = "/var/run/secrets/knative.dev/sink"
+	sinkTokenPath       = "token"
+)
+
 type ReceiveAdapterArgs struct {
-	Image          string
-	Source         *v1beta1.KafkaSource
-	Labels         map[string]string
-	SinkURI        string
-	AdditionalEnvs []corev1.EnvVar
+	Image             string
+	Source            *v1beta1.KafkaSource
+	Labels            map[string]string
+	SinkURI           string
+	DeadLetterSinkURI string
+	ReplyURI          string
+	AdditionalEnvs    []corev1.EnvVar
 }
 
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
@@ -72,6 +83,20 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		})
 	}
 
+	if args.Source.Spec.TopicPattern != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_TOPIC_PATTERN",
+			Value: args.Source.Spec.TopicPattern,
+		})
+	}
+
+	if args.Source.Spec.DetectStructuredCloudEvents {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_DETECT_STRUCTURED_CLOUDEVENTS",
+			Value: "true",
+		})
+	}
+
 	if args.Source.Spec.InitialOffset != "" {
 		env = append(env, corev1.EnvVar{
 			Name:  "KAFKA_INITIAL_OFFSET",
@@ -79,12 +104,76 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		})
 	}
 
+	if args.Source.Spec.IsolationLevel != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "KAFKA_ISOLATION_LEVEL",
+			Value: string(args.Source.Spec.IsolationLevel),
+		})
+	}
+
+	if args.Source.Spec.ConsumerConfig != nil {
+		// Cannot fail.
+		consumerConfigJson, _ := json.Marshal(args.Source.Spec.ConsumerConfig)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_CONSUMER_CONFIG", Value: string(consumerConfigJson)})
+	}
+
 	if args.Source.Spec.CloudEventOverrides != nil {
 		// Cannot fail.
 		ceJson, _ := json.Marshal(args.Source.Spec.CloudEventOverrides)
 		env = append(env, corev1.EnvVar{Name: adapter.EnvConfigCEOverrides, Value: string(ceJson)})
 	}
 
+	if args.Source.Spec.Deserializer != nil {
+		// Cannot fail.
+		deserializerJson, _ := json.Marshal(args.Source.Spec.Deserializer)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_DESERIALIZER", Value: string(deserializerJson)})
+	}
+
+	if args.Source.Spec.CloudEventMapping != nil {
+		// Cannot fail.
+		mappingJson, _ := json.Marshal(args.Source.Spec.CloudEventMapping)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_CLOUDEVENT_MAPPING", Value: string(mappingJson)})
+	}
+
+	if args.Source.Spec.HeaderMapping != nil {
+		// Cannot fail.
+		headerMappingJson, _ := json.Marshal(args.Source.Spec.HeaderMapping)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_HEADER_MAPPING", Value: string(headerMappingJson)})
+	}
+
+	if args.Source.Spec.Tombstones != nil {
+		// Cannot fail.
+		tombstonesJson, _ := json.Marshal(args.Source.Spec.Tombstones)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_TOMBSTONES", Value: string(tombstonesJson)})
+	}
+
+	if args.Source.Spec.Batching != nil {
+		// Cannot fail.
+		batchingJson, _ := json.Marshal(args.Source.Spec.Batching)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_BATCHING", Value: string(batchingJson)})
+	}
+
+	if args.Source.Spec.Delivery != nil {
+		// The dead letter sink is resolved by the controller.
+		delivery := args.Source.Spec.Delivery.DeepCopy()
+		delivery.DeadLetterSink = nil
+		// Cannot fail.
+		deliveryJson, _ := json.Marshal(delivery)
+		env = append(env, corev1.EnvVar{Name: "KAFKA_DELIVERY", Value: string(deliveryJson)})
+	}
+
+	if args.DeadLetterSinkURI != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_DEAD_LETTER_SINK", Value: args.DeadLetterSinkURI})
+	}
+
+	if args.ReplyURI != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_REPLY_SINK", Value: args.ReplyURI})
+	}
+
+	if args.Source.Spec.Reply != nil && args.Source.Spec.Reply.Topic != "" {
+		env = append(env, corev1.EnvVar{Name: "KAFKA_REPLY_TOPIC", Value: args.Source.Spec.Reply.Topic})
+	}
+
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_USER", args.Source.Spec.Net.SASL.User.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_PASSWORD", args.Source.Spec.Net.SASL.Password.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_SASL_TYPE", args.Source.Spec.Net.SASL.Type.SecretKeyRef)
@@ -92,6 +181,30 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_KEY", args.Source.Spec.Net.TLS.Key.SecretKeyRef)
 	env = appendEnvFromSecretKeyRef(env, "KAFKA_NET_TLS_CA_CERT", args.Source.Spec.Net.TLS.CACert.SecretKeyRef)
 
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	var serviceAccountName string
+	if auth := args.Source.Spec.SinkAuth; auth != nil {
+		if auth.BearerToken != nil {
+			env = appendEnvFromSecretKeyRef(env, sinkauth.TokenEnvVar, auth.BearerToken.SecretKeyRef)
+		}
+		if auth.ServiceAccountToken != nil {
+			serviceAccountName = auth.ServiceAccountToken.ServiceAccountName
+			volumes = append(volumes, sinkTokenVolume(auth.ServiceAccountToken))
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      sinkTokenVolumeName,
+				MountPath: sinkTokenMountPath,
+				ReadOnly:  true,
+			})
+			env = append(env, corev1.EnvVar{Name: sinkauth.TokenFileEnvVar, Value: path.Join(sinkTokenMountPath, sinkTokenPath)})
+		}
+		if auth.TLS != nil {
+			env = appendEnvFromSecretKeyRef(env, sinkauth.TLSCertEnvVar, auth.TLS.Cert.SecretKeyRef)
+			env = appendEnvFromSecretKeyRef(env, sinkauth.TLSKeyEnvVar, auth.TLS.Key.SecretKeyRef)
+			env = appendEnvFromSecretKeyRef(env, sinkauth.TLSCACertEnvVar, auth.TLS.CACert.SecretKeyRef)
+		}
+	}
+
 	return &v1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      kmeta.ChildName(fmt.Sprintf("kafkasource-%s-", args.Source.Name), string(args.Source.GetUID())),
@@ -105,12 +218,13 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 			Selector: &metav1.LabelSelector{
 				MatchLabels: args.Labels,
 			},
-			Replicas: args.Source.Spec.Consumers,
+			Replicas: replicas(args.Source),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: args.Labels,
 				},
 				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccountName,
 					Containers: []corev1.Container{
 						{
 							Name:  "receive-adapter",
@@ -121,14 +235,49 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 								{Name: "profiling", ContainerPort: 8008},
 								{Name: "control", ContainerPort: 9000},
 							},
+							VolumeMounts: volumeMounts,
 						},
 					},
+					Volumes: volumes,
 				},
 			},
 		},
 	}
 }
 
+// sinkTokenVolume returns the volume projecting the service account token
+// sent to the sink.
+func sinkTokenVolume(spec *v1beta1.ServiceAccountTokenSpec) corev1.Volume {
+	expirationSeconds := v1beta1.DefaultServiceAccountTokenExpirationSeconds
+	if spec.ExpirationSeconds != nil {
+		expirationSeconds = *spec.ExpirationSeconds
+	}
+	return corev1.Volume{
+		Name: sinkTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          spec.Audience,
+						ExpirationSeconds: &expirationSeconds,
+						Path:              sinkTokenPath,
+					},
+				}},
+			},
+		},
+	}
+}
+
+// replicas returns the replicas decided by the autoscaler when the source is
+// autoscaled, or Spec.Consumers otherwise.
+func replicas(src *v1beta1.KafkaSource) *int32 {
+	if src.Status.Autoscaling != nil {
+		replicas := src.Status.Autoscaling.Replicas
+		return &replicas
+	}
+	return src.Spec.Consumers
+}
+
 // appendEnvFromSecretKeyRef returns env with an EnvVar appended
 // setting key to the secret and key described by ref.
 // If ref is nil, env is returned unchanged.
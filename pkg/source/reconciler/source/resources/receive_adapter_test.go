@@ -26,6 +26,8 @@ import (
 
 	bindingsv1beta1 "knative.dev/eventing-kafka/pkg/apis/bindings/v1beta1"
 	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/ptr"
 )
@@ -613,3 +615,601 @@ func TestMakeReceiveAdapterKeyType(t *testing.T) {
 		t.Errorf("unexpected deploy (-want, +got) = %v", diff)
 	}
 }
+
+func TestMakeReceiveAdapterDelivery(t *testing.T) {
+	backoffPolicy := eventingduckv1.BackoffPolicyExponential
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "dls"},
+				},
+				Retry:         ptr.Int32(3),
+				BackoffPolicy: &backoffPolicy,
+				BackoffDelay:  ptr.String("PT0.2S"),
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:             "test-image",
+		Source:            src,
+		SinkURI:           "sink-uri",
+		DeadLetterSinkURI: "dead-letter-sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantDelivery := `{"retry":3,"backoffPolicy":"exponential","backoffDelay":"PT0.2S"}`
+	if env["KAFKA_DELIVERY"] != wantDelivery {
+		t.Errorf("unexpected KAFKA_DELIVERY, want %q, got %q", wantDelivery, env["KAFKA_DELIVERY"])
+	}
+	if env["KAFKA_DEAD_LETTER_SINK"] != "dead-letter-sink-uri" {
+		t.Errorf("unexpected KAFKA_DEAD_LETTER_SINK, want %q, got %q", "dead-letter-sink-uri", env["KAFKA_DEAD_LETTER_SINK"])
+	}
+	if src.Spec.Delivery.DeadLetterSink == nil {
+		t.Errorf("expected the source delivery spec to be left unchanged")
+	}
+}
+
+func TestMakeReceiveAdapterDeserializer(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Deserializer: &v1beta1.DeserializerSpec{
+				SchemaRegistryURL: "http://schema-registry:8081",
+				Value:             v1beta1.SchemaFormatProtobuf,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantDeserializer := `{"schemaRegistryUrl":"http://schema-registry:8081","value":"protobuf"}`
+	if env["KAFKA_DESERIALIZER"] != wantDeserializer {
+		t.Errorf("unexpected KAFKA_DESERIALIZER, want %q, got %q", wantDeserializer, env["KAFKA_DESERIALIZER"])
+	}
+}
+
+func TestMakeReceiveAdapterTopicPattern(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			TopicPattern: "tenant-.*",
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+		},
+		Status: v1beta1.KafkaSourceStatus{
+			Topics: []string{"tenant-a", "tenant-b"},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	// The adapter resolves the topic pattern itself
+	if env["KAFKA_TOPIC_PATTERN"] != "tenant-.*" {
+		t.Errorf("unexpected KAFKA_TOPIC_PATTERN, want %q, got %q", "tenant-.*", env["KAFKA_TOPIC_PATTERN"])
+	}
+	if env["KAFKA_TOPICS"] != "" {
+		t.Errorf("unexpected KAFKA_TOPICS, want %q, got %q", "", env["KAFKA_TOPICS"])
+	}
+}
+
+func TestMakeReceiveAdapterCloudEventMapping(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			CloudEventMapping: &v1beta1.CloudEventMappingSpec{
+				Type: "com.example.{{ .Value.kind }}",
+				ID:   "{{ .Topic }}/{{ .Partition }}/{{ .Offset }}",
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantMapping := `{"type":"com.example.{{ .Value.kind }}","id":"{{ .Topic }}/{{ .Partition }}/{{ .Offset }}"}`
+	if env["KAFKA_CLOUDEVENT_MAPPING"] != wantMapping {
+		t.Errorf("unexpected KAFKA_CLOUDEVENT_MAPPING, want %q, got %q", wantMapping, env["KAFKA_CLOUDEVENT_MAPPING"])
+	}
+}
+
+func TestMakeReceiveAdapterHeaderMapping(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			HeaderMapping: &v1beta1.HeaderMappingSpec{
+				Deny:   []string{"Authorization"},
+				Rename: map[string]string{"X-Tenant-Id": "tenant"},
+				Base64: true,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantMapping := `{"deny":["Authorization"],"rename":{"X-Tenant-Id":"tenant"},"base64":true}`
+	if env["KAFKA_HEADER_MAPPING"] != wantMapping {
+		t.Errorf("unexpected KAFKA_HEADER_MAPPING, want %q, got %q", wantMapping, env["KAFKA_HEADER_MAPPING"])
+	}
+}
+
+func TestMakeReceiveAdapterDetectStructuredCloudEvents(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup:               "group",
+			InitialOffset:               v1beta1.OffsetLatest,
+			DetectStructuredCloudEvents: true,
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	if env["KAFKA_DETECT_STRUCTURED_CLOUDEVENTS"] != "true" {
+		t.Errorf("unexpected KAFKA_DETECT_STRUCTURED_CLOUDEVENTS, want %q, got %q", "true", env["KAFKA_DETECT_STRUCTURED_CLOUDEVENTS"])
+	}
+}
+
+func TestMakeReceiveAdapterIsolationLevel(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup:  "group",
+			InitialOffset:  v1beta1.OffsetLatest,
+			IsolationLevel: v1beta1.IsolationLevelReadCommitted,
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	if env["KAFKA_ISOLATION_LEVEL"] != "read_committed" {
+		t.Errorf("unexpected KAFKA_ISOLATION_LEVEL, want %q, got %q", "read_committed", env["KAFKA_ISOLATION_LEVEL"])
+	}
+}
+
+func TestMakeReceiveAdapterConsumerConfig(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			ConsumerConfig: &v1beta1.ConsumerConfigSpec{
+				Fetch:             &v1beta1.FetchSpec{Default: 4096},
+				SessionTimeout:    ptr.String("PT20S"),
+				RebalanceStrategy: v1beta1.RebalanceStrategySticky,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	want := `{"fetch":{"default":4096},"sessionTimeout":"PT20S","rebalanceStrategy":"sticky"}`
+	if env["KAFKA_CONSUMER_CONFIG"] != want {
+		t.Errorf("unexpected KAFKA_CONSUMER_CONFIG, want %q, got %q", want, env["KAFKA_CONSUMER_CONFIG"])
+	}
+}
+
+func TestMakeReceiveAdapterTombstones(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Tombstones: &v1beta1.TombstoneSpec{
+				Policy: v1beta1.TombstonePolicyEmit,
+				Type:   "com.example.delete",
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantTombstones := `{"policy":"emit","type":"com.example.delete"}`
+	if env["KAFKA_TOMBSTONES"] != wantTombstones {
+		t.Errorf("unexpected KAFKA_TOMBSTONES, want %q, got %q", wantTombstones, env["KAFKA_TOMBSTONES"])
+	}
+}
+
+func TestMakeReceiveAdapterBatching(t *testing.T) {
+	maxWait := "PT0.5S"
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Batching: &v1beta1.BatchingSpec{
+				MaxEvents: 100,
+				MaxBytes:  1048576,
+				MaxWait:   &maxWait,
+			},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	env := map[string]string{}
+	for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+
+	wantBatching := `{"maxEvents":100,"maxBytes":1048576,"maxWait":"PT0.5S"}`
+	if env["KAFKA_BATCHING"] != wantBatching {
+		t.Errorf("unexpected KAFKA_BATCHING, want %q, got %q", wantBatching, env["KAFKA_BATCHING"])
+	}
+}
+
+func TestMakeReceiveAdapterReply(t *testing.T) {
+	testCases := map[string]struct {
+		reply    *v1beta1.ReplySpec
+		replyURI string
+		wantEnv  map[string]string
+	}{
+		"reply sink": {
+			reply: &v1beta1.ReplySpec{
+				Sink: &duckv1.Destination{
+					Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "replies"},
+				},
+			},
+			replyURI: "reply-uri",
+			wantEnv:  map[string]string{"KAFKA_REPLY_SINK": "reply-uri", "KAFKA_REPLY_TOPIC": ""},
+		},
+		"reply topic": {
+			reply:   &v1beta1.ReplySpec{Topic: "replies"},
+			wantEnv: map[string]string{"KAFKA_REPLY_SINK": "", "KAFKA_REPLY_TOPIC": "replies"},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-name",
+					Namespace: "source-namespace",
+				},
+				Spec: v1beta1.KafkaSourceSpec{
+					Topics: []string{"topic1,topic2"},
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{"server1,server2"},
+					},
+					ConsumerGroup: "group",
+					InitialOffset: v1beta1.OffsetLatest,
+					Reply:         tc.reply,
+				},
+			}
+
+			got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+				Image:    "test-image",
+				Source:   src,
+				SinkURI:  "sink-uri",
+				ReplyURI: tc.replyURI,
+			})
+
+			env := map[string]string{}
+			for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+				env[envVar.Name] = envVar.Value
+			}
+
+			for name, want := range tc.wantEnv {
+				if env[name] != want {
+					t.Errorf("unexpected %s, want %q, got %q", name, want, env[name])
+				}
+			}
+		})
+	}
+}
+
+func TestMakeReceiveAdapterAutoscaled(t *testing.T) {
+	src := &v1beta1.KafkaSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+		},
+		Spec: v1beta1.KafkaSourceSpec{
+			Topics: []string{"topic1,topic2"},
+			KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+				BootstrapServers: []string{"server1,server2"},
+			},
+			ConsumerGroup: "group",
+			InitialOffset: v1beta1.OffsetLatest,
+			Consumers:     ptr.Int32(1),
+		},
+		Status: v1beta1.KafkaSourceStatus{
+			Autoscaling: &v1beta1.AutoscalingStatus{Replicas: 3},
+		},
+	}
+
+	got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:   "test-image",
+		Source:  src,
+		SinkURI: "sink-uri",
+	})
+
+	if got.Spec.Replicas == nil || *got.Spec.Replicas != 3 {
+		t.Errorf("unexpected replicas, want 3, got %v", got.Spec.Replicas)
+	}
+}
+
+func TestMakeReceiveAdapterSinkAuth(t *testing.T) {
+	secretKeyRef := func(key string) bindingsv1beta1.SecretValueFromSource {
+		return bindingsv1beta1.SecretValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "sink-credentials"},
+				Key:                  key,
+			},
+		}
+	}
+	bearerToken := secretKeyRef("token")
+
+	testCases := map[string]struct {
+		sinkAuth           *v1beta1.SinkAuthSpec
+		wantEnv            map[string]string
+		wantSecretEnv      map[string]string
+		wantServiceAccount string
+		wantVolumes        []corev1.Volume
+	}{
+		"no sink auth": {
+			wantEnv: map[string]string{"K_SINK_AUTH_TOKEN_FILE": ""},
+		},
+		"bearer token and client certificate": {
+			sinkAuth: &v1beta1.SinkAuthSpec{
+				BearerToken: &bearerToken,
+				TLS: &v1beta1.SinkTLSSpec{
+					Cert:   secretKeyRef("tls.crt"),
+					Key:    secretKeyRef("tls.key"),
+					CACert: secretKeyRef("ca.crt"),
+				},
+			},
+			wantSecretEnv: map[string]string{
+				"K_SINK_AUTH_TOKEN":  "token",
+				"K_SINK_TLS_CERT":    "tls.crt",
+				"K_SINK_TLS_KEY":     "tls.key",
+				"K_SINK_TLS_CA_CERT": "ca.crt",
+			},
+		},
+		"service account token": {
+			sinkAuth: &v1beta1.SinkAuthSpec{
+				ServiceAccountToken: &v1beta1.ServiceAccountTokenSpec{
+					ServiceAccountName: "sink-client",
+					Audience:           "gateway",
+					ExpirationSeconds:  ptr.Int64(600),
+				},
+			},
+			wantEnv:            map[string]string{"K_SINK_AUTH_TOKEN_FILE": "/var/run/secrets/knative.dev/sink/token"},
+			wantServiceAccount: "sink-client",
+			wantVolumes: []corev1.Volume{{
+				Name: "sink-token",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          "gateway",
+								ExpirationSeconds: ptr.Int64(600),
+								Path:              "token",
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1beta1.KafkaSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-name",
+					Namespace: "source-namespace",
+				},
+				Spec: v1beta1.KafkaSourceSpec{
+					Topics: []string{"topic1,topic2"},
+					KafkaAuthSpec: bindingsv1beta1.KafkaAuthSpec{
+						BootstrapServers: []string{"server1,server2"},
+					},
+					ConsumerGroup: "group",
+					InitialOffset: v1beta1.OffsetLatest,
+					SinkAuth:      tc.sinkAuth,
+				},
+			}
+
+			got := MakeReceiveAdapter(&ReceiveAdapterArgs{
+				Image:   "test-image",
+				Source:  src,
+				SinkURI: "sink-uri",
+			})
+
+			env := map[string]string{}
+			secretEnv := map[string]string{}
+			for _, envVar := range got.Spec.Template.Spec.Containers[0].Env {
+				env[envVar.Name] = envVar.Value
+				if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil && envVar.ValueFrom.SecretKeyRef.Name == "sink-credentials" {
+					secretEnv[envVar.Name] = envVar.ValueFrom.SecretKeyRef.Key
+				}
+			}
+
+			for name, want := range tc.wantEnv {
+				if env[name] != want {
+					t.Errorf("unexpected %s, want %q, got %q", name, want, env[name])
+				}
+			}
+			if len(tc.wantSecretEnv) != len(secretEnv) {
+				t.Errorf("unexpected secret env, want %v, got %v", tc.wantSecretEnv, secretEnv)
+			}
+			for name, want := range tc.wantSecretEnv {
+				if secretEnv[name] != want {
+					t.Errorf("unexpected secret key of %s, want %q, got %q", name, want, secretEnv[name])
+				}
+			}
+
+			podSpec := got.Spec.Template.Spec
+			if podSpec.ServiceAccountName != tc.wantServiceAccount {
+				t.Errorf("unexpected service account, want %q, got %q", tc.wantServiceAccount, podSpec.ServiceAccountName)
+			}
+			if diff, err := kmp.SafeDiff(tc.wantVolumes, podSpec.Volumes); err != nil {
+				t.Errorf("unexpected volumes (-want, +got) = %v", err)
+			} else if diff != "" {
+				t.Errorf("unexpected volumes (-want, +got) = %v", diff)
+			}
+			if len(podSpec.Volumes) != len(podSpec.Containers[0].VolumeMounts) {
+				t.Errorf("unexpected volume mounts %v", podSpec.Containers[0].VolumeMounts)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+// avroDecoder decodes Avro binary data to the Avro JSON encoding.
+type avroDecoder struct {
+	codec *goavro.Codec
+}
+
+func newAvroDecoder(schema *Schema) (*avroDecoder, error) {
+	if len(schema.References) > 0 {
+		return nil, errors.New("Avro schema references are not supported")
+	}
+	codec, err := goavro.NewCodec(schema.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return &avroDecoder{codec: codec}, nil
+}
+
+func (d *avroDecoder) decode(payload []byte) ([]byte, error) {
+	native, remaining, err := d.codec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(remaining) > 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes", len(remaining))
+	}
+	return d.codec.TextualFromNative(nil, native)
+}
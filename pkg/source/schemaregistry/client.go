@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// SchemaTypeAvro is the schema registry type of Avro schemas (the default when unspecified)
+	SchemaTypeAvro = "AVRO"
+
+	// SchemaTypeProtobuf is the schema registry type of Protobuf schemas
+	SchemaTypeProtobuf = "PROTOBUF"
+)
+
+// Schema is a schema registered in the schema registry.
+type Schema struct {
+	// SchemaType is the type of the schema, empty for Avro schemas.
+	SchemaType string `json:"schemaType,omitempty"`
+
+	// Schema is the schema definition.
+	Schema string `json:"schema"`
+
+	// References are the other schemas imported by this schema.
+	References []Reference `json:"references,omitempty"`
+}
+
+// Type returns the type of the schema, defaulting to Avro.
+func (s *Schema) Type() string {
+	if s.SchemaType == "" {
+		return SchemaTypeAvro
+	}
+	return s.SchemaType
+}
+
+// Reference is a reference from a schema to another (imported) registered schema.
+type Reference struct {
+	// Name is the name by which the schema is imported (e.g. the .proto file name).
+	Name string `json:"name"`
+
+	// Subject is the subject under which the referenced schema is registered.
+	Subject string `json:"subject"`
+
+	// Version is the version of the referenced schema within the subject.
+	Version int `json:"version"`
+}
+
+// temporaryError is an error reaching the schema registry which may not occur when retried.
+type temporaryError struct {
+	error
+}
+
+func (e temporaryError) Unwrap() error {
+	return e.error
+}
+
+// IsTemporary returns whether the error (or one it wraps) is caused by the schema registry being
+// unreachable, timing out or failing with a server error, rather than by the data or its schema.
+func IsTemporary(err error) bool {
+	var temporary temporaryError
+	return errors.As(err, &temporary)
+}
+
+// Client is a caching client of a Confluent compatible schema registry. Registered
+// schemas are immutable, and are therefore cached indefinitely.
+type Client struct {
+	url        string
+	httpClient *http.Client
+
+	schemasMu sync.RWMutex
+	schemas   map[string]*Schema
+}
+
+// NewClient returns a Client of the schema registry at the specified URL.
+func NewClient(registryURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		url:        strings.TrimSuffix(registryURL, "/"),
+		httpClient: httpClient,
+		schemas:    make(map[string]*Schema),
+	}
+}
+
+// SchemaURL returns the URL of the schema with the specified ID.
+func (c *Client) SchemaURL(id int) string {
+	return c.url + "/schemas/ids/" + strconv.Itoa(id)
+}
+
+// GetSchema returns the schema with the specified ID.
+func (c *Client) GetSchema(ctx context.Context, id int) (*Schema, error) {
+	return c.get(ctx, "/schemas/ids/"+strconv.Itoa(id))
+}
+
+// GetReference returns the schema referenced by the specified Reference.
+func (c *Client) GetReference(ctx context.Context, reference Reference) (*Schema, error) {
+	return c.get(ctx, "/subjects/"+url.PathEscape(reference.Subject)+"/versions/"+strconv.Itoa(reference.Version))
+}
+
+// get returns the (cached) schema at the specified registry path.
+func (c *Client) get(ctx context.Context, path string) (*Schema, error) {
+	c.schemasMu.RLock()
+	schema, ok := c.schemas[path]
+	c.schemasMu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json, application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, temporaryError{fmt.Errorf("failed to get schema '%s': %w", path, err)}
+	}
+	defer func() {
+		// Always try to read and close body so the connection can be reused afterwards
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		err := fmt.Errorf("failed to get schema '%s': %d %s", path, res.StatusCode, http.StatusText(res.StatusCode))
+		if res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests {
+			return nil, temporaryError{err}
+		}
+		return nil, err
+	}
+
+	schema = &Schema{}
+	if err := json.NewDecoder(res.Body).Decode(schema); err != nil {
+		return nil, fmt.Errorf("failed to decode schema '%s': %w", path, err)
+	}
+
+	c.schemasMu.Lock()
+	c.schemas[path] = schema
+	c.schemasMu.Unlock()
+	return schema, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry is a minimal stand-in for a Confluent compatible schema registry.
+type fakeRegistry struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	schemas  map[string]*Schema
+	requests map[string]int
+}
+
+func newFakeRegistry(t *testing.T, schemas map[string]*Schema) *fakeRegistry {
+	registry := &fakeRegistry{schemas: schemas, requests: map[string]int{}}
+	registry.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registry.mu.Lock()
+		registry.requests[r.URL.Path]++
+		schema, ok := registry.schemas[r.URL.Path]
+		registry.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		assert.Nil(t, json.NewEncoder(w).Encode(schema))
+	}))
+	t.Cleanup(registry.Close)
+	return registry
+}
+
+func (r *fakeRegistry) requestCount(path string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.requests[path]
+}
+
+func TestClient_GetSchema(t *testing.T) {
+	schema := &Schema{Schema: `"string"`}
+	registry := newFakeRegistry(t, map[string]*Schema{"/schemas/ids/1": schema})
+	client := NewClient(registry.URL+"/", nil)
+
+	// The Schema Is Only Requested Once
+	for i := 0; i < 2; i++ {
+		result, err := client.GetSchema(context.TODO(), 1)
+		require.Nil(t, err)
+		assert.Equal(t, schema, result)
+		assert.Equal(t, SchemaTypeAvro, result.Type())
+	}
+	assert.Equal(t, 1, registry.requestCount("/schemas/ids/1"))
+	assert.Equal(t, registry.URL+"/schemas/ids/1", client.SchemaURL(1))
+
+	// Unknown Schemas Are Not Cached
+	for i := 0; i < 2; i++ {
+		result, err := client.GetSchema(context.TODO(), 2)
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.False(t, IsTemporary(err))
+	}
+	assert.Equal(t, 2, registry.requestCount("/schemas/ids/2"))
+}
+
+func TestClient_GetSchemaTemporaryError(t *testing.T) {
+	testCases := map[string]struct {
+		status        int
+		wantTemporary bool
+	}{
+		"server error":      {status: http.StatusServiceUnavailable, wantTemporary: true},
+		"too many requests": {status: http.StatusTooManyRequests, wantTemporary: true},
+		"not found":         {status: http.StatusNotFound, wantTemporary: false},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			_, err := NewClient(server.URL, nil).GetSchema(context.TODO(), 1)
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.wantTemporary, IsTemporary(err))
+		})
+	}
+
+	// The Schema Registry Is Unreachable
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	_, err := NewClient(server.URL, nil).GetSchema(context.TODO(), 1)
+	assert.NotNil(t, err)
+	assert.True(t, IsTemporary(err))
+}
+
+func TestClient_GetReference(t *testing.T) {
+	schema := &Schema{SchemaType: SchemaTypeProtobuf, Schema: `syntax = "proto3";`}
+	registry := newFakeRegistry(t, map[string]*Schema{"/subjects/other-value/versions/3": schema})
+	client := NewClient(registry.URL, nil)
+
+	result, err := client.GetReference(context.TODO(), Reference{Name: "other.proto", Subject: "other-value", Version: 3})
+	require.Nil(t, err)
+	assert.Equal(t, schema, result)
+	assert.Equal(t, SchemaTypeProtobuf, result.Type())
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+const (
+	// The magic byte with which the Confluent wire format starts
+	wireFormatMagicByte = 0
+
+	// The length of the Confluent wire format header (magic byte and schema ID)
+	wireFormatHeaderLength = 5
+)
+
+var (
+	// ErrInvalidWireFormat is returned when the data does not use the Confluent wire format.
+	ErrInvalidWireFormat = errors.New("data is not in the schema registry wire format")
+)
+
+// decoder decodes the payload (without the wire format header) of data
+// encoded with a specific schema to JSON.
+type decoder interface {
+	decode(payload []byte) ([]byte, error)
+}
+
+// Deserializer converts Confluent wire format encoded data to JSON by resolving
+// its schema ID against the schema registry.
+type Deserializer struct {
+	client *Client
+	format v1beta1.SchemaFormat
+
+	decodersMu sync.Mutex
+	decoders   map[int]decoder
+}
+
+// NewDeserializer returns a Deserializer of data in the specified format.
+func NewDeserializer(client *Client, format v1beta1.SchemaFormat) (*Deserializer, error) {
+	switch format {
+	case v1beta1.SchemaFormatAvro, v1beta1.SchemaFormatProtobuf:
+	default:
+		return nil, fmt.Errorf("unsupported schema format '%s'", format)
+	}
+	return &Deserializer{
+		client:   client,
+		format:   format,
+		decoders: make(map[int]decoder),
+	}, nil
+}
+
+// Deserialize returns the JSON representation of the data as well as the URL of its schema.
+func (d *Deserializer) Deserialize(ctx context.Context, data []byte) ([]byte, string, error) {
+	if len(data) < wireFormatHeaderLength || data[0] != wireFormatMagicByte {
+		return nil, "", ErrInvalidWireFormat
+	}
+	id := int(binary.BigEndian.Uint32(data[1:wireFormatHeaderLength]))
+
+	dec, err := d.getDecoder(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	json, err := dec.decode(data[wireFormatHeaderLength:])
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode data with schema %d: %w", id, err)
+	}
+	return json, d.client.SchemaURL(id), nil
+}
+
+// getDecoder returns the (cached) decoder of the schema with the specified ID.
+func (d *Deserializer) getDecoder(ctx context.Context, id int) (decoder, error) {
+	d.decodersMu.Lock()
+	defer d.decodersMu.Unlock()
+
+	if dec, ok := d.decoders[id]; ok {
+		return dec, nil
+	}
+
+	schema, err := d.client.GetSchema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dec decoder
+	switch d.format {
+	case v1beta1.SchemaFormatAvro:
+		if schema.Type() != SchemaTypeAvro {
+			return nil, fmt.Errorf("schema %d is not an Avro schema: %s", id, schema.Type())
+		}
+		dec, err = newAvroDecoder(schema)
+	case v1beta1.SchemaFormatProtobuf:
+		if schema.Type() != SchemaTypeProtobuf {
+			return nil, fmt.Errorf("schema %d is not a Protobuf schema: %s", id, schema.Type())
+		}
+		dec, err = newProtobufDecoder(ctx, d.client, id, schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %d: %w", id, err)
+	}
+
+	d.decoders[id] = dec
+	return dec, nil
+}
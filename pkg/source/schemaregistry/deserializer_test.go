@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"knative.dev/eventing-kafka/pkg/apis/sources/v1beta1"
+)
+
+const (
+	avroSchema = `{
+  "type": "record",
+  "name": "Event",
+  "fields": [
+    {"name": "name", "type": "string"},
+    {"name": "count", "type": "int"},
+    {"name": "note", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+	protobufSchema = `syntax = "proto3";
+package test;
+
+import "other.proto";
+import "google/protobuf/timestamp.proto";
+
+message Outer {
+  message Inner {
+    string name = 1;
+  }
+  Inner inner = 1;
+}
+
+message Event {
+  string name = 1;
+  int32 count = 2;
+  other.Ref ref = 3;
+  google.protobuf.Timestamp time = 4;
+}
+`
+
+	otherProtobufSchema = `syntax = "proto3";
+package other;
+
+message Ref {
+  string id = 1;
+}
+`
+)
+
+// wireFormat prefixes the payload with the Confluent wire format header of the schema ID.
+func wireFormat(id uint32, payload []byte) []byte {
+	data := make([]byte, wireFormatHeaderLength, wireFormatHeaderLength+len(payload))
+	binary.BigEndian.PutUint32(data[1:], id)
+	return append(data, payload...)
+}
+
+// messageIndexes encodes the Confluent Protobuf message indexes.
+func messageIndexes(indexes ...int64) []byte {
+	data := binary.AppendVarint(nil, int64(len(indexes)))
+	for _, index := range indexes {
+		data = binary.AppendVarint(data, index)
+	}
+	return data
+}
+
+func TestDeserializer_Avro(t *testing.T) {
+	registry := newFakeRegistry(t, map[string]*Schema{
+		"/schemas/ids/1": {Schema: avroSchema},
+		"/schemas/ids/2": {SchemaType: SchemaTypeProtobuf, Schema: protobufSchema},
+	})
+	deserializer, err := NewDeserializer(NewClient(registry.URL, nil), v1beta1.SchemaFormatAvro)
+	require.Nil(t, err)
+
+	codec, err := goavro.NewCodec(avroSchema)
+	require.Nil(t, err)
+	payload, err := codec.BinaryFromNative(nil, map[string]interface{}{
+		"name":  "test",
+		"count": 42,
+		"note":  goavro.Union("string", "hello"),
+	})
+	require.Nil(t, err)
+
+	// The Schema Is Resolved And The Decoder Cached
+	for i := 0; i < 2; i++ {
+		json, dataSchema, err := deserializer.Deserialize(context.TODO(), wireFormat(1, payload))
+		require.Nil(t, err)
+		assert.JSONEq(t, `{"name":"test","count":42,"note":{"string":"hello"}}`, string(json))
+		assert.Equal(t, registry.URL+"/schemas/ids/1", dataSchema)
+	}
+	assert.Equal(t, 1, registry.requestCount("/schemas/ids/1"))
+
+	// Invalid Data
+	_, _, err = deserializer.Deserialize(context.TODO(), wireFormat(1, payload[:3]))
+	assert.NotNil(t, err)
+	_, _, err = deserializer.Deserialize(context.TODO(), wireFormat(1, append(payload, 0)))
+	assert.NotNil(t, err)
+
+	// Protobuf Schema
+	_, _, err = deserializer.Deserialize(context.TODO(), wireFormat(2, payload))
+	assert.NotNil(t, err)
+
+	// Unknown Schema
+	_, _, err = deserializer.Deserialize(context.TODO(), wireFormat(3, payload))
+	assert.NotNil(t, err)
+}
+
+func TestDeserializer_Protobuf(t *testing.T) {
+	registry := newFakeRegistry(t, map[string]*Schema{
+		"/schemas/ids/1": {
+			SchemaType: SchemaTypeProtobuf,
+			Schema:     protobufSchema,
+			References: []Reference{{Name: "other.proto", Subject: "other-value", Version: 1}},
+		},
+		"/subjects/other-value/versions/1": {SchemaType: SchemaTypeProtobuf, Schema: otherProtobufSchema},
+		"/schemas/ids/2":                   {Schema: avroSchema},
+	})
+	deserializer, err := NewDeserializer(NewClient(registry.URL, nil), v1beta1.SchemaFormatProtobuf)
+	require.Nil(t, err)
+
+	// Event{name: "test", count: 42, ref: {id: "ref-id"}}
+	var event []byte
+	event = protowire.AppendTag(event, 1, protowire.BytesType)
+	event = protowire.AppendString(event, "test")
+	event = protowire.AppendTag(event, 2, protowire.VarintType)
+	event = protowire.AppendVarint(event, 42)
+	var ref []byte
+	ref = protowire.AppendTag(ref, 1, protowire.BytesType)
+	ref = protowire.AppendString(ref, "ref-id")
+	event = protowire.AppendTag(event, 3, protowire.BytesType)
+	event = protowire.AppendBytes(event, ref)
+
+	// Inner{name: "inner"}
+	var inner []byte
+	inner = protowire.AppendTag(inner, 1, protowire.BytesType)
+	inner = protowire.AppendString(inner, "inner")
+
+	// Outer{inner: {name: "inner"}}
+	var outer []byte
+	outer = protowire.AppendTag(outer, 1, protowire.BytesType)
+	outer = protowire.AppendBytes(outer, inner)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "second message",
+			data: wireFormat(1, append(messageIndexes(1), event...)),
+			want: `{"name":"test","count":42,"ref":{"id":"ref-id"}}`,
+		},
+		{
+			name: "first message (empty indexes)",
+			data: wireFormat(1, append(messageIndexes(), outer...)),
+			want: `{"inner":{"name":"inner"}}`,
+		},
+		{
+			name: "nested message",
+			data: wireFormat(1, append(messageIndexes(0, 0), inner...)),
+			want: `{"name":"inner"}`,
+		},
+		{
+			name:    "unknown message index",
+			data:    wireFormat(1, append(messageIndexes(2), event...)),
+			wantErr: true,
+		},
+		{
+			name:    "invalid message indexes",
+			data:    wireFormat(1, []byte{0x80}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid payload",
+			data:    wireFormat(1, append(messageIndexes(1), 0xff)),
+			wantErr: true,
+		},
+		{
+			name:    "avro schema",
+			data:    wireFormat(2, append(messageIndexes(1), event...)),
+			wantErr: true,
+		},
+		{
+			name:    "invalid wire format",
+			data:    []byte{1, 2, 3},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			json, dataSchema, err := deserializer.Deserialize(context.TODO(), test.data)
+			if test.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			assert.JSONEq(t, test.want, string(json))
+			assert.Equal(t, registry.URL+"/schemas/ids/1", dataSchema)
+		})
+	}
+	assert.Equal(t, 1, registry.requestCount("/subjects/other-value/versions/1"))
+}
+
+func TestNewDeserializer_UnsupportedFormat(t *testing.T) {
+	deserializer, err := NewDeserializer(NewClient("http://registry", nil), "json")
+	assert.Nil(t, deserializer)
+	assert.NotNil(t, err)
+}
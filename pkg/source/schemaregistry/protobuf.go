@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	errInvalidMessageIndexes = errors.New("invalid message indexes")
+)
+
+// protobufDecoder decodes Protobuf binary data, prefixed with the Confluent
+// message indexes, to the Protobuf JSON mapping.
+type protobufDecoder struct {
+	file protoreflect.FileDescriptor
+}
+
+func newProtobufDecoder(ctx context.Context, client *Client, id int, schema *Schema) (*protobufDecoder, error) {
+	// Gather The .proto Sources Of The Schema And All Its (Transitive) References
+	rootName := fmt.Sprintf("schema-%d.proto", id)
+	sources := map[string]string{rootName: schema.Schema}
+	if err := resolveReferences(ctx, client, schema.References, sources); err != nil {
+		return nil, err
+	}
+
+	// Parse The Sources (Well-Known Imports Such As google/protobuf/timestamp.proto Are Built-In)
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(sources)}
+	fds, err := parser.ParseFiles(rootName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert The Parsed Descriptors Into A protoreflect FileDescriptor
+	set := &descriptorpb.FileDescriptorSet{}
+	addFileDescriptorProtos(fds[0], set, map[string]bool{})
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, err
+	}
+	file, err := files.FindFileByPath(rootName)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufDecoder{file: file}, nil
+}
+
+// resolveReferences adds the sources of the referenced schemas, by import name, to the sources map.
+func resolveReferences(ctx context.Context, client *Client, references []Reference, sources map[string]string) error {
+	for _, reference := range references {
+		if _, ok := sources[reference.Name]; ok {
+			continue
+		}
+		schema, err := client.GetReference(ctx, reference)
+		if err != nil {
+			return err
+		}
+		sources[reference.Name] = schema.Schema
+		if err := resolveReferences(ctx, client, schema.References, sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFileDescriptorProtos adds the file, after its dependencies, to the FileDescriptorSet.
+func addFileDescriptorProtos(fd *desc.FileDescriptor, set *descriptorpb.FileDescriptorSet, added map[string]bool) {
+	if added[fd.GetName()] {
+		return
+	}
+	added[fd.GetName()] = true
+	for _, dependency := range fd.GetDependencies() {
+		addFileDescriptorProtos(dependency, set, added)
+	}
+	set.File = append(set.File, fd.AsFileDescriptorProto())
+}
+
+func (d *protobufDecoder) decode(payload []byte) ([]byte, error) {
+	indexes, payload, err := readMessageIndexes(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := d.messageDescriptor(indexes)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(msg)
+}
+
+// readMessageIndexes reads the zig-zag varint encoded message indexes which identify the
+// (possibly nested) message type within the schema, returning the remaining payload.
+func readMessageIndexes(payload []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 || count > int64(len(payload)) {
+		return nil, nil, errInvalidMessageIndexes
+	}
+	payload = payload[n:]
+
+	// An Empty Array Is An Optimization For The Common Case Of The First Message Type
+	if count == 0 {
+		return []int{0}, payload, nil
+	}
+
+	indexes := make([]int, count)
+	for i := range indexes {
+		index, n := binary.Varint(payload)
+		if n <= 0 || index < 0 {
+			return nil, nil, errInvalidMessageIndexes
+		}
+		indexes[i] = int(index)
+		payload = payload[n:]
+	}
+	return indexes, payload, nil
+}
+
+// messageDescriptor returns the descriptor of the message type identified by the indexes.
+func (d *protobufDecoder) messageDescriptor(indexes []int) (protoreflect.MessageDescriptor, error) {
+	var md protoreflect.MessageDescriptor
+	messages := d.file.Messages()
+	for _, index := range indexes {
+		if index >= messages.Len() {
+			return nil, fmt.Errorf("message index %v not found in the schema", indexes)
+		}
+		md = messages.Get(index)
+		messages = md.Messages()
+	}
+	return md, nil
+}